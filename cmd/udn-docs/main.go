@@ -0,0 +1,24 @@
+// Generates docs/yudien_functions.md from the registered UDN function signatures (yudien/function_signatures.go), so the docs match the functions that are actually registered.  Run from the repo root after changing a signature.
+//
+//	udn-docs [-o docs/yudien_functions.md]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/ghowland/yudien/yudien"
+	"io/ioutil"
+	"os"
+)
+
+func main() {
+	// Written to a file, as importing yudien prints to stdout when it initializes
+	output_path := flag.String("o", "docs/yudien_functions.md", "File to write the docs to")
+	flag.Parse()
+
+	err := ioutil.WriteFile(*output_path, []byte(yudien.FormatUdnFunctionDocs()), 0644)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "udn-docs: %s\n", err)
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,73 @@
+// Rewrites UDN in canonical form, so hand edited UDN in stored functions and widget data diffs cleanly.  Each file (or stdin) holds either a UDN execution group JSON (udn_data_json), or a single UDN statement.
+//
+//	udn-format [-w] [-l] [file ...]
+//
+// Prints the formatted UDN, unless -w (write the result back to the file) or -l (list the files that are not formatted) is given.  Exits 1 if any file could not be parsed.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/ghowland/yudien/yudien"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+func main() {
+	write_files := flag.Bool("w", false, "Write the formatted UDN back to the file, instead of printing it")
+	list_files := flag.Bool("l", false, "List the files whose UDN is not already formatted")
+	flag.Parse()
+
+	filenames := flag.Args()
+	if len(filenames) == 0 {
+		filenames = []string{"-"}
+	}
+
+	failed := false
+
+	for _, filename := range filenames {
+		var source []byte
+		var err error
+
+		if filename == "-" {
+			source, err = ioutil.ReadAll(os.Stdin)
+		} else {
+			source, err = ioutil.ReadFile(filename)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "udn-format: %s\n", err)
+			os.Exit(2)
+		}
+
+		formatted, err := yudien.FormatSchemaUDNSet(strings.TrimSpace(string(source)))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", filename, err)
+			failed = true
+			continue
+		}
+		formatted += "\n"
+
+		if *list_files {
+			if formatted != string(source) {
+				fmt.Println(filename)
+			}
+		}
+
+		if *write_files && filename != "-" {
+			if formatted != string(source) {
+				err = ioutil.WriteFile(filename, []byte(formatted), 0644)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "udn-format: %s\n", err)
+					os.Exit(2)
+				}
+			}
+		} else if !*list_files {
+			fmt.Print(formatted)
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
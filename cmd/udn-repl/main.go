@@ -0,0 +1,250 @@
+// Executes UDN statements interactively, so a snippet can be tried without editing a widget or stored function and reloading a page.  Each line is a UDN statement, its result is printed as JSON, and udn_data is kept between statements.
+//
+//	udn-repl [-config config.json]
+//
+// The config has the same sections the host app passes to yudien.Configure: {"database": {...}, "databases": {...}, "logging": {...}, "authentication": {...}}.  Without a config there is no database, so only functions that dont query data will work.
+//
+// Lines starting with ":" are commands, see :help.
+package main
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/ghowland/yudien/yudien"
+	"github.com/ghowland/yudien/yudiencore"
+	"github.com/ghowland/yudien/yudiendata"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+type ReplConfig struct {
+	Database       yudiendata.DatabaseConfig            `json:"database"`
+	Databases      map[string]yudiendata.DatabaseConfig `json:"databases"`
+	Logging        yudien.LoggingConfig                 `json:"logging"`
+	Authentication yudien.AuthenticationConfig          `json:"authentication"`
+}
+
+const repl_help = `Enter a UDN statement to execute it, or a command:
+  :data              Print udn_data
+  :ast <udn>         Print the parsed UdnParts of a statement, without executing it
+  :load <file.json>  Execute a UDN execution group file (udn_data_json)
+  :trace             Toggle trace logging
+  :debug             Toggle the step debugger, which stops at the first function of each statement
+  :break <name|id>   Stop the debugger at a function name or UdnPart Id
+  :reset             Clear udn_data, and start a new udn_schema
+  :help              Print this help
+  :quit              Exit (or EOF)
+`
+
+func main() {
+	config_path := flag.String("config", "", "JSON config with the database, logging and authentication sections.  Without it there is no database.")
+	flag.Parse()
+
+	var db *sql.DB
+
+	if *config_path != "" {
+		config := ReplConfig{}
+
+		config_json, err := ioutil.ReadFile(*config_path)
+		if err == nil {
+			err = json.Unmarshal(config_json, &config)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "udn-repl: Config: %s\n", err)
+			os.Exit(2)
+		}
+
+		yudien.Configure(&config.Database, config.Databases, &config.Logging, &config.Authentication)
+
+		db, err = sql.Open("postgres", config.Database.ConnectOptions)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "udn-repl: Database: %s\n", err)
+			os.Exit(2)
+		}
+		defer db.Close()
+	}
+
+	udn_schema := _NewReplSchema(db)
+	udn_data := _NewReplData()
+
+	// The log level to go back to when :trace is turned off
+	previous_log_level := yudien.DefaultEngine.GetLogLevel()
+
+	fmt.Print("UDN REPL.  :help for commands\n")
+
+	// The debugger reads its commands from the same input as we do
+	input := bufio.NewReader(os.Stdin)
+
+	var debugger *yudien.UdnTerminalDebugger
+	debug_stepping := false
+
+	for {
+		fmt.Print("udn> ")
+
+		line, err := input.ReadString('\n')
+		if err != nil && line == "" {
+			fmt.Println()
+			break
+		}
+
+		line = strings.TrimSpace(line)
+		command, command_arg := line, ""
+		if index := strings.Index(line, " "); index != -1 {
+			command, command_arg = line[:index], strings.TrimSpace(line[index+1:])
+		}
+
+		switch {
+		case line == "":
+			continue
+
+		case command == ":quit" || command == ":exit":
+			return
+
+		case command == ":help":
+			fmt.Print(repl_help)
+
+		case command == ":data":
+			_PrintJson(udn_data)
+
+		case command == ":reset":
+			udn_schema = _NewReplSchema(db)
+			udn_data = _NewReplData()
+
+		case command == ":trace":
+			if yudien.DefaultEngine.GetLogLevel() == yudiencore.ParseUdnLogLevel("trace") {
+				yudien.DefaultEngine.SetLogLevel(previous_log_level)
+				fmt.Print("Trace: off\n")
+			} else {
+				previous_log_level = yudien.DefaultEngine.GetLogLevel()
+				yudien.DefaultEngine.SetLogLevel(yudiencore.ParseUdnLogLevel("trace"))
+				fmt.Print("Trace: on\n")
+			}
+
+		case command == ":debug":
+			debug_stepping = !debug_stepping
+			if debug_stepping && debugger == nil {
+				debugger = yudien.NewUdnTerminalDebugger(input, os.Stdout)
+			}
+			fmt.Printf("Debug: %v\n", debug_stepping)
+
+		case command == ":break":
+			if debugger == nil {
+				debugger = yudien.NewUdnTerminalDebugger(input, os.Stdout)
+			}
+			debugger.Breakpoints[command_arg] = true
+
+		case command == ":ast":
+			udn_part, err := yudien.ParseUdnString(db, udn_schema, command_arg)
+			if err != nil {
+				fmt.Printf("Parse Error: %s\n", err)
+				continue
+			}
+
+			fmt.Print(yudien.DescribeUdnPart(udn_part))
+
+		case command == ":load":
+			udn_data_json, err := ioutil.ReadFile(command_arg)
+			if err != nil {
+				fmt.Printf("Load: %s\n", err)
+				continue
+			}
+
+			// A broken file panics in ProcessSchemaUDNSet, which shouldnt end the session
+			_StartReplExecution(udn_schema, debugger, debug_stepping)
+			_PrintResult(udn_schema, _LoadExecutionGroup(db, udn_schema, string(udn_data_json), udn_data))
+
+		case strings.HasPrefix(command, ":"):
+			fmt.Printf("Unknown command: %s  (:help for commands)\n", command)
+
+		default:
+			_StartReplExecution(udn_schema, debugger, debug_stepping)
+			_PrintResult(udn_schema, yudien.ProcessUDN(db, udn_schema, []string{line}, udn_data))
+		}
+	}
+}
+
+// udn_schema for a new session, with the database's UDN configuration if there is a database
+func _NewReplSchema(db *sql.DB) map[string]interface{} {
+	if db != nil {
+		return yudien.PrepareSchemaUDN(db)
+	}
+
+	return yudien.NewUdnSchema()
+}
+
+// Every statement is its own execution: it gets a new budget, and doesnt start with an error left by an earlier statement (ex: one that aborted, or a :load that panicked)
+func _StartReplExecution(udn_schema map[string]interface{}, debugger *yudien.UdnTerminalDebugger, debug_stepping bool) {
+	yudien.ClearUdnError(udn_schema)
+	yudien.StartUdnExecution(context.Background(), udn_schema, yudien.GetUdnEngine(udn_schema).Limits)
+
+	_AttachDebugger(udn_schema, debugger, debug_stepping)
+}
+
+// udn_data for a new session.  It has a function stack frame for the whole session, like a request does, so __set_temp/__get_temp and __let work between statements.
+func _NewReplData() map[string]interface{} {
+	udn_data := make(map[string]interface{})
+	yudien.PushUdnFunctionStack(udn_data)
+
+	return udn_data
+}
+
+// Attach the debugger for the next execution, if there is one.  When stepping, it stops at the first function, otherwise only at breakpoints.
+func _AttachDebugger(udn_schema map[string]interface{}, debugger *yudien.UdnTerminalDebugger, debug_stepping bool) {
+	if debugger == nil {
+		return
+	}
+
+	if debug_stepping {
+		debugger.Step()
+	} else {
+		debugger.Continue()
+	}
+
+	yudien.SetUdnDebugger(udn_schema, debugger)
+}
+
+func _LoadExecutionGroup(db *sql.DB, udn_schema map[string]interface{}, udn_data_json string, udn_data map[string]interface{}) (result interface{}) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			fmt.Printf("Load: %v\n", recovered)
+			result = nil
+		}
+	}()
+
+	return yudien.ProcessSchemaUDNSet(db, udn_schema, udn_data_json, udn_data)
+}
+
+// Print the result, or the uncaught error and the functions it unwound through
+func _PrintResult(udn_schema map[string]interface{}, result interface{}) {
+	udn_error := yudien.GetUdnError(udn_schema)
+	if udn_error == nil {
+		_PrintJson(result)
+		return
+	}
+
+	fmt.Printf("Error: %s: %s\n", udn_error["function"], udn_error["message"])
+
+	function_stack, _ := udn_error["function_stack"].([]interface{})
+	for _, frame := range function_stack {
+		frame_map, _ := frame.(map[string]interface{})
+		fmt.Printf("    %s  %s\n", frame_map["function"], frame_map["args"])
+	}
+
+	yudien.ClearUdnError(udn_schema)
+}
+
+func _PrintJson(value interface{}) {
+	output, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		// Not everything in udn_data can be JSON encoded, so fall back to Go's formatting
+		fmt.Printf("%v\n", value)
+		return
+	}
+
+	fmt.Println(string(output))
+}
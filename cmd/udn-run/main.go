@@ -0,0 +1,228 @@
+// Runs a UDN execution group file (udn_data_json, the [][][]string that ProcessSchemaUDNSet takes) without a database, so stored functions and widget UDN can be exercised by CI and developers.
+//
+//	udn-run [-data udn_data.json] [-dataman fixtures.json] [-expect expected.json] [-save-dataman] [-debug] [-trace dir] [-profile folded.txt] [-policy policy.json] group.json
+//
+// The Dataman functions (__data_get, __data_filter, __data_set, ...) use the records in the -dataman fixture file: {"collection_name": [{"_id": 1, ...}, ...]}.  Raw SQL (__query) cant be run.
+//
+// With -debug, the step debugger stops at the first function, and reads its commands from stdin (h for help).  It writes to stderr, so stdout is still only the output.
+//
+// With -trace, the execution trace is written into the directory, as JSON lines and an HTML viewer, and the JSON lines path is printed to stderr.
+//
+// With -profile, the functions are profiled: the folded stacks are written to the file (for flame graph tools), and the slowest functions are printed to stderr.
+//
+// With -policy, the execution is restricted by the UdnPolicy in the JSON file: {"groups": [...], "functions": [...], "url_hosts": [...], "databases": [...], "collections": [...], "read_only": true}.  This shows what widget UDN can do under the policy it is served with.
+//
+// Prints the output as JSON: {"result": ..., "udn_data": ..., "error": ...}, where "error" is the uncaught error, if there was one.  With -expect, the output is compared to the expected file instead, and the differences are printed.  Exits 1 if there was an uncaught error, or the output didnt match.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/ghowland/yudien/yudien"
+	"github.com/ghowland/yudien/yudiendata"
+	"github.com/ghowland/yudien/yudienutil"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+func main() {
+	data_path := flag.String("data", "", "JSON file with the starting udn_data")
+	dataman_path := flag.String("dataman", "", "JSON fixture file with the records for the Dataman functions")
+	expect_path := flag.String("expect", "", "JSON file with the expected output, to compare with")
+	save_dataman := flag.Bool("save-dataman", false, "Write the changed records back to the -dataman fixture file")
+	debug := flag.Bool("debug", false, "Step through the execution with the debugger, which reads commands from stdin")
+	trace_path := flag.String("trace", "", "Directory to write the execution trace into")
+	profile_path := flag.String("profile", "", "File to write the profile's folded stacks into")
+	policy_path := flag.String("policy", "", "JSON file with the UdnPolicy to restrict the execution to")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "usage: udn-run [-data udn_data.json] [-dataman fixtures.json] [-expect expected.json] [-save-dataman] [-debug] [-trace dir] [-profile folded.txt] [-policy policy.json] group.json\n")
+		os.Exit(2)
+	}
+
+	udn_data_json, err := ioutil.ReadFile(flag.Arg(0))
+	_ExitOnError(err)
+
+	udn_data := make(map[string]interface{})
+	if *data_path != "" {
+		udn_data, err = _LoadJsonMap(*data_path)
+		_ExitOnError(err)
+	}
+
+	dataman_store, err := yudiendata.NewDatamanFileStore(*dataman_path)
+	_ExitOnError(err)
+	yudien.DefaultEngine.Datasources.StandIn = dataman_store
+
+	udn_schema := yudien.NewUdnSchema()
+
+	if *debug {
+		yudien.SetUdnDebugger(udn_schema, yudien.NewUdnTerminalDebugger(bufio.NewReader(os.Stdin), os.Stderr))
+	}
+
+	if *trace_path != "" {
+		yudien.StartUdnTrace(udn_schema)
+	}
+
+	if *policy_path != "" {
+		policy_json, err := ioutil.ReadFile(*policy_path)
+		_ExitOnError(err)
+
+		policy := &yudien.UdnPolicy{}
+		if err := json.Unmarshal(policy_json, policy); err != nil {
+			_ExitOnError(fmt.Errorf("%s: %s", *policy_path, err))
+		}
+		yudien.SetUdnPolicy(udn_schema, policy)
+	}
+
+	profiler := yudien.NewUdnProfiler()
+	if *profile_path != "" {
+		yudien.SetUdnProfiler(udn_schema, profiler)
+	}
+
+	result := yudien.ProcessSchemaUDNSet(nil, udn_schema, string(udn_data_json), udn_data)
+
+	if trace := yudien.GetUdnTrace(udn_schema); trace != nil {
+		written_path, err := yudien.WriteUdnTrace(trace, *trace_path)
+		_ExitOnError(err)
+		fmt.Fprintf(os.Stderr, "Trace: %s\n", written_path)
+	}
+
+	if *profile_path != "" {
+		profile_file, err := os.Create(*profile_path)
+		_ExitOnError(err)
+		_ExitOnError(profiler.WriteFolded(profile_file))
+		profile_file.Close()
+
+		profiler.WriteTop(os.Stderr, 20)
+	}
+
+	output := map[string]interface{}{"result": result, "udn_data": udn_data}
+
+	udn_error := yudien.GetUdnError(udn_schema)
+	if udn_error != nil {
+		// Only the parts of the error that are the same every run, so they can be expected
+		output["error"] = map[string]interface{}{"message": udn_error["message"], "function": udn_error["function"], "function_stack": udn_error["function_stack"]}
+	}
+
+	output_json := _JsonText(output)
+
+	if *save_dataman && *dataman_path != "" {
+		_ExitOnError(dataman_store.Save())
+	}
+
+	if *expect_path == "" {
+		fmt.Println(output_json)
+
+		if udn_error != nil {
+			os.Exit(1)
+		}
+		return
+	}
+
+	expected, err := _LoadJsonMap(*expect_path)
+	_ExitOnError(err)
+
+	// Both are re-encoded, so formatting and key order dont matter
+	expected_json := _JsonText(expected)
+
+	if expected_json != output_json {
+		fmt.Printf("--- %s\n+++ %s\n", *expect_path, flag.Arg(0))
+		fmt.Print(_DiffLines(strings.Split(expected_json, "\n"), strings.Split(output_json, "\n")))
+		os.Exit(1)
+	}
+}
+
+func _ExitOnError(err error) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "udn-run: %s\n", err)
+		os.Exit(2)
+	}
+}
+
+func _LoadJsonMap(path string) (map[string]interface{}, error) {
+	text, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := yudienutil.JsonLoadMap(string(text))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+
+	// Whole numbers are int64 in UDN
+	yudienutil.JsonIntegers(data)
+
+	return data, nil
+}
+
+// Indented JSON, with sorted keys.  Values that cant be JSON encoded are printed with Go's formatting.
+func _JsonText(value interface{}) string {
+	text, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(text)
+}
+
+// Returns the lines that were removed ("-") and added ("+") to get from expected to actual, with the unchanged lines around them for context
+func _DiffLines(expected []string, actual []string) string {
+	// Longest common subsequence of the lines, from the end, so we can walk it forwards
+	common := make([][]int, len(expected)+1)
+	for index := range common {
+		common[index] = make([]int, len(actual)+1)
+	}
+	for expected_index := len(expected) - 1; expected_index >= 0; expected_index-- {
+		for actual_index := len(actual) - 1; actual_index >= 0; actual_index-- {
+			if expected[expected_index] == actual[actual_index] {
+				common[expected_index][actual_index] = common[expected_index+1][actual_index+1] + 1
+			} else if common[expected_index+1][actual_index] >= common[expected_index][actual_index+1] {
+				common[expected_index][actual_index] = common[expected_index+1][actual_index]
+			} else {
+				common[expected_index][actual_index] = common[expected_index][actual_index+1]
+			}
+		}
+	}
+
+	lines := make([]string, 0)
+	expected_index, actual_index := 0, 0
+
+	for expected_index < len(expected) || actual_index < len(actual) {
+		if expected_index < len(expected) && actual_index < len(actual) && expected[expected_index] == actual[actual_index] {
+			lines = append(lines, " "+expected[expected_index])
+			expected_index++
+			actual_index++
+		} else if actual_index == len(actual) || (expected_index < len(expected) && common[expected_index+1][actual_index] >= common[expected_index][actual_index+1]) {
+			lines = append(lines, "-"+expected[expected_index])
+			expected_index++
+		} else {
+			lines = append(lines, "+"+actual[actual_index])
+			actual_index++
+		}
+	}
+
+	// Only keep 3 lines of context around the changes
+	output := ""
+	for index, line := range lines {
+		near_change := false
+		for nearby := index - 3; nearby <= index+3; nearby++ {
+			if nearby >= 0 && nearby < len(lines) && lines[nearby][0] != ' ' {
+				near_change = true
+				break
+			}
+		}
+
+		if near_change {
+			output += line + "\n"
+		} else if index > 0 && output != "" && !strings.HasSuffix(output, "...\n") {
+			output += "...\n"
+		}
+	}
+
+	return output
+}
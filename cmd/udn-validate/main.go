@@ -0,0 +1,68 @@
+// Validates UDN before it is saved to udn_stored_function or widget data.  Each file (or stdin) holds either a UDN execution group JSON (udn_data_json), or a single UDN statement.
+//
+//	udn-validate [-json] [-warnings-as-errors] [file ...]
+//
+// Exits 1 if any errors were found, so it can be used in a pre-save hook or CI.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/ghowland/yudien/yudien"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+func main() {
+	json_output := flag.Bool("json", false, "Print the issues as a JSON array")
+	warnings_as_errors := flag.Bool("warnings-as-errors", false, "Exit 1 on warnings, such as deprecated functions")
+	flag.Parse()
+
+	filenames := flag.Args()
+	if len(filenames) == 0 {
+		filenames = []string{"-"}
+	}
+
+	all_issues := make([]yudien.UdnValidationIssue, 0)
+	failed := false
+
+	for _, filename := range filenames {
+		var source []byte
+		var err error
+
+		if filename == "-" {
+			source, err = ioutil.ReadAll(os.Stdin)
+		} else {
+			source, err = ioutil.ReadFile(filename)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "udn-validate: %s\n", err)
+			os.Exit(2)
+		}
+
+		issues := yudien.ValidateSchemaUDNSet(strings.TrimSpace(string(source)))
+
+		for _, issue := range issues {
+			if issue.Severity == "error" || *warnings_as_errors {
+				failed = true
+			}
+
+			if !*json_output {
+				fmt.Printf("%s: %s\n    %s\n", filename, issue, issue.Statement)
+			}
+		}
+
+		all_issues = append(all_issues, issues...)
+	}
+
+	if *json_output {
+		output, _ := json.MarshalIndent(all_issues, "", "  ")
+		fmt.Println(string(output))
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -2,9 +2,10 @@ package yudien
 
 import (
 	"bytes"
-	"container/list"
+	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	. "github.com/ghowland/ddd/ddd"
 	. "github.com/ghowland/yudien/yudiencore"
@@ -56,7 +57,16 @@ func UDN_Login(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart
 	username := GetResult(args[0], type_string).(string)
 	password := GetResult(args[1], type_string).(string)
 
-	ldap_user := LdapLogin(username, password)
+	// The user and their session are saved
+	for _, collection_name := range []string{"user", "web_user_session"} {
+		if message := CheckUdnPolicyCollection(udn_schema, _UdnDatamanOptions(udn_schema, make(map[string]interface{})), collection_name); message != "" {
+			return UdnResultError("Login: %s", message)
+		}
+	}
+
+	engine := GetUdnEngine(udn_schema)
+
+	ldap_user := LdapLoginConfig(engine.Ldap, username, password)
 
 	user_map := make(map[string]interface{})
 
@@ -81,7 +91,7 @@ func UDN_Login(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart
 
 		UdnLogLevel(udn_schema, log_info,"LDAP Authenticated: %s\n\n", user_map["username"])
 
-	} else if user, user_found := DevelopmentUsers[username]; user_found && ldap_override_admin && username == user.Username && password == user.Password {
+	} else if user, user_found := engine.DevelopmentUsers[username]; user_found && ldap_override_admin && username == user.Username && password == user.Password {
 		user_map["first_name"] = user.Data.FirstName
 		user_map["full_name"] = user.Data.FirstName + " " + user.Data.LastName
 		user_map["email"] = user.Data.Email
@@ -110,7 +120,7 @@ func UDN_Login(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart
 	filter := map[string]interface{}{}
 	filter["name"] = []interface{}{"=", ldap_user.Username}
 
-	filter_options := make(map[string]interface{})
+	filter_options := _UdnDatamanOptions(udn_schema, make(map[string]interface{}))
 	user_data_result := DatamanFilter("user", filter, filter_options)
 
 	UdnLogLevel(udn_schema, log_debug, "DatamanFilter: RESULT: %v\n", user_data_result)
@@ -133,7 +143,7 @@ func UDN_Login(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart
 		user_data["ldap_data_json"] = string(user_map_json)
 
 		// Save the new user into the DB
-		options_map := make(map[string]interface{})
+		options_map := _UdnDatamanOptions(udn_schema, make(map[string]interface{}))
 		user_data = DatamanSet("user", user_data, options_map)
 
 	} else {
@@ -152,7 +162,7 @@ func UDN_Login(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart
 	filter = make(map[string]interface{})
 	filter["user_id"] = []interface{}{"=", user_data["_id"]}
 	filter["web_site_id"] = []interface{}{"=", 1} //TODO(g): Make dynamic
-	filter_options = make(map[string]interface{})
+	filter_options = _UdnDatamanOptions(udn_schema, make(map[string]interface{}))
 	web_user_session_filter := DatamanFilter("web_user_session", filter, filter_options)
 
 	if len(web_user_session_filter) == 0 {
@@ -165,7 +175,7 @@ func UDN_Login(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart
 		web_user_session["name"] = id.String()
 
 		// Save the new user session
-		options_map := make(map[string]interface{})
+		options_map := _UdnDatamanOptions(udn_schema, make(map[string]interface{}))
 		web_user_session = DatamanSet("web_user_session", web_user_session, options_map)
 
 	} else {
@@ -195,6 +205,13 @@ func UDN_DddRender(db *sql.DB, udn_schema map[string]interface{}, udn_start *Udn
 	save_data := GetResult(args[6], type_map).(map[string]interface{}) // This is incoming data, and will be only for the position_location's data, not the complete record
 	temp_id := GetResult(args[7], type_int).(int64)                    // Initial value is passed in as 0, not empty string or nil
 
+	// The DDD data is read, and the record being edited is saved in the temp table
+	for _, collection_name := range []string{"ddd", "temp"} {
+		if message := CheckUdnPolicyCollection(udn_schema, _UdnDatamanOptions(udn_schema, make(map[string]interface{})), collection_name); message != "" {
+			return UdnResultError("DDD Render: %s", message)
+		}
+	}
+
 	UdnLogLevel(udn_schema, log_trace, "\nDDD Render: Position: %s  Move X: %d  Y: %d  Is Delete: %d  DDD: %d  Data Location: %s\nSave Data:\n%s\n\n", position_location, move_x, move_y, is_delete, ddd_id, data_location, JsonDump(save_data))
 
 	//TEST: Add some static rows...
@@ -214,7 +231,7 @@ func UDN_DddRender(db *sql.DB, udn_schema map[string]interface{}, udn_start *Udn
 	ddd_data := make(map[string]interface{})
 
 	// Get our DDD data, so we can cache it and use it without having to query it many times
-	ddd_options := make(map[string]interface{})
+	ddd_options := _UdnDatamanOptions(udn_schema, make(map[string]interface{}))
 	ddd_data_record := DatamanGet("ddd", int(ddd_id), ddd_options)
 	ddd_data = ddd_data_record["data_json"].(map[string]interface{})
 
@@ -231,13 +248,13 @@ func UDN_DddRender(db *sql.DB, udn_schema map[string]interface{}, udn_start *Udn
 		// Put this data into the temp table, and get our temp_id
 		temp_data := make(map[string]interface{})
 		temp_data["data_json"] = JsonDump(data_record)
-		options_map := make(map[string]interface{})
+		options_map := _UdnDatamanOptions(udn_schema, make(map[string]interface{}))
 		temp_data_result := DatamanSet("temp", temp_data, options_map)
 		UdnLogLevel(udn_schema, log_trace, "Temp data result: %v\n\n", temp_data_result)
 		temp_id = temp_data_result["_id"].(int64)
 	} else {
 		// Get the ddd_data from the temp table
-		temp_options := make(map[string]interface{})
+		temp_options := _UdnDatamanOptions(udn_schema, make(map[string]interface{}))
 		temp_record := DatamanGet("temp", int(temp_id), temp_options)
 
 		err := json.Unmarshal([]byte(temp_record["data_json"].(string)), &data_record)
@@ -423,8 +440,13 @@ func UDN_DddRender(db *sql.DB, udn_schema map[string]interface{}, udn_start *Udn
 }
 
 func UDN_Library_Query(db *sql.DB, sql string) []interface{} {
+	return UDN_Library_QueryContext(context.Background(), db, sql)
+}
+
+// UDN_Library_Query, which is cancelled with the context
+func UDN_Library_QueryContext(ctx context.Context, db *sql.DB, sql string) []interface{} {
 	// Query
-	rs, err := db.Query(sql)
+	rs, err := db.QueryContext(ctx, sql)
 	if err != nil {
 		log.Panic(fmt.Sprintf("SQL: %s\nError: %s\n", sql, err))
 	}
@@ -492,12 +514,20 @@ func UDN_QueryById(db *sql.DB, udn_schema map[string]interface{}, udn_start *Udn
 
 	UdnLogLevel(udn_schema, log_trace, "Query: %s  Stored Query: %s  Data Args: %v\n", udn_start.Value, arg_0, arg_1)
 
+	if message := CheckUdnPolicySql(udn_schema); message != "" {
+		return UdnResultError("Query: %s", message)
+	}
+
 	query_sql := fmt.Sprintf("SELECT * FROM datasource_query WHERE _id = %s", arg_0)
 
 	//TODO(g): Make a new function that returns a list of UdnResult with map.string
 
+	// Queries are cancelled with the execution
+	query_context, query_cancel := UdnExecutionContext(udn_schema)
+	defer query_cancel()
+
 	// This returns an array of TextTemplateMap, original method, for templating data
-	query_result := Query(db, query_sql)
+	query_result := QueryContext(query_context, db, query_sql)
 
 	sql_parameters := make(map[string]string)
 	has_params := false
@@ -505,7 +535,7 @@ func UDN_QueryById(db *sql.DB, udn_schema map[string]interface{}, udn_start *Udn
 		//UdnLogLevel(udn_schema, log_trace, "-- Has params: %v\n", query_result[0]["parameter_data_json"])
 		err := json.Unmarshal([]byte(query_result[0]["parameter_json_data"].(string)), &sql_parameters)
 		if err != nil {
-			log.Panic(err)
+			return UdnResultError("Query: Invalid parameter_json_data: %s", err)
 		}
 		has_params = true
 	} else {
@@ -536,7 +566,7 @@ func UDN_QueryById(db *sql.DB, udn_schema map[string]interface{}, udn_start *Udn
 	}
 
 	// This query returns a list.List of map[string]interface{}, new method for more-raw data
-	result.Result = UDN_Library_Query(db, result_sql)
+	result.Result = UDN_Library_QueryContext(query_context, db, result_sql)
 
 	UdnLogLevel(udn_schema, log_trace, "Query: Result [Items: %d]: %s\n", len(result.Result.([]interface{})), SnippetData(GetResult(result, type_string), 60))
 
@@ -554,14 +584,7 @@ func UDN_DebugOutput(db *sql.DB, udn_schema map[string]interface{}, udn_start *U
 	result := UdnResult{}
 	result.Result = input
 
-	type_str := fmt.Sprintf("%T", input)
-
-	if type_str == "*list.List" {
-		UdnLogLevel(udn_schema, log_debug, "Debug Output: List: %s: %v\n", type_str, SprintList(*input.(*list.List)))
-
-	} else {
-		UdnLogLevel(udn_schema, log_debug, "Debug Output: %s: %s\n", type_str, JsonDump(input))
-	}
+	UdnLogLevel(udn_schema, log_debug, "Debug Output: %T: %s\n", input, JsonDump(input))
 
 	return result
 }
@@ -600,7 +623,7 @@ func UDN_Widget(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPar
 
 	result := UdnResult{}
 	//result.Result = udn_data["widget"].Map[arg_0.Result.(string)]
-	result.Result = udn_data_page[args[0].(string)] //TODO(g): We get this from the page map.  Is this is the best naming?  Check it...
+	result.Result = udn_data_page[GetResult(args[0], type_string).(string)] //TODO(g): We get this from the page map.  Is this is the best naming?  Check it...
 
 	return result
 }
@@ -740,7 +763,7 @@ func UDN_StringTemplateMultiWrap(db *sql.DB, udn_schema map[string]interface{},
 		item := StringFile{}
 		err := item_template.Execute(&item, input_template)
 		if err != nil {
-			log.Panic(err)
+			return UdnResultError("Template Wrap: %s", err)
 		}
 
 		// Set the current_output for return, and put it in our udn_data, so we can access it again
@@ -780,7 +803,7 @@ func UDN_MapStringFormat(db *sql.DB, udn_schema map[string]interface{}, udn_star
 			item := StringFile{}
 			err := item_template.Execute(&item, input_template)
 			if err != nil {
-				log.Panic(err)
+				return UdnResultError("Format: %s", err)
 			}
 
 			// Save the templated string to the set_key in our input, so we are modifying our input
@@ -816,7 +839,7 @@ func UDN_MapTemplate(db *sql.DB, udn_schema map[string]interface{}, udn_start *U
 	for count := 0; count < items; count++ {
 		offset := count * 3
 
-		set_key := args[offset].(string)
+		set_key := GetResult(args[offset], type_string).(string)
 		template_str := GetResult(args[offset+1], type_string).(string)
 		template_data := GetResult(args[offset+2], type_map).(map[string]interface{})
 
@@ -830,7 +853,7 @@ func UDN_MapTemplate(db *sql.DB, udn_schema map[string]interface{}, udn_start *U
 		item := StringFile{}
 		err := item_template.Execute(&item, input_template)
 		if err != nil {
-			log.Panic(err)
+			return UdnResultError("Template Map: %s", err)
 		}
 
 		// Save the templated string to the set_key in our input, so we are modifying our input
@@ -901,7 +924,7 @@ func UDN_MapTemplateKey(db *sql.DB, udn_schema map[string]interface{}, udn_start
 		item := StringFile{}
 		err := item_template.Execute(&item, input_template)
 		if err != nil {
-			log.Panic(err)
+			return UdnResultError("Map Template Key: %s", err)
 		}
 
 		template_key := item.String
@@ -940,8 +963,8 @@ func UDN_StringAppend(db *sql.DB, udn_schema map[string]interface{}, udn_start *
 	UdnLogLevel(udn_schema, log_trace, "String Append: %v\n", args)
 
 	// If we only have 1 argument, and it contains dots, we need to break this into a set of args
-	if len(args) == 1 && strings.Contains(args[0].(string), ".") {
-		args = SimpleDottedStringToArray(args[0].(string), ".")
+	if len(args) == 1 && strings.Contains(GetResult(args[0], type_string).(string), ".") {
+		args = SimpleDottedStringToArray(GetResult(args[0], type_string).(string), ".")
 	}
 
 	// Get the string we are going to append to
@@ -1171,6 +1194,9 @@ func UDN_InputGet(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnP
 		switch arg.(type) {
 		case string:
 			cur_result = cur_result.(map[string]interface{})[arg.(string)]
+		case int64:
+			// Int literals are map keys here too
+			cur_result = cur_result.(map[string]interface{})[GetResult(arg, type_string).(string)]
 		default:
 			//TODO(g): Support ints?  Make this a stand alone function, and just call it from the UDN function
 			cur_result = nil
@@ -1192,9 +1218,18 @@ func UDN_StoredFunction(db *sql.DB, udn_schema map[string]interface{}, udn_start
 
 	sql := fmt.Sprintf("SELECT * FROM udn_stored_function WHERE name = '%s' AND udn_stored_function_domain_id = %d", function_name, function_domain_id)
 
-	function_rows := Query(db, sql)
+	query_context, query_cancel := UdnExecutionContext(udn_schema)
+	defer query_cancel()
 
-	// Get all our args, after the first one (which is our function_name)
+	function_rows := QueryContext(query_context, db, sql)
+
+	if message := PushUdnCallDepth(udn_schema, udn_data); message != "" {
+		return UdnResultError(message)
+	}
+	defer PopUdnCallDepth(udn_data)
+
+	// Get all our args, after the first one (which is our function_name).  The caller's function_arg is put back when we return, so a stored function can call another one and still use its own args.
+	caller_function_arg, has_caller_function_arg := udn_data["function_arg"]
 	udn_data["function_arg"] = GetResult(args[1:], type_map)
 
 	//UdnLogLevel(udn_schema, log_trace, "Stored Function: Args: %d: %s\n", len(udn_data["function_arg"].(map[string]interface{})), SprintMap(udn_data["function_arg"].(map[string]interface{})))
@@ -1206,6 +1241,12 @@ func UDN_StoredFunction(db *sql.DB, udn_schema map[string]interface{}, udn_start
 		result.Result = ProcessSchemaUDNSet(db, udn_schema, function_rows[0]["udn_data_json"].(string), udn_data)
 	}
 
+	if has_caller_function_arg {
+		udn_data["function_arg"] = caller_function_arg
+	} else {
+		delete(udn_data, "function_arg")
+	}
+
 	return result
 }
 
@@ -1221,6 +1262,11 @@ func UDN_Execute(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPa
 
 	//UdnLogLevel(udn_schema, log_trace, "Execute: UDN String As Target: %v\n", udn_target)
 
+	if message := PushUdnCallDepth(udn_schema, udn_data); message != "" {
+		return UdnResultError(message)
+	}
+	defer PopUdnCallDepth(udn_data)
+
 	// Execute the Target against the input
 	result := UdnResult{}
 	//result.Result = ProcessUDN(db, udn_schema, udn_source, udn_target, udn_data)
@@ -1296,6 +1342,9 @@ func UDN_ArraySlice(db *sql.DB, udn_schema map[string]interface{}, udn_start *Ud
 		case int:
 			start_index = args[0].(int)
 			end_index = input_len
+		case int64:
+			start_index = int(args[0].(int64))
+			end_index = input_len
 		case float64:
 			start_index = int(args[0].(float64))
 			end_index = input_len
@@ -1319,6 +1368,8 @@ func UDN_ArraySlice(db *sql.DB, udn_schema map[string]interface{}, udn_start *Ud
 			}
 		case int:
 			end_index = args[1].(int)
+		case int64:
+			end_index = int(args[1].(int64))
 		case float64:
 
 			end_index = int(args[1].(float64))
@@ -1466,7 +1517,7 @@ func UDN_ArrayAppendArray(db *sql.DB, udn_schema map[string]interface{}, udn_sta
 }
 
 func UDN_ArrayDivide(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart, args []interface{}, input interface{}, udn_data map[string]interface{}) UdnResult {
-	divisor, err := strconv.Atoi(args[0].(string))
+	divisor, err := strconv.Atoi(GetResult(args[0], type_string).(string))
 
 	// Dont process this, if it isnt valid...  Just pass through
 	if err != nil || divisor <= 0 {
@@ -1519,7 +1570,7 @@ func UDN_ArrayMapRemap(db *sql.DB, udn_schema map[string]interface{}, udn_start
 
 		// Remap all the old map keys to new map keys in the new map
 		for new_key, old_key := range remap {
-			new_map[new_key] = old_map[old_key.(string)]
+			new_map[new_key] = old_map[GetResult(old_key, type_string).(string)]
 		}
 
 		// Add the new map to the new array
@@ -2041,7 +2092,7 @@ func UDN_MapFilterKey(db *sql.DB, udn_schema map[string]interface{}, udn_start *
 
 	for _, filter_key := range filter_key_list {
 
-		filter_key_str := filter_key.(string)
+		filter_key_str := GetResult(filter_key, type_string).(string)
 
 		UdnLogLevel(udn_schema, log_trace, "Map Filter Key: Key %s: %s\n", filter_key, SnippetData(input_map, 20))
 
@@ -2098,7 +2149,7 @@ func UDN_ArrayMapTemplate(db *sql.DB, udn_schema map[string]interface{}, udn_sta
 			result_str := StringFile{}
 			err := item_template.Execute(&result_str, input_template)
 			if err != nil {
-				log.Panic(err)
+				return UdnResultError("Array Map Template: %s", err)
 			}
 
 			// Save the resulting templated string back into the input array of maps
@@ -2378,7 +2429,7 @@ func UDN_RenderDataWidgetInstance(db *sql.DB, udn_schema map[string]interface{},
 	if web_data_widget_instance["static_data_json"] != nil {
 		err := json.Unmarshal([]byte(web_data_widget_instance["static_data_json"].(string)), &decoded_instance_json)
 		if err != nil {
-			log.Panic(err)
+			return UdnResultError("Render Data: Invalid web_data_widget_instance.static_data_json: %s", err)
 		}
 	}
 	udn_data["data_instance_static"] = decoded_instance_json
@@ -2392,7 +2443,7 @@ func UDN_RenderDataWidgetInstance(db *sql.DB, udn_schema map[string]interface{},
 	if web_data_widget["static_data_json"] != nil {
 		err := json.Unmarshal([]byte(web_data_widget["static_data_json"].(string)), &decoded_json)
 		if err != nil {
-			log.Panic(err)
+			return UdnResultError("Render Data: Invalid web_data_widget.static_data_json: %s", err)
 		}
 	}
 	udn_data["data_static"] = decoded_json
@@ -2443,7 +2494,7 @@ func UDN_JsonDecode(db *sql.DB, udn_schema map[string]interface{}, udn_start *Ud
 		//err := json.Unmarshal([]byte(input.(string)), &decoded_map)
 		err := json.Unmarshal([]byte(input.(string)), &decoded_interface)
 		if err != nil {
-			log.Panic(err)
+			return UdnResultError("JSON Decode: %s", err)
 		}
 	}
 
@@ -2595,7 +2646,7 @@ func UDN_SafeDataGet(db *sql.DB, udn_schema map[string]interface{}, udn_start *U
 
 	// Get the safe label database and table name
 	//TODO(g): Cache this
-	safe_options := make(map[string]interface{})
+	safe_options := _UdnDatamanOptions(udn_schema, make(map[string]interface{}))
 	safe_filter := make(map[string]interface{})
 	safe_filter["name"] = safe_label
 
@@ -2603,12 +2654,17 @@ func UDN_SafeDataGet(db *sql.DB, udn_schema map[string]interface{}, udn_start *U
 	if len(safe_result) > 0 {
 		safe_record := safe_result[0]
 
-		schema_table := GetSchemaTable(safe_record["schema_table_id"].(int64))
-		schema := GetSchemaTable(schema_table["schema_id"].(int64))
-		datasource := GetSchemaTable(schema["datasource_id"].(int64))
+		schema_table := GetSchemaTable(safe_record["schema_table_id"].(int64), safe_options)
+		schema := GetSchemaTable(schema_table["schema_id"].(int64), safe_options)
+		datasource := GetSchemaTable(schema["datasource_id"].(int64), safe_options)
 
 		// Ensure they are connecting to the same database, always
 		options["db"] = datasource["name"]
+		options = _UdnDatamanOptions(udn_schema, options)
+
+		if message := CheckUdnPolicyCollection(udn_schema, options, schema_table["name"].(string)); message != "" {
+			return UdnResultError("Safe Data: %s", message)
+		}
 
 		result_map := DatamanGet(schema_table["name"].(string), int(record_id), options)
 
@@ -2637,7 +2693,7 @@ func UDN_SafeDataFilter(db *sql.DB, udn_schema map[string]interface{}, udn_start
 
 	// Get the safe label database and table name
 	//TODO(g): Cache this
-	safe_options := make(map[string]interface{})
+	safe_options := _UdnDatamanOptions(udn_schema, make(map[string]interface{}))
 	safe_filter := make(map[string]interface{})
 	safe_filter["name"] = safe_label
 
@@ -2645,12 +2701,17 @@ func UDN_SafeDataFilter(db *sql.DB, udn_schema map[string]interface{}, udn_start
 	if len(safe_result) > 0 {
 		safe_record := safe_result[0]
 
-		schema_table := GetSchemaTable(safe_record["schema_table_id"].(int64))
-		schema := GetSchemaTable(schema_table["schema_id"].(int64))
-		datasource := GetSchemaTable(schema["datasource_id"].(int64))
+		schema_table := GetSchemaTable(safe_record["schema_table_id"].(int64), safe_options)
+		schema := GetSchemaTable(schema_table["schema_id"].(int64), safe_options)
+		datasource := GetSchemaTable(schema["datasource_id"].(int64), safe_options)
 
 		// Ensure they are connecting to the same database, always
 		options["db"] = datasource["name"]
+		options = _UdnDatamanOptions(udn_schema, options)
+
+		if message := CheckUdnPolicyCollection(udn_schema, options, schema_table["name"].(string)); message != "" {
+			return UdnResultError("Safe Data: %s", message)
+		}
 
 		// Update the filter_map with the safe_record["data_json"]["filter"]
 		if safe_record["data_json"] != nil && safe_record["data_json"].(map[string]interface{})["filter"] != nil {
@@ -2686,7 +2747,7 @@ func UDN_SafeDataFilterFull(db *sql.DB, udn_schema map[string]interface{}, udn_s
 
 	// Get the safe label database and table name
 	//TODO(g): Cache this
-	safe_options := make(map[string]interface{})
+	safe_options := _UdnDatamanOptions(udn_schema, make(map[string]interface{}))
 	safe_filter := make(map[string]interface{})
 	safe_filter["name"] = safe_label
 
@@ -2694,12 +2755,17 @@ func UDN_SafeDataFilterFull(db *sql.DB, udn_schema map[string]interface{}, udn_s
 	if len(safe_result) > 0 {
 		safe_record := safe_result[0]
 
-		schema_table := GetSchemaTable(safe_record["schema_table_id"].(int64))
-		schema := GetSchemaTable(schema_table["schema_id"].(int64))
-		datasource := GetSchemaTable(schema["datasource_id"].(int64))
+		schema_table := GetSchemaTable(safe_record["schema_table_id"].(int64), safe_options)
+		schema := GetSchemaTable(schema_table["schema_id"].(int64), safe_options)
+		datasource := GetSchemaTable(schema["datasource_id"].(int64), safe_options)
 
 		// Ensure they are connecting to the same database, always
 		options["db"] = datasource["name"]
+		options = _UdnDatamanOptions(udn_schema, options)
+
+		if message := CheckUdnPolicyCollection(udn_schema, options, schema_table["name"].(string)); message != "" {
+			return UdnResultError("Safe Data: %s", message)
+		}
 
 		// Update the filter_map with the safe_record["data_json"]["filter"]
 		if safe_record["data_json"] != nil && safe_record["data_json"].(map[string]interface{})["filter"] != nil {
@@ -2736,6 +2802,12 @@ func UDN_TimeSeriesGet(db *sql.DB, udn_schema map[string]interface{}, udn_start
 		options = GetResult(args[2], type_map).(map[string]interface{})
 	}
 
+	options = _UdnDatamanOptions(udn_schema, options)
+
+	if message := CheckUdnPolicyCollection(udn_schema, options, collection_name); message != "" {
+		return UdnResultError("Time Series Get: %s", message)
+	}
+
 	result_map := DatamanGet(collection_name, int(record_id), options)
 
 	result := UdnResult{}
@@ -2760,6 +2832,12 @@ func UDN_TimeSeriesFilter(db *sql.DB, udn_schema map[string]interface{}, udn_sta
 		options = GetResult(args[2], type_map).(map[string]interface{})
 	}
 
+	options = _UdnDatamanOptions(udn_schema, options)
+
+	if message := CheckUdnPolicyCollection(udn_schema, options, collection_name); message != "" {
+		return UdnResultError("Time Series Filter: %s", message)
+	}
+
 	result_map := DatamanGet(collection_name, int(record_id), options)
 
 	result := UdnResult{}
@@ -2774,14 +2852,15 @@ func UDN_SetLogLevel(db *sql.DB, udn_schema map[string]interface{}, udn_start *U
 	UdnLogLevel(udn_schema, log_trace, "Set Log Level: '%s'\n", log_level)
 
 	// Set the log level if it fits into ones we allow
+	engine := GetUdnEngine(udn_schema)
 	if log_level == "info" {
-		Debug_Udn_Log_Level = log_info
+		engine.SetLogLevel(log_info)
 		UdnLogLevel(udn_schema, log_info, "Set Log Level: INFO\n")
 	} else if log_level == "debug" {
-		Debug_Udn_Log_Level = log_debug
+		engine.SetLogLevel(log_debug)
 		UdnLogLevel(udn_schema, log_debug, "Set Log Level: DEBUG\n")
 	} else if log_level == "trace" {
-		Debug_Udn_Log_Level = log_trace
+		engine.SetLogLevel(log_trace)
 		UdnLogLevel(udn_schema, log_trace, "Set Log Level: TRACE\n")
 	}
 
@@ -2803,6 +2882,11 @@ func UDN_DataGet(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPa
 	if len(args) > 2 {
 		options = GetResult(args[2], type_map).(map[string]interface{})
 	}
+	options = _UdnDatamanOptions(udn_schema, options)
+
+	if message := CheckUdnPolicyCollection(udn_schema, options, collection_name); message != "" {
+		return UdnResultError("Data Get: %s", message)
+	}
 
 	// If this is a negative value, return an empty map, this is a new record
 	if record_id < 0 {
@@ -2828,10 +2912,19 @@ func UDN_DataSet(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPa
 
 	collection_name := GetResult(args[0], type_string).(string)
 	record := GetResult(args[1], type_map).(map[string]interface{})
-	options := GetResult(args[1], type_map).(map[string]interface{})
+	options := _UdnDatamanOptions(udn_schema, GetResult(args[1], type_map).(map[string]interface{}))
+
+	if message := CheckUdnPolicyCollection(udn_schema, options, collection_name); message != "" {
+		return UdnResultError("Data Set: %s", message)
+	}
 
 	result_map := DatamanSet(collection_name, record, options)
 
+	// Dataman failures come back as a record with only an _error
+	if _, has_error := result_map["_error"]; has_error && result_map["_id"] == nil {
+		return UdnResultError("Data Set: %s: %v", collection_name, result_map["_error"])
+	}
+
 	result := UdnResult{}
 	result.Result = result_map
 
@@ -2849,6 +2942,11 @@ func UDN_DataFilter(db *sql.DB, udn_schema map[string]interface{}, udn_start *Ud
 	if len(args) >= 3 {
 		options = GetResult(args[2], type_map).(map[string]interface{})
 	}
+	options = _UdnDatamanOptions(udn_schema, options)
+
+	if message := CheckUdnPolicyCollection(udn_schema, options, collection_name); message != "" {
+		return UdnResultError("Data Filter: %s", message)
+	}
 
 	result_list := DatamanFilter(collection_name, filter, options)
 
@@ -2872,6 +2970,11 @@ func UDN_DataFilterFull(db *sql.DB, udn_schema map[string]interface{}, udn_start
 	if len(args) >= 3 {
 		options = GetResult(args[2], type_map).(map[string]interface{})
 	}
+	options = _UdnDatamanOptions(udn_schema, options)
+
+	if message := CheckUdnPolicyCollection(udn_schema, options, collection_name); message != "" {
+		return UdnResultError("Data Filter: %s", message)
+	}
 
 	result_list := DatamanFilterFull(collection_name, filter, options)
 
@@ -2891,6 +2994,11 @@ func UDN_DataDelete(db *sql.DB, udn_schema map[string]interface{}, udn_start *Ud
 	if len(args) > 2 {
 		options = GetResult(args[2], type_map).(map[string]interface{})
 	}
+	options = _UdnDatamanOptions(udn_schema, options)
+
+	if message := CheckUdnPolicyCollection(udn_schema, options, collection_name); message != "" {
+		return UdnResultError("Data Delete: %s", message)
+	}
 
 	result_map := DatamanDelete(collection_name, record_id, options)
 
@@ -2905,11 +3013,17 @@ func UDN_DataTombstone(db *sql.DB, udn_schema map[string]interface{}, udn_start
 
 	record_label := GetResult(args[0], type_string).(string)
 
-	record := DatamanGetByLabel(record_label)
+	if message := CheckUdnPolicyLabel(udn_schema, record_label); message != "" {
+		return UdnResultError("Data Tombstone: %s", message)
+	}
+
+	options := _UdnDatamanOptions(udn_schema, make(map[string]interface{}))
+
+	record := DatamanGetByLabel(record_label, options)
 
 	record["_is_deleted"] = true
 
-	record_result := DatamanSetByLabel(record_label, record)
+	record_result := DatamanSetByLabel(record_label, record, options)
 
 	result := UdnResult{}
 	result.Result = record_result
@@ -2922,7 +3036,11 @@ func UDN_DataFieldMapDelete(db *sql.DB, udn_schema map[string]interface{}, udn_s
 
 	field_label := GetResult(args[0], type_string).(string)
 
-	record := DataFieldMapDelete(field_label)
+	if message := CheckUdnPolicyLabel(udn_schema, field_label); message != "" {
+		return UdnResultError("Data Field Map Delete: %s", message)
+	}
+
+	record := DataFieldMapDelete(udn_schema, field_label)
 
 	result := UdnResult{}
 	result.Result = record
@@ -2930,7 +3048,7 @@ func UDN_DataFieldMapDelete(db *sql.DB, udn_schema map[string]interface{}, udn_s
 	return result
 }
 
-func DataFieldMapDelete(field_label string) map[string]interface{} {
+func DataFieldMapDelete(udn_schema map[string]interface{}, field_label string) map[string]interface{} {
 	return_record := make(map[string]interface{})
 
 	// If this is a deep-field (JSON)
@@ -2942,7 +3060,7 @@ func DataFieldMapDelete(field_label string) map[string]interface{} {
 
 		record_label := fmt.Sprintf("%s.%s.%s", database, collection, record_pkey)
 
-		record := GetRecordFromRecordLabel(record_label)
+		record := GetRecordFromRecordLabel(udn_schema, record_label)
 
 		//UdnLogLevel(nil, log_trace, "DataFieldMapDelete: Info: %s: %s: %s: %v\n", database, collection, record_pkey, field_parts)
 		//UdnLogLevel(nil, log_trace, "DataFieldMapDelete: Before: %s: %s\n", field_label, JsonDump(record))
@@ -3019,18 +3137,17 @@ func DataFieldMapDelete(field_label string) map[string]interface{} {
 		//UdnLogLevel(nil, log_trace, "DataFieldMapDelete: After: %s: %s\n", field_label, JsonDump(record))
 
 		// Update the record again
-		options := map[string]interface{}{"db": database,}
+		options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": database})
 		return_record = DatamanSet(collection, record, options)
 	}
 
 	return return_record
 }
 
-func GetRecordFromRecordLabel(record_label string) map[string]interface{} {
+func GetRecordFromRecordLabel(udn_schema map[string]interface{}, record_label string) map[string]interface{} {
 	label_parts := strings.Split(record_label, ".")
 
-	options := make(map[string]interface{})
-	options["db"] = label_parts[0]
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": label_parts[0]})
 
 	record_id, _ := strconv.ParseInt(label_parts[2], 10, 64)
 	record := DatamanGet(label_parts[1], int(record_id), options)
@@ -3066,6 +3183,12 @@ func UDN_DataDeleteFilter(db *sql.DB, udn_schema map[string]interface{}, udn_sta
 		options = GetResult(args[2], type_map).(map[string]interface{})
 	}
 
+	options = _UdnDatamanOptions(udn_schema, options)
+
+	if message := CheckUdnPolicyCollection(udn_schema, options, collection_name); message != "" {
+		return UdnResultError("Data Delete Filter: %s", message)
+	}
+
 	// Find all entries to be delete
 	delete_list := DatamanFilterFull(collection_name, filter, options)
 	result_array := make([]map[string]interface{}, 0, 10)
@@ -3073,7 +3196,7 @@ func UDN_DataDeleteFilter(db *sql.DB, udn_schema map[string]interface{}, udn_sta
 	// call the singular DataDelete on each element
 	for _, element := range delete_list {
 		//TODO(z): For future speed improvements if needed, group deletes together if necessary
-		result_map := DatamanDelete(collection_name, element["_id"].(int64), make(map[string]interface{}))
+		result_map := DatamanDelete(collection_name, element["_id"].(int64), options)
 		result_array = AppendArrayMap(result_array, result_map)
 	}
 
@@ -3099,6 +3222,12 @@ func UDN_ChangeDataSubmit(db *sql.DB, udn_schema map[string]interface{}, udn_sta
 	// Make a submit map, and add in hierarchy deeper maps of:  DB -> table -> record PKEY -> fields -> values
 	submit_map := make(map[string]interface{})
 
+	// Nothing is submitted if the policy doesnt allow any of the records
+	for key := range input_val {
+		if message := CheckUdnPolicyLabel(udn_schema, key); message != "" {
+			return UdnResultError("Change: Submit: %s", message)
+		}
+	}
 
 	// Compile the fields into records, which can be submitted
 	for key, value := range input_val {
@@ -3213,7 +3342,7 @@ func UDN_ChangeDataSubmit(db *sql.DB, udn_schema map[string]interface{}, udn_sta
 	// Check all our records for validation errors, and return early if they are any
 	for database, database_map := range submit_map {
 		for table, table_map := range database_map.(map[string]interface{}) {
-			options := make(map[string]interface{})
+			options := _UdnDatamanOptions(udn_schema, make(map[string]interface{}))
 
 			filter_map := make(map[string]interface{})
 			filter_map_array := make([]interface{}, 2)
@@ -3266,8 +3395,7 @@ func UDN_ChangeDataSubmit(db *sql.DB, udn_schema map[string]interface{}, udn_sta
 			for record_pkey, record_map := range table_map.(map[string]interface{}) {
 				UdnLogLevel(nil, log_trace,"Change: Submit: DB: %s  Table: %s  Record: %s  Map: %s\n", database, table, record_pkey, JsonDump(record_map))
 
-				option_map := make(map[string]interface{})
-				option_map["db"] = database
+				option_map := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": database})
 
 				result_map := DatamanSet(table, record_map.(map[string]interface{}), option_map)
 
@@ -3292,7 +3420,7 @@ func UDN_MapKeyDelete(db *sql.DB, udn_schema map[string]interface{}, udn_start *
 	UdnLogLevel(udn_schema, log_trace, "Map Key Delete: %v\n", args)
 
 	for _, key := range args {
-		delete(input.(map[string]interface{}), key.(string))
+		delete(input.(map[string]interface{}), GetResult(key, type_string).(string))
 	}
 
 	result := UdnResult{}
@@ -3499,10 +3627,8 @@ func UDN_GetFirst(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnP
 
 	// Process each of our args, until one of them isnt nil
 	for _, arg := range args {
-		type_str := fmt.Sprintf("%T", arg)
-
-		if strings.HasPrefix(type_str, "[]") {
-			for _, item := range arg.([]interface{}) {
+		if arg_items, ok := arg.([]interface{}); ok {
+			for _, item := range arg_items {
 				arg_str := GetResult(item, type_string).(string)
 				arg_array := make([]interface{}, 0)
 				arg_array = AppendArray(arg_array, arg_str)
@@ -3564,23 +3690,8 @@ func UDN_Set(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart,
 }
 
 func UDN_GetTemp(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart, args []interface{}, input interface{}, udn_data map[string]interface{}) UdnResult {
-	function_stack := udn_data["__function_stack"].([]map[string]interface{})
-	function_stack_item := function_stack[len(function_stack)-1]
-	function_uuid := function_stack_item["uuid"].(string)
-	UdnLogLevel(udn_schema, log_trace, "Get Temp: %s: %v\n", function_uuid, SnippetData(args, 80))
-
-	// Ensure temp exists
-	if udn_data["__temp"] == nil {
-		udn_data["__temp"] = make(map[string]interface{})
-	}
-
-	// Ensure this Function Temp exists
-	if udn_data["__temp"].(map[string]interface{})[function_uuid] == nil {
-		udn_data["__temp"].(map[string]interface{})[function_uuid] = make(map[string]interface{})
-	}
-
-	// Set the temp_udn_data starting at this new value
-	temp_udn_data := udn_data["__temp"].(map[string]interface{})[function_uuid].(map[string]interface{})
+	temp_udn_data := _GetUdnTempData(udn_data)
+	UdnLogLevel(udn_schema, log_trace, "Get Temp: %v\n", SnippetData(args, 80))
 
 	// Call the normal Get function, with this temp_udn_data data
 	result := UDN_Get(db, udn_schema, udn_start, args, input, temp_udn_data)
@@ -3589,20 +3700,11 @@ func UDN_GetTemp(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPa
 }
 
 func UDN_GetTempKey(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart, args []interface{}, input interface{}, udn_data map[string]interface{}) UdnResult {
-	function_stack := udn_data["__function_stack"].([]map[string]interface{})
-	function_stack_item := function_stack[len(function_stack)-1]
-	function_uuid := function_stack_item["uuid"].(string)
-	UdnLogLevel(udn_schema, log_trace, "Get Temp Key: %s: %v\n", function_uuid, SnippetData(args, 80))
-
-	// Ensure temp exists
-	if udn_data["__temp"] == nil {
-		udn_data["__temp"] = make(map[string]interface{})
-	}
-
 	// Ensure this Function Temp exists
-	if udn_data["__temp"].(map[string]interface{})[function_uuid] == nil {
-		udn_data["__temp"].(map[string]interface{})[function_uuid] = make(map[string]interface{})
-	}
+	_GetUdnTempData(udn_data)
+
+	function_uuid := _GetUdnFunctionStackFrame(udn_data)["uuid"].(string)
+	UdnLogLevel(udn_schema, log_trace, "Get Temp Key: %s: %v\n", function_uuid, SnippetData(args, 80))
 
 	// concatenate all the arguments to return the final temp variable string
 	var buffer bytes.Buffer
@@ -3612,7 +3714,7 @@ func UDN_GetTempKey(db *sql.DB, udn_schema map[string]interface{}, udn_start *Ud
 
 	for _, arg := range args{
 		buffer.WriteString(".")
-		buffer.WriteString(arg.(string))
+		buffer.WriteString(GetResult(arg, type_string).(string))
 	}
 
 	temp_string := buffer.String()
@@ -3625,23 +3727,8 @@ func UDN_GetTempKey(db *sql.DB, udn_schema map[string]interface{}, udn_start *Ud
 }
 
 func UDN_SetTemp(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart, args []interface{}, input interface{}, udn_data map[string]interface{}) UdnResult {
-	function_stack := udn_data["__function_stack"].([]map[string]interface{})
-	function_stack_item := function_stack[len(function_stack)-1]
-	function_uuid := function_stack_item["uuid"].(string)
-	UdnLogLevel(udn_schema, log_trace, "Set Temp: %s: %v   Input: %s\n", function_uuid, SnippetData(args, 80), SnippetData(input, 40))
-
-	// Ensure temp exists
-	if udn_data["__temp"] == nil {
-		udn_data["__temp"] = make(map[string]interface{})
-	}
-
-	// Ensure this Function Temp exists
-	if udn_data["__temp"].(map[string]interface{})[function_uuid] == nil {
-		udn_data["__temp"].(map[string]interface{})[function_uuid] = make(map[string]interface{})
-	}
-
-	// Set the temp_udn_data starting at this new value
-	temp_udn_data := udn_data["__temp"].(map[string]interface{})[function_uuid].(map[string]interface{})
+	temp_udn_data := _GetUdnTempData(udn_data)
+	UdnLogLevel(udn_schema, log_trace, "Set Temp: %v   Input: %s\n", SnippetData(args, 80), SnippetData(input, 40))
 
 	// Call the normal Get function, with this temp_udn_data data
 	result := UDN_Set(db, udn_schema, udn_start, args, input, temp_udn_data)
@@ -3688,7 +3775,7 @@ func UDN_SetHttpResponseCode(db *sql.DB, udn_schema map[string]interface{}, udn_
 
 	// args[0] - desired http code to be set
 	if len(args) > 0 {
-		http_response_code, err := strconv.Atoi(args[0].(string))
+		http_response_code, err := strconv.Atoi(GetResult(args[0], type_string).(string))
 		if err == nil {
 			udn_data["http_response_code"] = http_response_code
 		}
@@ -3717,6 +3804,9 @@ func UDN_Iterate(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPa
 
 	// If we have something to iterate over
 	if len(input_array) > 0 {
+		// __break and __continue in our block come to us
+		PushUdnLoop(udn_schema)
+
 		// Loop over the items in the input
 		for item_index, item := range input_array {
 			UdnLogLevel(udn_schema, log_trace, "\n====== Iterate Loop Start: [%s]  Input: %v\n\n", udn_start.Id, SnippetData(item, 300))
@@ -3724,6 +3814,9 @@ func UDN_Iterate(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPa
 			// Set the iterate index, so we can track it
 			udn_data["_iterate_index"] = item_index
 
+			// Each loop has its own variables (__let/__var)
+			PushUdnScope(udn_data)
+
 			// Get the input
 			current_input := item
 
@@ -3740,6 +3833,11 @@ func UDN_Iterate(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPa
 				current_input_result := ExecuteUdnPart(db, udn_schema, udn_current, current_input, udn_data)
 				current_input = current_input_result.Result
 
+				// Stop this block on an error, it unwinds through us.  A __break or __continue stops it too, and we handle it below.
+				if UdnErrorPending(udn_schema) || UdnLoopControlPending(udn_schema) {
+					break
+				}
+
 				// If we are being told to skip to another NextUdnPart, we need to do this, to respect the Flow Control
 				if current_input_result.NextUdnPart != nil {
 					// Move the current to the specified NextUdnPart
@@ -3748,13 +3846,29 @@ func UDN_Iterate(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPa
 				}
 			}
 
-			// Take the final input (the result of all the execution), and put it into the list.List we return, which is now a transformation of the input list
-			result_list = AppendArray(result_list, current_input)
+			PopUdnScope(udn_data)
+
+			// A __break or __continue stopped the block part way through, so skip to our __end_iterate.  This item is left out of the result list.
+			loop_control := ClearUdnLoopControl(udn_schema)
+			if loop_control != "" {
+				UdnLogLevel(udn_schema, log_trace, "\n====== Iterate Loop Control: [%s]  %s\n\n", udn_start.Id, loop_control)
+				udn_current = udn_start.BlockEnd
+			} else {
+				// Take the final input (the result of all the execution), and put it into the list.List we return, which is now a transformation of the input list
+				result_list = AppendArray(result_list, current_input)
+			}
 
 			// Fix the execution stack by setting the udn_current to the udn_current, which is __end_iterate, which means this block will not be executed when UDN_Iterate completes
 			result.NextUdnPart = udn_current
+
+			// Dont run any more iterations after an error or __break
+			if UdnErrorPending(udn_schema) || loop_control == loop_control_break {
+				break
+			}
 		}
 
+		PopUdnLoop(udn_schema)
+
 		// Send them passed the __end_iterate, to the next one, or nil
 		if result.NextUdnPart == nil {
 			UdnLogLevel(udn_schema, log_trace, "\n====== Iterate Finished: [%s]  NextUdnPart: %v\n\n", udn_start.Id, result.NextUdnPart)
@@ -3800,10 +3914,17 @@ func UDN_While(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart
 
 	var current_input interface{}
 
+	// __break and __continue in our block come to us
+	PushUdnLoop(udn_schema)
+
 	// If we have something to iterate over
 	for current_loops < max_loops {
 		condition_value := ProcessSingleUDNTarget(db, udn_schema, condition_udn_string, nil, udn_data)
 
+		if UdnErrorPending(udn_schema) {
+			break
+		}
+
 		if !IfResult(condition_value) {
 			UdnLogLevel(udn_schema, log_trace, "\n====== While Finished: [%s]  Condition False (%v): %v\n\n", udn_start.Id, current_loops, condition_value)
 			// Break out of the while loop
@@ -3815,6 +3936,9 @@ func UDN_While(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart
 		// Get the input
 		current_input = nil
 
+		// Each loop has its own variables (__let/__var)
+		PushUdnScope(udn_data)
+
 		// Variables for looping over functions (flow control)
 		udn_current := udn_start
 
@@ -3828,6 +3952,11 @@ func UDN_While(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart
 			current_input_result := ExecuteUdnPart(db, udn_schema, udn_current, current_input, udn_data)
 			current_input = current_input_result.Result
 
+			// Stop this block on an error, it unwinds through us.  A __break or __continue stops it too, and we handle it below.
+			if UdnErrorPending(udn_schema) || UdnLoopControlPending(udn_schema) {
+				break
+			}
+
 			// If we are being told to skip to another NextUdnPart, we need to do this, to respect the Flow Control
 			if current_input_result.NextUdnPart != nil {
 				// Move the current to the specified NextUdnPart
@@ -3836,8 +3965,17 @@ func UDN_While(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart
 			}
 		}
 
-		// Take the final input (the result of all the execution), and put it into the list.List we return, which is now a transformation of the input list
-		result_list = AppendArray(result_list, current_input)
+		PopUdnScope(udn_data)
+
+		// A __break or __continue stopped the block part way through, so skip to our __end_while.  This loop is left out of the result list.
+		loop_control := ClearUdnLoopControl(udn_schema)
+		if loop_control != "" {
+			UdnLogLevel(udn_schema, log_trace, "\n====== While Loop Control: [%s]  %s\n\n", udn_start.Id, loop_control)
+			udn_current = udn_start.BlockEnd
+		} else {
+			// Take the final input (the result of all the execution), and put it into the list.List we return, which is now a transformation of the input list
+			result_list = AppendArray(result_list, current_input)
+		}
 
 		// Fix the execution stack by setting the udn_current to the udn_current, which is __end_iterate, which means this block will not be executed when UDN_Iterate completes
 		result.NextUdnPart = udn_current
@@ -3851,6 +3989,11 @@ func UDN_While(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart
 			UdnLogLevel(udn_schema, log_trace, "\n====== While Finished: [%s]  NextUdnPart: End of UDN Parts\n\n", udn_start.Id)
 		}
 
+		// Dont run any more loops after an error or __break
+		if UdnErrorPending(udn_schema) || loop_control == loop_control_break {
+			break
+		}
+
 		current_loops++
 		if current_loops >= max_loops {
 			UdnLogLevel(udn_schema, log_trace, "\n====== While Finished: [%s]  Maximum Loops Reached (%d): %v\n\n", udn_start.Id, max_loops, result.NextUdnPart)
@@ -3859,6 +4002,8 @@ func UDN_While(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart
 		}
 	}
 
+	PopUdnLoop(udn_schema)
+
 	// Store the result list
 	result.Result = result_list
 
@@ -3932,6 +4077,9 @@ func UDN_IfCondition(db *sql.DB, udn_schema map[string]interface{}, udn_start *U
 
 	current_input := input
 
+	// Variables set in the block we execute (__let) are removed at __end_if
+	PushUdnScope(udn_data)
+
 	// Check the first argument, to see if we should execute the IF-THEN statements, if it is false, we will look for ELSE-IF or ELSE if no ELSE-IF blocks are true.
 
 	// Keep track of any embedded IF statements, as we will need to process or not process them, depending on whether we are currently embedded in other IFs
@@ -3963,7 +4111,7 @@ func UDN_IfCondition(db *sql.DB, udn_schema map[string]interface{}, udn_start *U
 			} else {
 				// Else, we havent executed a block, so we need to determine if we should start executing.  This is only variable for "__else_if", "else" will always execute if we get here
 				if udn_current.Value == "__else_if" {
-					udn_current_arg_0 := udn_current.Children.Front().Value.(*UdnPart)
+					udn_current_arg_0 := udn_current.Children[0]
 					// If we dont have a "true" value, then skip this next block
 					if udn_current_arg_0.Value == "0" {
 						skip_this_block = true
@@ -3987,6 +4135,11 @@ func UDN_IfCondition(db *sql.DB, udn_schema map[string]interface{}, udn_start *U
 					current_result := ExecuteUdnPart(db, udn_schema, udn_current, current_input, udn_data)
 					current_input = current_result.Result
 
+					// Stop this block on an error, it unwinds through us.  A __break or __continue unwinds through us to its loop.
+					if UdnErrorPending(udn_schema) || UdnLoopControlPending(udn_schema) {
+						break
+					}
+
 					// If we were told what our NextUdnPart is, jump ahead
 					if current_result.NextUdnPart != nil {
 						UdnLogLevel(udn_schema, log_trace, "If: Flow Control: JUMPING to NextUdnPart: %s [%s]\n", current_result.NextUdnPart.Value, current_result.NextUdnPart.Id)
@@ -3997,6 +4150,8 @@ func UDN_IfCondition(db *sql.DB, udn_schema map[string]interface{}, udn_start *U
 		}
 	}
 
+	PopUdnScope(udn_data)
+
 	// Skip to the end of the __if block (__end_if)
 	for udn_current != nil && udn_current.Value != "__end_if" && udn_current.NextUdnPart != nil {
 		udn_current = udn_current.NextUdnPart
@@ -4027,6 +4182,159 @@ func UDN_ElseIfCondition(db *sql.DB, udn_schema map[string]interface{}, udn_star
 	return result
 }
 
+func UDN_Try(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart, args []interface{}, input interface{}, udn_data map[string]interface{}) UdnResult {
+	// Executes the block until __catch (or __end_try if there is no __catch).  If anything in it returns an error, the rest of the block is skipped, the error is put in udn_data["error"] ({message, function, function_stack}) and the __catch block is executed.  Without an error, the __catch block is skipped.
+	if udn_start.BlockEnd == nil {
+		return UdnResultError("__try has no matching __end_try")
+	}
+
+	// Find our __catch, skipping over any __try blocks inside ours, as their __catch isnt ours
+	var udn_catch *UdnPart
+	for udn_current := udn_start.NextUdnPart; udn_current != nil && udn_current != udn_start.BlockEnd; udn_current = udn_current.NextUdnPart {
+		if udn_current.Value == "__try" && udn_current.BlockEnd != nil {
+			udn_current = udn_current.BlockEnd
+		} else if udn_current.Value == "__catch" {
+			udn_catch = udn_current
+			break
+		}
+	}
+
+	try_block_end := udn_start.BlockEnd
+	if udn_catch != nil {
+		try_block_end = udn_catch
+	}
+
+	UdnLogLevel(udn_schema, log_trace, "Try: [%s]  Has Catch: %v\n", udn_start.Id, udn_catch != nil)
+
+	current_input := _ExecuteUdnBlock(db, udn_schema, udn_start, try_block_end, input, udn_data)
+
+	// An aborted execution (over its execution limits) isnt caught, it has to stop everything
+	if UdnErrorPending(udn_schema) && UdnExecutionAborted(udn_schema) == "" {
+		udn_error := ClearUdnError(udn_schema)
+
+		UdnLogLevel(udn_schema, log_debug, "Try: [%s]  Caught Error: %s: %s\n", udn_start.Id, udn_error["function"], udn_error["message"])
+
+		udn_data["error"] = udn_error
+
+		// The catch block gets the same input the try block did, the error is in __get.error
+		current_input = input
+		if udn_catch != nil {
+			current_input = _ExecuteUdnBlock(db, udn_schema, udn_catch, udn_start.BlockEnd, input, udn_data)
+		}
+	}
+
+	result := UdnResult{}
+	result.Result = current_input
+	result.NextUdnPart = udn_start.BlockEnd
+
+	return result
+}
+
+func UDN_Switch(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart, args []interface{}, input interface{}, udn_data map[string]interface{}) UdnResult {
+	// Executes the first __case arm whose args match the input (compared like __compare_equal), or the __default arm if none match.  Each arm runs until the next __case/__default or __end_switch, and gets the same input as the __switch.  Without a matching arm, the input passes through.
+	if udn_start.BlockEnd == nil {
+		return UdnResultError("__switch has no matching __end_switch")
+	}
+
+	// Find our arms, skipping over any blocks inside ours (ex: another __switch), as their arms arent ours
+	udn_arms := make([]*UdnPart, 0)
+	var udn_default *UdnPart
+	for udn_current := udn_start.NextUdnPart; udn_current != nil && udn_current != udn_start.BlockEnd; udn_current = udn_current.NextUdnPart {
+		if udn_current.BlockEnd != nil {
+			udn_current = udn_current.BlockEnd
+		} else if udn_current.Value == "__case" || udn_current.Value == "__default" {
+			if udn_current.Value == "__default" {
+				if udn_default != nil {
+					return UdnResultError("__switch has more than one __default")
+				}
+				udn_default = udn_current
+			}
+
+			udn_arms = append(udn_arms, udn_current)
+		}
+	}
+
+	UdnLogLevel(udn_schema, log_trace, "Switch: [%s]  Arms: %d  Has Default: %v  Input: %s\n", udn_start.Id, len(udn_arms), udn_default != nil, SnippetData(input, 60))
+
+	// Find the arm to execute.  The __case args are only processed until one matches, like __else_if conditions.
+	var udn_arm *UdnPart
+	for _, udn_current := range udn_arms {
+		if udn_current.Value != "__case" {
+			continue
+		}
+
+		case_args := ProcessUdnArguments(db, udn_schema, udn_current, input, udn_data)
+		if UdnErrorPending(udn_schema) {
+			return UdnResult{}
+		}
+
+		for _, case_arg := range case_args {
+			if CompareUdnData(input, case_arg) == 1 {
+				udn_arm = udn_current
+				break
+			}
+		}
+
+		if udn_arm != nil {
+			break
+		}
+	}
+
+	if udn_arm == nil {
+		udn_arm = udn_default
+	}
+
+	current_input := input
+
+	if udn_arm != nil {
+		// The arm ends at the next arm, or our __end_switch
+		udn_arm_end := udn_start.BlockEnd
+		for index, udn_current := range udn_arms {
+			if udn_current == udn_arm && index+1 < len(udn_arms) {
+				udn_arm_end = udn_arms[index+1]
+			}
+		}
+
+		UdnLogLevel(udn_schema, log_trace, "Switch: [%s]  Executing: %s [%s]\n", udn_start.Id, udn_arm.Value, udn_arm.Id)
+
+		// Variables set in the arm (__let) are removed at __end_switch
+		PushUdnScope(udn_data)
+		current_input = _ExecuteUdnBlock(db, udn_schema, udn_arm, udn_arm_end, input, udn_data)
+		PopUdnScope(udn_data)
+	}
+
+	result := UdnResult{}
+	result.Result = current_input
+	result.NextUdnPart = udn_start.BlockEnd
+
+	return result
+}
+
+// Execute the UdnParts after udn_block_start, until udn_block_end, piping the input through them.  Stops if there is an error.  Returns the last output.
+func _ExecuteUdnBlock(db *sql.DB, udn_schema map[string]interface{}, udn_block_start *UdnPart, udn_block_end *UdnPart, input interface{}, udn_data map[string]interface{}) interface{} {
+	current_input := input
+
+	udn_current := udn_block_start
+
+	for udn_current.NextUdnPart != nil && udn_current.NextUdnPart != udn_block_end {
+		udn_current = udn_current.NextUdnPart
+
+		current_result := ExecuteUdnPart(db, udn_schema, udn_current, current_input, udn_data)
+		current_input = current_result.Result
+
+		if UdnErrorPending(udn_schema) || UdnLoopControlPending(udn_schema) {
+			break
+		}
+
+		// If we were told what our NextUdnPart is, jump ahead (end of an inner block)
+		if current_result.NextUdnPart != nil {
+			udn_current = current_result.NextUdnPart
+		}
+	}
+
+	return current_input
+}
+
 func UDN_Not(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart, args []interface{}, input interface{}, udn_data map[string]interface{}) UdnResult {
 	UdnLogLevel(udn_schema, log_trace, "Not: %v\n", SnippetData(input, 60))
 
@@ -4418,9 +4726,9 @@ func UDN_GroupBy(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPa
 		source_data = args[1].([]map[string]interface{})
 	}
 
-	method := strings.ToLower(args[0].(string))
-	aggregate_field := args[2].(string)
-	field := args[3].(string) //TODO(z): Make field variadic - Implement grouping on multiple fields - currently only supports grouping on one field  (when there is use case)
+	method := strings.ToLower(GetResult(args[0], type_string).(string))
+	aggregate_field := GetResult(args[2], type_string).(string)
+	field := GetResult(args[3], type_string).(string) //TODO(z): Make field variadic - Implement grouping on multiple fields - currently only supports grouping on one field  (when there is use case)
 
 	result_list := make([]map[string]interface{}, 0) // stores result array
 	result_map := make(map[string]interface{}) // stores all seen keys
@@ -4510,7 +4818,7 @@ func UDN_Math(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart,
 	}
 
 	all_integer := true // Flag used to determine whether we should do an integer operation
-	function := strings.ToLower(args[0].(string))
+	function := strings.ToLower(GetResult(args[0], type_string).(string))
 	operands := args[1:]
 	num_of_operands := len(operands)
 
@@ -4668,7 +4976,7 @@ func UDN_ExecCommand(db *sql.DB, udn_schema map[string]interface{}, udn_start *U
 	cmd_output, err := exec.Command(evaluated_args[0], evaluated_args[1:]...).Output()
 
 	if err != nil {
-		log.Panic(err)
+		return UdnResultError("Exec Command: %s: %s", evaluated_args[0], err)
 	}
 
 	UdnLogLevel(udn_schema, log_debug, "Exec_Command: Command '%s'\n", evaluated_args[0])
@@ -4686,15 +4994,14 @@ func UDN_HttpRequest(db *sql.DB, udn_schema map[string]interface{}, udn_start *U
 	//Send a http request to url
 	//E.g. __http_request.'GET'.'http://example.com', sends GET request to target url, returns an unmarshalled json if have any in the response.
 	//E.g. __input.{name=Bob}.__http_request.'POST'.'http://example.com', sends POST request to target url with {"name":"bob"}, returns an unmarshalled json if have any in the response.
-	//If error or failed or timeout (10 seconds by default), return nothing
+	//If error or failed or timeout (10 seconds by default), returns an error, which can be handled with __try/__catch
 
 	UdnLogLevel(udn_schema, log_debug,"Http Request: %v with input: %v\n", args, input)
 
 	result := UdnResult{}
 
 	if len(args) < 2 {
-		UdnLogLevel(udn_schema, log_error,"Insufficient args: %v\n", args)
-		return result
+		return UdnResultError("Http Request: Insufficient args: %v", args)
 	}
 
 	method := GetResult(args[0], type_string).(string)
@@ -4704,9 +5011,23 @@ func UDN_HttpRequest(db *sql.DB, udn_schema map[string]interface{}, udn_start *U
 	if len(args) > 2 {
 		timeout_secs = GetResult(args[2], type_float).(float64)
 	}
+	if message := CheckUdnPolicyUrl(udn_schema, method, url); message != "" {
+		return UdnResultError("Http Request: %s", message)
+	}
+
 	timeout := time.Duration(timeout_secs) * time.Second
 	client := http.Client{
 		Timeout: timeout,
+		// Redirects can go to another host, so they are checked against the policy too
+		CheckRedirect: func(redirect *http.Request, via []*http.Request) error {
+			if message := CheckUdnPolicyUrl(udn_schema, redirect.Method, redirect.URL.String()); message != "" {
+				return errors.New(message)
+			}
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return nil
+		},
 	}
 
 	var request *http.Request
@@ -4723,40 +5044,37 @@ func UDN_HttpRequest(db *sql.DB, udn_schema map[string]interface{}, udn_start *U
 				inputMap, ok = input.(map[string]interface{})
 				break
 			default:
-				UdnLogLevel(udn_schema, log_error,"Body format not supported, input: %v\n", input)
-				return result
+				return UdnResultError("Http Request: Body format not supported, input: %v", input)
 			}
 			if !ok {
-				UdnLogLevel(udn_schema, log_error,"Input format casting failed, input: %v\n", input)
-				return result
+				return UdnResultError("Http Request: Input format casting failed, input: %v", input)
 			}
 			jsonValue, err := json.Marshal(inputMap)
 			if err != nil {
-				UdnLogLevel(udn_schema, log_error,"Input json marshal error: %v\n", err)
-				return result
+				return UdnResultError("Http Request: Input json marshal error: %v", err)
 			}
 			request, err = http.NewRequest(method, url, bytes.NewBuffer(jsonValue))
 			if err != nil {
-				UdnLogLevel(udn_schema, log_error, "Cannot create a Http NewRequest: %v\n", err)
-				return result
+				return UdnResultError("Http Request: Cannot create a Http NewRequest: %v", err)
 			}
 		}
 	} else if method == "GET" || method == "DELETE"{
 		var err error
 		request, err = http.NewRequest(method, url, nil)
 		if err != nil {
-			UdnLogLevel(udn_schema, log_error, "Cannot create a Http NewRequest: %v\n", err)
-			return result
+			return UdnResultError("Http Request: Cannot create a Http NewRequest: %v", err)
 		}
 	} else {
-		UdnLogLevel(udn_schema, log_error, "Unsupported http request method: %v\n", method)
-		return result
+		return UdnResultError("Http Request: Unsupported http request method: %v", method)
 	}
 
-	resp, err := client.Do(request)
+	// The request is cancelled with the execution, or at its deadline, if that is before our timeout
+	request_context, request_cancel := UdnExecutionContext(udn_schema)
+	defer request_cancel()
+
+	resp, err := client.Do(request.WithContext(request_context))
 	if err != nil {
-		UdnLogLevel(udn_schema, log_error,"Http request failed or timed-out: %v\n", err)
-		return result
+		return UdnResultError("Http Request: Http request failed or timed-out: %v", err)
 	}
 
 	defer resp.Body.Close()
@@ -4765,12 +5083,11 @@ func UDN_HttpRequest(db *sql.DB, udn_schema map[string]interface{}, udn_start *U
 
 	body, readErr := ioutil.ReadAll(resp.Body)
 	if readErr != nil {
-		UdnLogLevel(udn_schema, log_error,"Response body read error: %v\n", readErr)
-		return result
+		return UdnResultError("Http Request: Response body read error: %v", readErr)
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode > 300{
-		UdnLogLevel(udn_schema, log_error,"Http request failed with status: %v\n", resp.Status)
+		return UdnResultError("Http Request: Http request failed with status: %v", resp.Status)
 	} else {
 		if method == "POST" || method == "PUT" || method == "DELETE"{
 			result.Result = resp.StatusCode
@@ -4779,8 +5096,7 @@ func UDN_HttpRequest(db *sql.DB, udn_schema map[string]interface{}, udn_start *U
 			if strings.Contains(contentType, "application/json"){
 				err = json.Unmarshal(body, &res)
 				if err != nil {
-					UdnLogLevel(udn_schema, log_error,"Response body unmarshal error: %v\n", err)
-					return result
+					return UdnResultError("Http Request: Response body unmarshal error: %v", err)
 				}
 				result.Result = res
 			} else {
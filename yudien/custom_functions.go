@@ -44,8 +44,7 @@ func UDN_Custom_PopulateScheduleDutyResponsibility(db *sql.DB, udn_schema map[st
 	result := UdnResult{}
 	result.Result = nil
 
-	options := make(map[string]interface{})
-	options["db"] = database
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": database})
 
 	// Get the Responsibility
 	responsibility := DatamanGet("duty_responsibility", int(responsibility_id), options)
@@ -126,7 +125,7 @@ func UDN_Custom_PopulateScheduleDutyResponsibility(db *sql.DB, udn_schema map[st
 	UdnLogLevel(udn_schema, log_trace, "CUSTOM: Populate Schedule: Duty Responsibility: Schedule Timeline Items: %v\n", timeline_items)
 
 
-	EvaluateShiftTimes(database, responsibility, shifts, start_time, business_user_id, roster_users, business_users)
+	EvaluateShiftTimes(udn_schema, database, responsibility, shifts, start_time, business_user_id, roster_users, business_users)
 
 
 	UdnLogLevel(udn_schema, log_trace, "CUSTOM: Populate Schedule: Duty Responsibility: Result: %v\n", result.Result)
@@ -134,13 +133,12 @@ func UDN_Custom_PopulateScheduleDutyResponsibility(db *sql.DB, udn_schema map[st
 	return result
 }
 
-func EvaluateShiftTimes(database string, responsibility map[string]interface{}, shifts []map[string]interface{}, start_time time.Time, business_user_id int64, roster_users []map[string]interface{}, business_users []map[string]interface{}) {
+func EvaluateShiftTimes(udn_schema map[string]interface{}, database string, responsibility map[string]interface{}, shifts []map[string]interface{}, start_time time.Time, business_user_id int64, roster_users []map[string]interface{}, business_users []map[string]interface{}) {
 	UdnLogLevel(nil, log_trace, "Evaluate Shift Times: %v\n", shifts)
 
 	time_layout := time_format_db
 
-	options := make(map[string]interface{})
-	options["db"] = database
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": database})
 
 	// How long we want to populate for; when we want to stop populating
 	population_duration := time.Duration(responsibility["populate_schedule_duration"].(int64)) * time.Second
@@ -394,8 +392,13 @@ func UDN_Custom_Code(db *sql.DB, udn_schema map[string]interface{}, udn_start *U
 }
 
 func CodeExecute(database string, code_id int, config_map map[string]interface{}, input interface{}, db *sql.DB, udn_schema map[string]interface{}, udn_data map[string]interface{}) interface{} {
-	options := make(map[string]interface{})
-	options["db"] = database
+	// Code can chain to itself.  If this is too deep, the execution is aborted, and the next function we execute fails with the error.
+	if message := PushUdnCallDepth(udn_schema, udn_data); message != "" {
+		return nil
+	}
+	defer PopUdnCallDepth(udn_data)
+
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": database})
 
 	code := DatamanGet("code", code_id, options)
 	filter := map[string]interface{}{
@@ -453,7 +456,7 @@ func UDN_Custom_Health_Check_PromQL(db *sql.DB, udn_schema map[string]interface{
 
 	business := udn_data["business"].(map[string]interface{})
 
-	HealthCheckPromQL(internal_database_name, config, api_server_connection_table, api_server_connection_id, business)
+	HealthCheckPromQL(udn_schema, internal_database_name, config, api_server_connection_table, api_server_connection_id, business)
 
 	result := UdnResult{}
 	result.Result = nil
@@ -461,7 +464,7 @@ func UDN_Custom_Health_Check_PromQL(db *sql.DB, udn_schema map[string]interface{
 	return result
 }
 
-func HealthCheckPromQL(internal_database_name string, config map[string]interface{}, api_server_connection_table string, api_server_connection_id int64, business map[string]interface{}) {
+func HealthCheckPromQL(udn_schema map[string]interface{}, internal_database_name string, config map[string]interface{}, api_server_connection_table string, api_server_connection_id int64, business map[string]interface{}) {
 	UdnLogLevel(nil, log_trace, "HealthCheckPromQL: Init: %s: %s: %d: %s\n", internal_database_name, api_server_connection_table, api_server_connection_id, JsonDump(config))
 	UdnLogLevel(nil, log_trace, "HealthCheckPromQL: Business: %s\n", JsonDump(business))
 
@@ -482,7 +485,7 @@ func HealthCheckPromQL(internal_database_name string, config map[string]interfac
 	step := 5
 
 	// Server info for API
-	options := map[string]interface{}{"db": internal_database_name}
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 	api_server := DatamanGet(api_server_connection_table, int(api_server_connection_id), options)
 
 	// Encode query_arg
@@ -561,7 +564,7 @@ func HealthCheckPromQL(internal_database_name string, config map[string]interfac
 
 			// If this is a match, create an outage item (and outage if none is open for new items)
 			if is_match {
-				PopulateOutageItem(internal_database_name, metric_map, value_array, percentage_of_match, match_percent, invert_match, business, business_environment_namespace, health_check, metric_map_hash)
+				PopulateOutageItem(udn_schema, internal_database_name, metric_map, value_array, percentage_of_match, match_percent, invert_match, business, business_environment_namespace, health_check, metric_map_hash)
 			}
 		}
 	} else {
@@ -585,12 +588,11 @@ func HealthCheckPromQL(internal_database_name string, config map[string]interfac
 }
 
 
-func PopulateOutageItem(internal_database_name string, metric_map map[string]interface{}, value_array []interface{}, percentage_of_match float64, match_percent float64, invert_match bool, business map[string]interface{}, business_environment_namespace map[string]interface{}, health_check map[string]interface{}, metric_map_hash string) {
+func PopulateOutageItem(udn_schema map[string]interface{}, internal_database_name string, metric_map map[string]interface{}, value_array []interface{}, percentage_of_match float64, match_percent float64, invert_match bool, business map[string]interface{}, business_environment_namespace map[string]interface{}, health_check map[string]interface{}, metric_map_hash string) {
 	UdnLogLevel(nil, log_trace, "PopulateOutageItem: %f: %f: %s: %v\n", percentage_of_match, match_percent, metric_map_hash, metric_map)
 
 	// Check to see if there are any open outages
-	options := make(map[string]interface{})
-	options["db"] = internal_database_name
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
 
 	// Check to see if this alert is part of the open outages, and update them
@@ -695,7 +697,7 @@ func PopulateOutageItem(internal_database_name string, metric_map map[string]int
 	outage_item := DatamanInsert("outage_item", new_outage_item, options)
 
 	// Outage Alert...  Starting
-	OutageAlert(internal_database_name, outage, outage_item, 1, health_check["escalation_policy_id"])
+	OutageAlert(udn_schema, internal_database_name, outage, outage_item, 1, health_check["escalation_policy_id"])
 
 	UdnLogLevel(nil, log_trace, "PopulateOutageItem: Service Outage Item: %v\n", outage_item)
 }
@@ -708,8 +710,7 @@ func UDN_Custom_Metric_Filter(db *sql.DB, udn_schema map[string]interface{}, udn
 
 	UdnLogLevel(udn_schema, log_trace, "CUSTOM: Metric: Filter: %v: %v\n", metric_name_array, labelset_map)
 
-	options := make(map[string]interface{})
-	options["db"] = internal_database_name
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
 
 	filter := map[string]interface{}{
@@ -776,7 +777,7 @@ func UDN_Custom_Metric_Get_Values(db *sql.DB, udn_schema map[string]interface{},
 	duration_ms := GetResult(args[1], type_int).(int64)
 	offset_ms := GetResult(args[2], type_int).(int64)
 
-	time_store_values := MetricGetValues(internal_database_name, duration_ms, offset_ms, input)
+	time_store_values := MetricGetValues(udn_schema, internal_database_name, duration_ms, offset_ms, input)
 
 	result := UdnResult{}
 	result.Result = time_store_values
@@ -784,11 +785,10 @@ func UDN_Custom_Metric_Get_Values(db *sql.DB, udn_schema map[string]interface{},
 	return result
 }
 
-func MetricGetValues(internal_database_name string, duration_ms int64, offset_ms int64, input interface{}) map[int64]interface{} {
+func MetricGetValues(udn_schema map[string]interface{}, internal_database_name string, duration_ms int64, offset_ms int64, input interface{}) map[int64]interface{} {
 	UdnLogLevel(nil, log_trace, "MetricGetValues: %d: %d\n", duration_ms, offset_ms)
 
-	options := make(map[string]interface{})
-	options["db"] = internal_database_name
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
 	time_store_values := make(map[int64]interface{})
 
@@ -821,8 +821,7 @@ func UDN_Custom_Metric_Rule_Match_Percent(db *sql.DB, udn_schema map[string]inte
 func MetricRuleMatchPercent(internal_database_name string, rules []interface{}, input map[int64]interface{}) map[int64]float64 {
 	UdnLogLevel(nil, log_trace, "MetricRuleMatchPercent: %v\n", rules)
 
-	options := make(map[string]interface{})
-	options["db"] = internal_database_name
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
 	input_val := input
 
@@ -953,8 +952,7 @@ func UDN_Custom_Metric_Handle_Outage(db *sql.DB, udn_schema map[string]interface
 	UdnLogLevel(udn_schema, log_trace, "CUSTOM: Metric: Handle Outage: Config: %s\n", JsonDump(config))
 	UdnLogLevel(udn_schema, log_trace, "CUSTOM: Metric: Handle Outage: Input: %s\n", JsonDump(input_val))
 
-	options := make(map[string]interface{})
-	options["db"] = internal_database_name
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
 	alert_threshold := GetResult(config["alert_threshold"], type_float).(float64)
 
@@ -964,7 +962,7 @@ func UDN_Custom_Metric_Handle_Outage(db *sql.DB, udn_schema map[string]interface
 			UdnLogLevel(udn_schema, log_trace, "CUSTOM: Metric: Handle Outage: Alert: %d: %f < %f\n", time_store_item_id, value, alert_threshold)
 
 			if config["health_check"] != nil {
-				MetricPopulateOutage(internal_database_name, config, time_store_item_id, value, alert_threshold)
+				MetricPopulateOutage(udn_schema, internal_database_name, config, time_store_item_id, value, alert_threshold)
 			} else {
 				UdnLogLevel(udn_schema, log_trace, "WARNNG: Metric: Handle Outage: Cant Populate Outage, because Health Check data is missing from config: health_check == nil\n")
 			}
@@ -978,14 +976,13 @@ func UDN_Custom_Metric_Handle_Outage(db *sql.DB, udn_schema map[string]interface
 	return result
 }
 
-func MetricPopulateOutage(internal_database_name string, config map[string]interface{}, time_store_item_id int64, value float64, alert_threshold float64) {
+func MetricPopulateOutage(udn_schema map[string]interface{}, internal_database_name string, config map[string]interface{}, time_store_item_id int64, value float64, alert_threshold float64) {
 	health_check := config["health_check"].(map[string]interface{})
 
 	UdnLogLevel(nil, log_trace, "CUSTOM: Metric: Populate Outage: %d: %f: %s\n", time_store_item_id, value, health_check["name"])
 
 	// Check to see if there are any open outages
-	options := make(map[string]interface{})
-	options["db"] = internal_database_name
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
 
 	// Check to see if this alert is part of the open outages, and update them
@@ -1042,7 +1039,7 @@ func MetricPopulateOutage(internal_database_name string, config map[string]inter
 	outage_item := DatamanInsert("outage_item", new_outage_item, options)
 
 	// Outage Alert...  Starting
-	OutageAlert(internal_database_name, outage, 1, health_check["escalation_policy_id"])
+	OutageAlert(udn_schema, internal_database_name, outage, 1, health_check["escalation_policy_id"])
 
 	UdnLogLevel(nil, log_trace, "CUSTOM: Metric: Populate Outage: Service Outage Item: %v\n", outage_item)
 
@@ -1054,7 +1051,7 @@ func UDN_Custom_Metric_Process_Open_Outages(db *sql.DB, udn_schema map[string]in
 	internal_database_name := GetResult(args[0], type_string).(string)
 
 	// Do all the work here, so I can call it from Go as well as UDN.  Need to cover the complex ground outside of UDN for now.
-	ProcessOpenOutages(internal_database_name)
+	ProcessOpenOutages(udn_schema, internal_database_name)
 
 	result := UdnResult{}
 	result.Result = nil
@@ -1062,10 +1059,9 @@ func UDN_Custom_Metric_Process_Open_Outages(db *sql.DB, udn_schema map[string]in
 	return result
 }
 
-func ProcessOpenOutages(internal_database_name string) {
+func ProcessOpenOutages(udn_schema map[string]interface{}, internal_database_name string) {
 	// Check to see if there are any open outages
-	options := make(map[string]interface{})
-	options["db"] = internal_database_name
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
 
 	//TODO(g): Check to see if we need to alert again, or we can close the outage, or if we are flapping, etc.  This is the state handler.
@@ -1087,7 +1083,7 @@ func ProcessOpenOutages(internal_database_name string) {
 		businsess_environment_namespace := DatamanGet("businsess_environment_namespace", int(outage_item["business_environment_namespace_metric_id"].(int64)), options)
 
 		businsess_environment_namespace_array := []map[string]interface{}{businsess_environment_namespace}
-		time_store_values := MetricGetValues(internal_database_name, health_check["duration_ms"].(int64), health_check["offset_ms"].(int64), businsess_environment_namespace_array)
+		time_store_values := MetricGetValues(udn_schema, internal_database_name, health_check["duration_ms"].(int64), health_check["offset_ms"].(int64), businsess_environment_namespace_array)
 
 		// Get the percentage
 		rules := health_check["code_data_json"].(map[string]interface{})["rules"].([]interface{})
@@ -1123,17 +1119,16 @@ func ProcessOpenOutages(internal_database_name string) {
 			outage_result := DatamanSet("outage", outage, options)
 
 			// Outage Alert...  Stopping
-			OutageAlert(internal_database_name, outage_result, 3, nil)
+			OutageAlert(udn_schema, internal_database_name, outage_result, 3, nil)
 		}
 	}
 
 }
 */
 
-func OutageAlert(internal_database_name string, outage map[string]interface{}, outage_item map[string]interface{}, outage_alert_notication_type int64, escalation_policy_id interface{}) {
+func OutageAlert(udn_schema map[string]interface{}, internal_database_name string, outage map[string]interface{}, outage_item map[string]interface{}, outage_alert_notication_type int64, escalation_policy_id interface{}) {
 	// Check to see if there are any open outages
-	options := make(map[string]interface{})
-	options["db"] = internal_database_name
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
 	//TODO(g): Make a decision making system here.  For now, I am just doing the simple "make alert when told" thing.
 
@@ -1179,7 +1174,7 @@ func OutageAlert(internal_database_name string, outage map[string]interface{}, o
 		//TODO(g): Get this from the Escalation Policy Method
 		new_alert_notification["alert_notification_method_id"] = 1 // Email
 
-		escalation_policy_item_id, escalation_policy_item_info := GetAlertEscalationPolicyItemIdAndInfo(internal_database_name, alert)
+		escalation_policy_item_id, escalation_policy_item_info := GetAlertEscalationPolicyItemIdAndInfo(udn_schema, internal_database_name, alert)
 		if escalation_policy_item_id == -1 {
 			UdnLogLevel(nil, log_error, "OutageAlert: ERROR: No Escalation Policy found for Alert: Service Outage: %v -- Alert: %v\n", outage, alert)
 			return
@@ -1187,7 +1182,7 @@ func OutageAlert(internal_database_name string, outage map[string]interface{}, o
 
 		new_alert_notification["escalation_policy_item_id"] = escalation_policy_item_id
 		new_alert_notification["escalation_policy_item_info"] = escalation_policy_item_info
-		new_alert_notification["business_user_contact_id"] = GetEscalationPolicyUserContactId(internal_database_name, alert["escalation_policy_id"].(int64), time.Now())
+		new_alert_notification["business_user_contact_id"] = GetEscalationPolicyUserContactId(udn_schema, internal_database_name, alert["escalation_policy_id"].(int64), time.Now())
 
 		_ = DatamanInsert("alert_notification", new_alert_notification, options)
 
@@ -1249,7 +1244,7 @@ func OutageAlert(internal_database_name string, outage map[string]interface{}, o
 		//TODO(g): Get this from the Escalation Policy Method
 		new_alert_notification["alert_notification_method_id"] = 1 // Email
 
-		escalation_policy_item_id, escalation_policy_item_info := GetAlertEscalationPolicyItemIdAndInfo(internal_database_name, alert)
+		escalation_policy_item_id, escalation_policy_item_info := GetAlertEscalationPolicyItemIdAndInfo(udn_schema, internal_database_name, alert)
 		if escalation_policy_item_id == -1 {
 			UdnLogLevel(nil, log_error, "OutageAlert: ERROR: No Escalation Policy found for Alert: Service Outage: %v -- Alert: %v\n", outage, alert)
 			return
@@ -1257,7 +1252,7 @@ func OutageAlert(internal_database_name string, outage map[string]interface{}, o
 
 		new_alert_notification["escalation_policy_item_id"] = escalation_policy_item_id
 		new_alert_notification["escalation_policy_item_info"] = escalation_policy_item_info
-		new_alert_notification["business_user_contact_id"] = GetEscalationPolicyUserContactId(internal_database_name, alert["escalation_policy_id"].(int64), time.Now())
+		new_alert_notification["business_user_contact_id"] = GetEscalationPolicyUserContactId(udn_schema, internal_database_name, alert["escalation_policy_id"].(int64), time.Now())
 
 		alert_notification := DatamanInsert("alert_notification", new_alert_notification, options)
 
@@ -1271,9 +1266,8 @@ func OutageAlert(internal_database_name string, outage map[string]interface{}, o
 
 }
 
-func GetEscalationPolicyUserContactId(internal_database_name string, escalation_policy_item_id int64, at_time time.Time) int64 {
-	options := make(map[string]interface{})
-	options["db"] = internal_database_name
+func GetEscalationPolicyUserContactId(udn_schema map[string]interface{}, internal_database_name string, escalation_policy_item_id int64, at_time time.Time) int64 {
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
 	var business_user_contact_id int64
 
@@ -1317,9 +1311,8 @@ func GetEscalationPolicyUserContactId(internal_database_name string, escalation_
 	return business_user_contact_id
 }
 
-func GetAlertEscalationPolicyItemIdAndInfo(internal_database_name string, alert map[string]interface{}) (int64, string) {
-	options := make(map[string]interface{})
-	options["db"] = internal_database_name
+func GetAlertEscalationPolicyItemIdAndInfo(udn_schema map[string]interface{}, internal_database_name string, alert map[string]interface{}) (int64, string) {
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
 	//TODO(g): Make a decision making system here.  For now, I am just doing the simple "make alert when told" thing.
 
@@ -1345,7 +1338,7 @@ func UDN_Custom_Metric_Process_Alert_Notifications(db *sql.DB, udn_schema map[st
 	internal_database_name := GetResult(args[0], type_string).(string)
 
 	// Do all the work here, so I can call it from Go as well as UDN.  Need to cover the complex ground outside of UDN for now.
-	ProcessAlertNotifications(internal_database_name)
+	ProcessAlertNotifications(udn_schema, internal_database_name)
 
 	result := UdnResult{}
 	result.Result = nil
@@ -1353,9 +1346,8 @@ func UDN_Custom_Metric_Process_Alert_Notifications(db *sql.DB, udn_schema map[st
 	return result
 }
 
-func ProcessAlertNotifications(internal_database_name string) {
-	options := make(map[string]interface{})
-	options["db"] = internal_database_name
+func ProcessAlertNotifications(udn_schema map[string]interface{}, internal_database_name string) {
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
 	UdnLogLevel(nil, log_trace, "ProcessAlertNotifications\n")
 
@@ -1366,13 +1358,12 @@ func ProcessAlertNotifications(internal_database_name string) {
 	alert_notification_array := DatamanFilter("alert_notification", filter, options)
 
 	for _, alert_notification := range alert_notification_array {
-		SendAlert(internal_database_name, alert_notification)
+		SendAlert(udn_schema, internal_database_name, alert_notification)
 	}
 }
 
-func SendAlert(internal_database_name string, alert_notification map[string]interface{}) {
-	options := make(map[string]interface{})
-	options["db"] = internal_database_name
+func SendAlert(udn_schema map[string]interface{}, internal_database_name string, alert_notification map[string]interface{}) {
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
 	business_user_contact := DatamanGet("business_user_contact", int(alert_notification["business_user_contact_id"].(int64)), options)
 	business_user := DatamanGet("business_user", int(business_user_contact["business_user_id"].(int64)), options)
@@ -1426,7 +1417,7 @@ func UDN_Custom_Metric_Escalation_Policy_Oncall(db *sql.DB, udn_schema map[strin
 	escalation_policy_id:= GetResult(args[1], type_int).(int64)
 
 	// Do all the work here, so I can call it from Go as well as UDN.  Need to cover the complex ground outside of UDN for now.
-	data := EscalationPolicyGetOncall(internal_database_name, escalation_policy_id, time.Now())
+	data := EscalationPolicyGetOncall(udn_schema, internal_database_name, escalation_policy_id, time.Now())
 
 	result := UdnResult{}
 	result.Result = data
@@ -1434,18 +1425,17 @@ func UDN_Custom_Metric_Escalation_Policy_Oncall(db *sql.DB, udn_schema map[strin
 	return result
 }
 
-func EscalationPolicyGetOncall(internal_database_name string, escalation_policy_id int64, at_time time.Time) map[string]interface{} {
+func EscalationPolicyGetOncall(udn_schema map[string]interface{}, internal_database_name string, escalation_policy_id int64, at_time time.Time) map[string]interface{} {
 	options := make(map[string]interface{})
 	options["db"] = internal_database_name
 
-	data := GetEscalationPolicyInfo(internal_database_name, escalation_policy_id, at_time)
+	data := GetEscalationPolicyInfo(udn_schema, internal_database_name, escalation_policy_id, at_time)
 
 	return data
 }
 
-func GetEscalationPolicyInfo(internal_database_name string, escalation_policy_id int64, at_time time.Time) map[string]interface{} {
-	options := make(map[string]interface{})
-	options["db"] = internal_database_name
+func GetEscalationPolicyInfo(udn_schema map[string]interface{}, internal_database_name string, escalation_policy_id int64, at_time time.Time) map[string]interface{} {
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
 	filter := map[string]interface{}{
 		"escalation_policy_id": []interface{}{"=", escalation_policy_id},
@@ -1460,7 +1450,7 @@ func GetEscalationPolicyInfo(internal_database_name string, escalation_policy_id
 	oncall_users := ""
 
 	for _, escalation_policy_item := range escalation_policy_item_array {
-		item := GetEscalationPolicyItemInfo(internal_database_name, escalation_policy_item["_id"].(int64), at_time)
+		item := GetEscalationPolicyItemInfo(udn_schema, internal_database_name, escalation_policy_item["_id"].(int64), at_time)
 
 		UdnLogLevel(nil, log_trace, "GetEscalationPolicyInfo: %d: %v\n", escalation_policy_item["_id"], item)
 
@@ -1482,9 +1472,8 @@ func GetEscalationPolicyInfo(internal_database_name string, escalation_policy_id
 	return data
 }
 
-func GetEscalationPolicyItemInfo(internal_database_name string, escalation_policy_item_id int64, at_time time.Time) map[string]interface{} {
-	options := make(map[string]interface{})
-	options["db"] = internal_database_name
+func GetEscalationPolicyItemInfo(udn_schema map[string]interface{}, internal_database_name string, escalation_policy_item_id int64, at_time time.Time) map[string]interface{} {
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
 	// Make our return map data
 	data := make(map[string]interface{})
@@ -1553,7 +1542,7 @@ func UDN_Custom_Monitor_Post_Process_Change(db *sql.DB, udn_schema map[string]in
 
 
 	// Do all the work here, so I can call it from Go as well as UDN.  Need to cover the complex ground outside of UDN for now.
-	error_map := MonitorPostProcessChange(internal_database_name, ts_database_table, ts_connection_database_name, ts_tablename, api_server_connection_table, api_server_connection_id)
+	error_map := MonitorPostProcessChange(udn_schema, internal_database_name, ts_database_table, ts_connection_database_name, ts_tablename, api_server_connection_table, api_server_connection_id)
 
 	result := UdnResult{}
 	result.Result = error_map
@@ -1561,9 +1550,8 @@ func UDN_Custom_Monitor_Post_Process_Change(db *sql.DB, udn_schema map[string]in
 	return result
 }
 
-func MonitorPostProcessChange(internal_database_name string, ts_database_table string, ts_connection_database_name string, ts_tablename string, api_server_connection_table string, api_server_connection_id int64) map[string]interface{} {
-	options := make(map[string]interface{})
-	options["db"] = internal_database_name
+func MonitorPostProcessChange(udn_schema map[string]interface{}, internal_database_name string, ts_database_table string, ts_connection_database_name string, ts_tablename string, api_server_connection_table string, api_server_connection_id int64) map[string]interface{} {
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
 	// Find Monitors that dont have metrics, create the metrics and add to Api
 	filter := make(map[string]interface{})
@@ -1611,12 +1599,12 @@ func MonitorPostProcessChange(internal_database_name string, ts_database_table s
 			DatamanSet("time_store_item", time_store_item, options)
 
 			// Add the Monitor to Api
-			Api_AddTask(internal_database_name, monitor["_id"].(int64), ts_database_table, ts_connection_database_name, ts_tablename, api_server_connection_table, api_server_connection_id)
+			Api_AddTask(udn_schema, internal_database_name, monitor["_id"].(int64), ts_database_table, ts_connection_database_name, ts_tablename, api_server_connection_table, api_server_connection_id)
 		}
 	}
 
 	// Go through all our monitors and update any that have changed, add any that are missing, remove (STOP) any that we dont know about
-	MonitorUpdateAll(internal_database_name, ts_database_table, ts_connection_database_name, ts_tablename, api_server_connection_table, api_server_connection_id)
+	MonitorUpdateAll(udn_schema, internal_database_name, ts_database_table, ts_connection_database_name, ts_tablename, api_server_connection_table, api_server_connection_id)
 
 
 	// If we have errors, put them back in with field_label dotted keys, so we can re-render them in the form
@@ -1624,15 +1612,14 @@ func MonitorPostProcessChange(internal_database_name string, ts_database_table s
 	return error_map
 }
 
-func MonitorUpdateAll(internal_database_name string, ts_database_table string, ts_connection_database_name string, ts_tablename string, api_server_connection_table string, api_server_connection_id int64) {
-	options := make(map[string]interface{})
-	options["db"] = internal_database_name
+func MonitorUpdateAll(udn_schema map[string]interface{}, internal_database_name string, ts_database_table string, ts_connection_database_name string, ts_tablename string, api_server_connection_table string, api_server_connection_id int64) {
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
 	filter := make(map[string]interface{})
 	monitor_list := DatamanFilter("service_monitor", filter, options)
 
 	// Get all our current Api tasks
-	tasks := Api_GetTasks(internal_database_name, api_server_connection_table, api_server_connection_id)
+	tasks := Api_GetTasks(udn_schema, internal_database_name, api_server_connection_table, api_server_connection_id)
 
 	UdnLogLevel(nil, log_trace, "CUSTOM: MonitorUpdateAll: Tasks: %s\n", JsonDump(tasks))
 
@@ -1648,19 +1635,19 @@ func MonitorUpdateAll(internal_database_name string, ts_database_table string, t
 		if tasks[time_store_item_id_str] != nil {
 			UdnLogLevel(nil, log_trace, "CUSTOM: MonitorUpdateAll: FOUND: %s: %d\n", service_monitor["name"], business_environment_namespace_metric["time_store_item_id"])
 
-			Api_StopTask(internal_database_name, business_environment_namespace_metric["time_store_item_id"].(int64), api_server_connection_table, api_server_connection_id)
-			Api_AddTask_Delay(internal_database_name, service_monitor["_id"].(int64), ts_database_table, ts_connection_database_name, ts_tablename, api_server_connection_table, api_server_connection_id)
+			Api_StopTask(udn_schema, internal_database_name, business_environment_namespace_metric["time_store_item_id"].(int64), api_server_connection_table, api_server_connection_id)
+			Api_AddTask_Delay(udn_schema, internal_database_name, service_monitor["_id"].(int64), ts_database_table, ts_connection_database_name, ts_tablename, api_server_connection_table, api_server_connection_id)
 		} else {
 			UdnLogLevel(nil, log_trace, "CUSTOM: MonitorUpdateAll: NOT FOUND: %s: %d\n", service_monitor["name"], business_environment_namespace_metric["time_store_item_id"])
 
-			Api_AddTask(internal_database_name, service_monitor["_id"].(int64), ts_database_table, ts_connection_database_name, ts_tablename, api_server_connection_table, api_server_connection_id)
+			Api_AddTask(udn_schema, internal_database_name, service_monitor["_id"].(int64), ts_database_table, ts_connection_database_name, ts_tablename, api_server_connection_table, api_server_connection_id)
 		}
 
 		time_store_item_monitor_map[time_store_item_id_str] = service_monitor
 	}
 
 	// Update the tasks after our above changes
-	tasks = Api_GetTasks(internal_database_name, api_server_connection_table, api_server_connection_id)
+	tasks = Api_GetTasks(udn_schema, internal_database_name, api_server_connection_table, api_server_connection_id)
 
 	UdnLogLevel(nil, log_trace, "CUSTOM: MonitorUpdateAll: Task Map: %s\n", JsonDump(tasks))
 	UdnLogLevel(nil, log_trace, "CUSTOM: MonitorUpdateAll: Time Store Monitor Map: %s\n", JsonDump(time_store_item_monitor_map))
@@ -1675,15 +1662,14 @@ func MonitorUpdateAll(internal_database_name string, ts_database_table string, t
 				UdnLogLevel(nil, log_trace, "CUSTOM: MonitorUpdateAll: Remove: Error parsing key: %s\n", task_key)
 			} else {
 				UdnLogLevel(nil, log_trace, "CUSTOM: MonitorUpdateAll: Remove: Stopping: %s\n", task_key)
-				Api_StopTask(internal_database_name, task_id, api_server_connection_table, api_server_connection_id)
+				Api_StopTask(udn_schema, internal_database_name, task_id, api_server_connection_table, api_server_connection_id)
 			}
 		}
 	}
 }
 
-func Api_GetTasks(internal_database_name string, api_server_connection_table string, api_server_connection_id int64) map[string]interface{} {
-	options := make(map[string]interface{})
-	options["db"] = internal_database_name
+func Api_GetTasks(udn_schema map[string]interface{}, internal_database_name string, api_server_connection_table string, api_server_connection_id int64) map[string]interface{} {
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
 	api_server := DatamanGet(api_server_connection_table, int(api_server_connection_id), options)
 
@@ -1699,9 +1685,8 @@ func Api_GetTasks(internal_database_name string, api_server_connection_table str
 	return result_map
 }
 
-func Api_GetData(internal_database_name string, service_monitor_id int64, ts_database_table string, ts_connection_database_name string, ts_tablename string, api_server_connection_table string, api_server_connection_id int64) map[string]interface{} {
-	options := make(map[string]interface{})
-	options["db"] = internal_database_name
+func Api_GetData(udn_schema map[string]interface{}, internal_database_name string, service_monitor_id int64, ts_database_table string, ts_connection_database_name string, ts_tablename string, api_server_connection_table string, api_server_connection_id int64) map[string]interface{} {
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
 	service_monitor := DatamanGet("service_monitor", int(service_monitor_id), options)
 	service_monitor_type := DatamanGet("service_monitor_type", int(service_monitor["service_monitor_type_id"].(int64)), options)
@@ -1760,11 +1745,10 @@ func Api_GetData(internal_database_name string, service_monitor_id int64, ts_dat
 	return data
 }
 
-func Api_UpdateTask(internal_database_name string, service_monitor_id int64, ts_database_table string, ts_connection_database_name string, ts_tablename string, api_server_connection_table string, api_server_connection_id int64) bool {
-	options := make(map[string]interface{})
-	options["db"] = internal_database_name
+func Api_UpdateTask(udn_schema map[string]interface{}, internal_database_name string, service_monitor_id int64, ts_database_table string, ts_connection_database_name string, ts_tablename string, api_server_connection_table string, api_server_connection_id int64) bool {
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
-	data := Api_GetData(internal_database_name, service_monitor_id, ts_database_table, ts_connection_database_name, ts_tablename, api_server_connection_table, api_server_connection_id)
+	data := Api_GetData(udn_schema, internal_database_name, service_monitor_id, ts_database_table, ts_connection_database_name, ts_tablename, api_server_connection_table, api_server_connection_id)
 
 	UdnLogLevel(nil, log_trace, "CUSTOM: Api: Update Task: %s\n", JsonDump(data))
 
@@ -1779,9 +1763,8 @@ func Api_UpdateTask(internal_database_name string, service_monitor_id int64, ts_
 	return true
 }
 
-func Api_StopTask(internal_database_name string, time_store_item_id int64, api_server_connection_table string, api_server_connection_id int64) bool {
-	options := make(map[string]interface{})
-	options["db"] = internal_database_name
+func Api_StopTask(udn_schema map[string]interface{}, internal_database_name string, time_store_item_id int64, api_server_connection_table string, api_server_connection_id int64) bool {
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
 	api_server := DatamanGet(api_server_connection_table, int(api_server_connection_id), options)
 
@@ -1794,20 +1777,19 @@ func Api_StopTask(internal_database_name string, time_store_item_id int64, api_s
 	return true
 }
 
-func Api_AddTask_Delay(internal_database_name string, service_monitor_id int64, ts_database_table string, ts_connection_database_name string, ts_tablename string, api_server_connection_table string, api_server_connection_id int64) bool {
+func Api_AddTask_Delay(udn_schema map[string]interface{}, internal_database_name string, service_monitor_id int64, ts_database_table string, ts_connection_database_name string, ts_tablename string, api_server_connection_table string, api_server_connection_id int64) bool {
 	// Defer the add for a few seconds, allowing the stop to finish
 	time.Sleep(5 * time.Second)
 
-	result := Api_AddTask(internal_database_name, service_monitor_id, ts_database_table, ts_connection_database_name, ts_tablename, api_server_connection_table, api_server_connection_id)
+	result := Api_AddTask(udn_schema, internal_database_name, service_monitor_id, ts_database_table, ts_connection_database_name, ts_tablename, api_server_connection_table, api_server_connection_id)
 
 	return result
 }
 
-func Api_AddTask(internal_database_name string, service_monitor_id int64, ts_database_table string, ts_connection_database_name string, ts_tablename string, api_server_connection_table string, api_server_connection_id int64) bool {
-	options := make(map[string]interface{})
-	options["db"] = internal_database_name
+func Api_AddTask(udn_schema map[string]interface{}, internal_database_name string, service_monitor_id int64, ts_database_table string, ts_connection_database_name string, ts_tablename string, api_server_connection_table string, api_server_connection_id int64) bool {
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
-	data := Api_GetData(internal_database_name, service_monitor_id, ts_database_table, ts_connection_database_name, ts_tablename, api_server_connection_table, api_server_connection_id)
+	data := Api_GetData(udn_schema, internal_database_name, service_monitor_id, ts_database_table, ts_connection_database_name, ts_tablename, api_server_connection_table, api_server_connection_id)
 
 	UdnLogLevel(nil, log_trace, "CUSTOM: Api: Add Task: %s\n", JsonDump(data))
 
@@ -1831,7 +1813,7 @@ func UDN_Custom_Duty_Shift_Summary(db *sql.DB, udn_schema map[string]interface{}
 
 
 	// Do all the work here, so I can call it from Go as well as UDN.  Need to cover the complex ground outside of UDN for now.
-	error_map := GetDutyShiftSummary(internal_database_name, duty_id, time_start, time_stop)
+	error_map := GetDutyShiftSummary(udn_schema, internal_database_name, duty_id, time_start, time_stop)
 
 	result := UdnResult{}
 	result.Result = error_map
@@ -1839,9 +1821,8 @@ func UDN_Custom_Duty_Shift_Summary(db *sql.DB, udn_schema map[string]interface{}
 	return result
 }
 
-func GetDutyShiftSummary(internal_database_name string, duty_id int64, time_start time.Time, time_stop time.Time) []map[string]interface{} {
-	options := make(map[string]interface{})
-	options["db"] = internal_database_name
+func GetDutyShiftSummary(udn_schema map[string]interface{}, internal_database_name string, duty_id int64, time_start time.Time, time_stop time.Time) []map[string]interface{} {
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
 	duty := DatamanGet("duty", int(duty_id), options)
 
@@ -1924,7 +1905,7 @@ func UDN_Custom_Duty_Responsibility_Current_User(db *sql.DB, udn_schema map[stri
 	duty_responsibility_id := GetResult(args[1], type_int).(int64)
 
 	// Do all the work here, so I can call it from Go as well as UDN.  Need to cover the complex ground outside of UDN for now.
-	user := GetDutyResponsibilityCurrentUser(internal_database_name, duty_responsibility_id)
+	user := GetDutyResponsibilityCurrentUser(udn_schema, internal_database_name, duty_responsibility_id)
 
 	result := UdnResult{}
 	result.Result = user
@@ -1932,9 +1913,8 @@ func UDN_Custom_Duty_Responsibility_Current_User(db *sql.DB, udn_schema map[stri
 	return result
 }
 
-func GetDutyResponsibilityCurrentUser(internal_database_name string, duty_responsibility_id int64) map[string]interface{} {
-	options := make(map[string]interface{})
-	options["db"] = internal_database_name
+func GetDutyResponsibilityCurrentUser(udn_schema map[string]interface{}, internal_database_name string, duty_responsibility_id int64) map[string]interface{} {
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
 
 	duty_responsibility := DatamanGet("duty_responsibility", int(duty_responsibility_id), options)
@@ -1963,7 +1943,7 @@ func UDN_Custom_Duty_Roster_User_Shift_Info(db *sql.DB, udn_schema map[string]in
 	duty_responsibility_id := GetResult(args[2], type_int).(int64)
 
 	// Do all the work here, so I can call it from Go as well as UDN.  Need to cover the complex ground outside of UDN for now.
-	user := GetDutyRosterUserShiftInfo(internal_database_name, duty_roster_id, duty_responsibility_id)
+	user := GetDutyRosterUserShiftInfo(udn_schema, internal_database_name, duty_roster_id, duty_responsibility_id)
 
 	result := UdnResult{}
 	result.Result = user
@@ -1971,9 +1951,8 @@ func UDN_Custom_Duty_Roster_User_Shift_Info(db *sql.DB, udn_schema map[string]in
 	return result
 }
 
-func GetDutyRosterUserShiftInfo(internal_database_name string, duty_roster_id int64, duty_responsibility_id int64) []map[string]interface{} {
-	options := make(map[string]interface{})
-	options["db"] = internal_database_name
+func GetDutyRosterUserShiftInfo(udn_schema map[string]interface{}, internal_database_name string, duty_roster_id int64, duty_responsibility_id int64) []map[string]interface{} {
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
 	result_array := make([]map[string]interface{}, 0)
 
@@ -2066,7 +2045,7 @@ func UDN_Custom_Activity_Daily(db *sql.DB, udn_schema map[string]interface{}, ud
 	}
 
 	// Do all the work here, so I can call it from Go as well as UDN.  Need to cover the complex ground outside of UDN for now.
-	data := ActivityDaily(internal_database_name, table_name, time_start_field_name, int(days), field_match_map, time_start)
+	data := ActivityDaily(udn_schema, internal_database_name, table_name, time_start_field_name, int(days), field_match_map, time_start)
 
 	result := UdnResult{}
 	result.Result = data
@@ -2074,12 +2053,11 @@ func UDN_Custom_Activity_Daily(db *sql.DB, udn_schema map[string]interface{}, ud
 	return result
 }
 
-func ActivityDaily(internal_database_name string, table_name string, time_start_field_name string, days int, field_match_map map[string]interface{}, time_start_str string) map[string]interface{} {
-	options := make(map[string]interface{})
-	options["db"] = internal_database_name
+func ActivityDaily(udn_schema map[string]interface{}, internal_database_name string, table_name string, time_start_field_name string, days int, field_match_map map[string]interface{}, time_start_str string) map[string]interface{} {
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
 	// All queries must have business_id in their table schema, because we need to enforce security
-	business := GetUserBusiness(internal_database_name)
+	business := GetUserBusiness(udn_schema, internal_database_name)
 
 	start := time.Now()
 	if time_start_str != "" {
@@ -2155,9 +2133,8 @@ func ActivityDaily(internal_database_name string, table_name string, time_start_
 	return result_map
 }
 
-func GetUserBusiness(internal_database_name string) map[string]interface{} {
-	options := make(map[string]interface{})
-	options["db"] = internal_database_name
+func GetUserBusiness(udn_schema map[string]interface{}, internal_database_name string) map[string]interface{} {
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
 	//TODO(g): Actually get this from the current user
 	business_id := 1
@@ -2253,7 +2230,7 @@ func UDN_Custom_Dashboard_Item_Edit(db *sql.DB, udn_schema map[string]interface{
 	input_data_map := GetResult(args[6], type_map).(map[string]interface{})
 
 	// Do all the work here, so I can call it from Go as well as UDN.  Need to cover the complex ground outside of UDN for now.
-	data := DashboardItemEdit(internal_database_name, business, dashboard_item_id_or_nil, input_map, input_data_map, api_server_connection_table, api_server_connection_id)
+	data := DashboardItemEdit(udn_schema, internal_database_name, business, dashboard_item_id_or_nil, input_map, input_data_map, api_server_connection_table, api_server_connection_id)
 
 	result := UdnResult{}
 	result.Result = data
@@ -2261,12 +2238,11 @@ func UDN_Custom_Dashboard_Item_Edit(db *sql.DB, udn_schema map[string]interface{
 	return result
 }
 
-func DashboardItemEdit(internal_database_name string, business map[string]interface{}, dashboard_item_id_or_nil interface{}, input_map map[string]interface{}, input_data_map map[string]interface{}, api_server_connection_table string, api_server_connection_id int64) map[string]interface{} {
-	options := make(map[string]interface{})
-	options["db"] = internal_database_name
+func DashboardItemEdit(udn_schema map[string]interface{}, internal_database_name string, business map[string]interface{}, dashboard_item_id_or_nil interface{}, input_map map[string]interface{}, input_data_map map[string]interface{}, api_server_connection_table string, api_server_connection_id int64) map[string]interface{} {
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
 	//// All queries must have business_id in their table schema, because we need to enforce security
-	//business := GetUserBusiness(internal_database_name)
+	//business := GetUserBusiness(udn_schema, internal_database_name)
 
 	// Assume this is a new dashboard_item
 	graph := make(map[string]interface{})
@@ -2589,7 +2565,7 @@ func UDN_Custom_Dataman_Create_Filter_Html(db *sql.DB, udn_schema map[string]int
 	input_map := GetResult(args[3], type_map).(map[string]interface{})
 
 	// Do all the work here, so I can call it from Go as well as UDN.  Need to cover the complex ground outside of UDN for now.
-	html := DatamanCreateFilterHtml(internal_database_name, field_label, filter_array, input_map)
+	html := DatamanCreateFilterHtml(udn_schema, internal_database_name, field_label, filter_array, input_map)
 
 	result := UdnResult{}
 	result.Result = html
@@ -2597,9 +2573,8 @@ func UDN_Custom_Dataman_Create_Filter_Html(db *sql.DB, udn_schema map[string]int
 	return result
 }
 
-func DatamanCreateFilterHtml(internal_database_name string, field_label string, filter_array []interface{}, input_map map[string]interface{}) string {
-	options := make(map[string]interface{})
-	options["db"] = internal_database_name
+func DatamanCreateFilterHtml(udn_schema map[string]interface{}, internal_database_name string, field_label string, filter_array []interface{}, input_map map[string]interface{}) string {
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
 	UdnLogLevel(nil, log_trace, "DatamanCreateFilterHtml: field_label: %s: %s\n", field_label, JsonDump(filter_array))
 
@@ -2620,7 +2595,7 @@ func DatamanCreateFilterHtml(internal_database_name string, field_label string,
 				UdnLogLevel(nil, log_trace, "DatamanCreateFilterHtml: %s: item: %s %s %s\n", item_field_label, field, operator, JsonDump(value))
 
 				web_widget_name := compare_map[operator].(map[string]interface{})["web_widget_name"].(string)
-				web_widget_html := GetWebWidgetHtml(web_widget_name)
+				web_widget_html := GetWebWidgetHtml(udn_schema, web_widget_name)
 
 				// Use the input_map
 				data := MapCopy(input_map)
@@ -2643,9 +2618,9 @@ func DatamanCreateFilterHtml(internal_database_name string, field_label string,
 		}
 	}
 
-	core_table := GetWebWidgetHtml("core_table_simple")
-	core_icon_list := GetWebWidgetHtml("core_icon_list")
-	core_button := GetWebWidgetHtml("core_button")
+	core_table := GetWebWidgetHtml(udn_schema, "core_table_simple")
+	core_icon_list := GetWebWidgetHtml(udn_schema, "core_icon_list")
+	core_button := GetWebWidgetHtml(udn_schema, "core_button")
 
 
 	for _, html_field_item := range html_field_array {
@@ -2683,8 +2658,8 @@ func DatamanCreateFilterHtml(internal_database_name string, field_label string,
 	return html
 }
 
-func GetWebWidgetHtml(name string) string {
-	options := make(map[string]interface{})
+func GetWebWidgetHtml(udn_schema map[string]interface{}, name string) string {
+	options := _UdnDatamanOptions(udn_schema, make(map[string]interface{}))
 
 	filter := map[string]interface{}{
 		"name": []interface{}{"=", name},
@@ -2706,7 +2681,7 @@ func UDN_Custom_Dataman_Add_Rule(db *sql.DB, udn_schema map[string]interface{},
 	input_map := GetResult(args[0], type_map).(map[string]interface{})
 
 	// Do all the work here, so I can call it from Go as well as UDN.  Need to cover the complex ground outside of UDN for now.
-	html := DatamanAddRule(input_map)
+	html := DatamanAddRule(udn_schema, input_map)
 
 	result := UdnResult{}
 	result.Result = html
@@ -2714,7 +2689,7 @@ func UDN_Custom_Dataman_Add_Rule(db *sql.DB, udn_schema map[string]interface{},
 	return result
 }
 
-func DatamanAddRule(input_map map[string]interface{}) string {
+func DatamanAddRule(udn_schema map[string]interface{}, input_map map[string]interface{}) string {
 	UdnLogLevel(nil, log_trace, "DatamanAddRule: %s\n", JsonDump(input_map))
 
 	data_map := input_map["data"].(map[string]interface{})
@@ -2722,8 +2697,7 @@ func DatamanAddRule(input_map map[string]interface{}) string {
 	database, collection, record_pkey, field := ParseFieldLabel(data_map["field_label"].(string))
 
 
-	options := make(map[string]interface{})
-	options["db"] = database
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": database})
 
 	record_id, _ := strconv.ParseInt(record_pkey, 10, 64)
 	record := DatamanGet(collection, int(record_id), options)
@@ -2767,7 +2741,7 @@ func UDN_Custom_API_Business_Update(db *sql.DB, udn_schema map[string]interface{
 	api_server_connection_id := GetResult(args[2], type_int).(int64)
 
 	// Do all the work here, so I can call it from Go as well as UDN.  Need to cover the complex ground outside of UDN for now.
-	API_BusinessUpdate(internal_database_name, api_server_connection_table, api_server_connection_id)
+	API_BusinessUpdate(udn_schema, internal_database_name, api_server_connection_table, api_server_connection_id)
 
 	result := UdnResult{}
 	result.Result = nil
@@ -2775,9 +2749,8 @@ func UDN_Custom_API_Business_Update(db *sql.DB, udn_schema map[string]interface{
 	return result
 }
 
-func API_BusinessUpdate(internal_database_name string, api_server_connection_table string, api_server_connection_id int64) {
-	options := make(map[string]interface{})
-	options["db"] = internal_database_name
+func API_BusinessUpdate(udn_schema map[string]interface{}, internal_database_name string, api_server_connection_table string, api_server_connection_id int64) {
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
 	//TODO(g): Get this from the user login info...
 	business_id := 1
@@ -2905,7 +2878,7 @@ func UDN_Custom_Login(db *sql.DB, udn_schema map[string]interface{}, udn_start *
 	username := GetResult(args[2], type_string).(string)
 	password := GetResult(args[3], type_string).(string)
 
-	options := map[string]interface{}{"db": internal_database_name}
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
 	// Get the user (if it exists)
 	filter := map[string]interface{}{}
@@ -2999,7 +2972,7 @@ func UDN_Custom_Auth(db *sql.DB, udn_schema map[string]interface{}, udn_start *U
 
 	result_map["session"] = session
 
-	options := map[string]interface{}{"db": internal_database_name}
+	options := _UdnDatamanOptions(udn_schema, map[string]interface{}{"db": internal_database_name})
 
 	// Get the user (if it exists)
 	filter := map[string]interface{}{}
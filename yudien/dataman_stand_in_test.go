@@ -0,0 +1,78 @@
+package yudien
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/ghowland/yudien/yudiendata"
+)
+
+// A udn_schema of a new engine, whose Dataman functions use store instead of databases
+func testStandInUdnSchema(store DatamanStore) map[string]interface{} {
+	engine := NewEngine()
+	engine.Datasources.StandIn = store
+
+	udn_schema := engine.NewUdnSchema()
+	udn_schema["allow_logging"] = false
+
+	return udn_schema
+}
+
+func TestDatamanFileStore(t *testing.T) {
+	fixture_dir, err := ioutil.TempDir("", "udn_dataman")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fixture_dir)
+
+	fixture_path := filepath.Join(fixture_dir, "fixture.json")
+	ioutil.WriteFile(fixture_path, []byte(`{"user": [{"_id": 1, "name": "a", "age": 30}, {"_id": 2, "name": "b", "age": 10}, {"_id": 3, "name": "c", "age": 20, "_is_deleted": true}]}`), 0644)
+
+	store, err := NewDatamanFileStore(fixture_path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	udn_schema := testStandInUdnSchema(store)
+	udn_data := map[string]interface{}{
+		"filter": []interface{}{map[string]interface{}{"age": []interface{}{">", 15}}, "OR", map[string]interface{}{"name": "b"}},
+	}
+
+	tests := []struct {
+		udn    string
+		result interface{}
+	}{
+		{"__data_get.user.2.__get_index.name", "b"},
+		{"__data_filter.user.{name=a}.__get_index.0.age", int64(30)},
+		// Tombstoned records are left out
+		{"__data_filter_full.user.(__get.filter).{sort=[name]}.__get_index.1.name", "b"},
+		{"__data_filter_full.user.(__get.filter).{sort=[name]}.__get_index.2", nil},
+		{"__data_set.user.{name=d,age=5}.__get_index._id", int64(4)},
+		{"__data_set.user.{_id=4,age=6}.__get_index.name", "d"},
+		{"__data_delete.user.1.__get_index.name", "a"},
+	}
+
+	for _, test := range tests {
+		result := ProcessSingleUDNTarget(nil, udn_schema, test.udn, nil, udn_data)
+
+		if result != test.result {
+			t.Errorf("%s: Expected %v, got %v  Error: %v", test.udn, test.result, result, GetUdnError(udn_schema))
+		}
+	}
+
+	if records := ProcessSingleUDNTarget(nil, udn_schema, "__data_filter.user.{}", nil, udn_data); len(records.([]map[string]interface{})) != 2 {
+		t.Errorf("Unexpected records after the changes: %v", records)
+	}
+
+	// Changes are only in memory until they are saved
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	saved_store, err := NewDatamanFileStore(fixture_path)
+	if err != nil || len(saved_store.Collections["user"]) != 3 || saved_store.Collections["user"][2]["age"] != int64(6) {
+		t.Errorf("Saved fixture was not reloaded: %v  %v", saved_store, err)
+	}
+}
@@ -0,0 +1,274 @@
+package yudien
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	. "github.com/ghowland/yudien/yudiencore"
+	. "github.com/ghowland/yudien/yudienutil"
+	"io"
+	"sort"
+	"strings"
+)
+
+// A debugger is attached to an execution with SetUdnDebugger, and ExecuteUdnPart calls it before and after every function.  The hooks run on the executing goroutine, so a debugger can pause the execution by not returning (ex: waiting for a command from a terminal), and can change udn_data (and the event's Args, before the function runs) while paused.
+//NOTE(g): While a debugger is attached, concurrent blocks and __parallel_iterate run one at a time, like they do for debug logging, so the hooks are always called in execution order
+type UdnDebugger interface {
+	BeforeUdnPart(event *UdnDebugEvent)
+	AfterUdnPart(event *UdnDebugEvent)
+}
+
+// A function being executed.  The same event is passed to BeforeUdnPart and AfterUdnPart, with Result set for AfterUdnPart.
+type UdnDebugEvent struct {
+	Schema  map[string]interface{}
+	Part    *UdnPart
+	Input   interface{}
+	Args    []interface{}
+	UdnData map[string]interface{}
+
+	// How many functions this one is nested in: arguments, block bodies and called functions (__call, __function) are all one deeper than the function executing them
+	Depth int
+
+	Result UdnResult
+}
+
+func SetUdnDebugger(udn_schema map[string]interface{}, debugger UdnDebugger) {
+	if debugger == nil {
+		delete(udn_schema, "debugger")
+	} else {
+		udn_schema["debugger"] = debugger
+	}
+}
+
+// Returns the debugger attached to this execution, or nil
+func GetUdnDebugger(udn_schema map[string]interface{}) UdnDebugger {
+	if udn_schema == nil {
+		return nil
+	}
+
+	debugger, _ := udn_schema["debugger"].(UdnDebugger)
+	return debugger
+}
+
+// Execute a function between the debugger's hooks.  Called by ExecuteUdnPart instead of calling the function directly, when there is a debugger.
+func _ExecuteUdnFunctionDebug(debugger UdnDebugger, db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart, args []interface{}, input interface{}, udn_data map[string]interface{}) UdnResult {
+	debug_depth, _ := udn_schema["debug_depth"].(int)
+
+	event := &UdnDebugEvent{Schema: udn_schema, Part: udn_start, Input: input, Args: args, UdnData: udn_data, Depth: debug_depth}
+
+	debugger.BeforeUdnPart(event)
+
+	// Everything our function executes is one deeper.  Put back even if it panics.
+	udn_schema["debug_depth"] = debug_depth + 1
+	defer func() {
+		udn_schema["debug_depth"] = debug_depth
+	}()
+
+	event.Result = GetUdnEngine(udn_schema).Functions[udn_start.Value](db, udn_schema, udn_start, event.Args, input, udn_data)
+
+	udn_schema["debug_depth"] = debug_depth
+
+	debugger.AfterUdnPart(event)
+
+	return event.Result
+}
+
+const (
+	debug_mode_continue  = iota // Only stop at breakpoints
+	debug_mode_step_in   = iota // Stop at the next function
+	debug_mode_step_over = iota // Stop at the next function that isnt nested in this one
+	debug_mode_step_out  = iota // Stop at the next function after this one's caller
+)
+
+const udn_terminal_debugger_help = `Commands:
+  s, step            Step into the next function, including arguments, block bodies and called functions
+  n, next            Step over this function, to the next one at this depth or above
+  o, out             Step out, to the next function after the one this is nested in
+  c, continue        Continue until a breakpoint
+  b <function|id>    Add a breakpoint on a function name (ex: __set) or a UdnPart Id
+  d <function|id>    Delete a breakpoint
+  l, list            List the breakpoints
+  w, where           Print the functions we are nested in
+  i, input           Print the input
+  a, args            Print the arguments
+  p [path]           Print udn_data, or a dotted path in it (ex: p temp.user.name)
+  set <path> <json>  Set a dotted path in udn_data to a JSON value (ex: set temp.count 5)
+  q, quit            Stop debugging, and let the execution finish
+  h, help            Print this help
+`
+
+// A debugger that pauses on a terminal.  When it stops before a function, it prints where it is and reads commands until one resumes the execution.
+type UdnTerminalDebugger struct {
+	Input  *bufio.Reader
+	Output io.Writer
+
+	// Function names and UdnPart Ids to stop at
+	Breakpoints map[string]bool
+
+	mode       int
+	mode_depth int
+
+	// The functions we are executing (outermost first), and if we stopped at them, so we print their result when they finish
+	stack  []*UdnDebugEvent
+	paused []bool
+}
+
+// Returns a terminal debugger that stops at the first function it executes
+func NewUdnTerminalDebugger(input *bufio.Reader, output io.Writer) *UdnTerminalDebugger {
+	return &UdnTerminalDebugger{Input: input, Output: output, Breakpoints: make(map[string]bool), mode: debug_mode_step_in}
+}
+
+// Stop at the next function executed
+func (debugger *UdnTerminalDebugger) Step() {
+	debugger.mode = debug_mode_step_in
+}
+
+// Only stop at breakpoints
+func (debugger *UdnTerminalDebugger) Continue() {
+	debugger.mode = debug_mode_continue
+}
+
+func (debugger *UdnTerminalDebugger) BeforeUdnPart(event *UdnDebugEvent) {
+	// A function that panicked never called AfterUdnPart, so our stack may be deeper than this event
+	if len(debugger.stack) > event.Depth {
+		debugger.stack = debugger.stack[:event.Depth]
+		debugger.paused = debugger.paused[:event.Depth]
+	}
+
+	pause := debugger.Breakpoints[event.Part.Value] || debugger.Breakpoints[event.Part.Id]
+
+	switch debugger.mode {
+	case debug_mode_step_in:
+		pause = true
+	case debug_mode_step_over:
+		pause = pause || event.Depth <= debugger.mode_depth
+	case debug_mode_step_out:
+		pause = pause || event.Depth < debugger.mode_depth
+	}
+
+	debugger.stack = append(debugger.stack, event)
+	debugger.paused = append(debugger.paused, pause)
+
+	if pause {
+		debugger._Pause(event)
+	}
+}
+
+func (debugger *UdnTerminalDebugger) AfterUdnPart(event *UdnDebugEvent) {
+	if len(debugger.stack) <= event.Depth {
+		return
+	}
+
+	if debugger.paused[event.Depth] {
+		if event.Result.Error != "" {
+			fmt.Fprintf(debugger.Output, "<- %s [%s]  Error: %s\n", event.Part.Value, event.Part.Id, event.Result.Error)
+		} else {
+			fmt.Fprintf(debugger.Output, "<- %s [%s]  Result: %s\n", event.Part.Value, event.Part.Id, SnippetData(event.Result.Result, 300))
+		}
+	}
+
+	debugger.stack = debugger.stack[:event.Depth]
+	debugger.paused = debugger.paused[:event.Depth]
+}
+
+// Read commands until one resumes the execution
+func (debugger *UdnTerminalDebugger) _Pause(event *UdnDebugEvent) {
+	fmt.Fprintf(debugger.Output, "-> %s%s [%s]  Args: %s\n", strings.Repeat("  ", event.Depth), event.Part.Value, event.Part.Id, SnippetData(event.Args, 200))
+
+	for {
+		fmt.Fprint(debugger.Output, "debug> ")
+
+		line, err := debugger.Input.ReadString('\n')
+		if err != nil && line == "" {
+			// No more input, so nobody is debugging anymore
+			debugger._Detach()
+			return
+		}
+
+		command, command_arg := strings.TrimSpace(line), ""
+		if index := strings.Index(command, " "); index != -1 {
+			command, command_arg = command[:index], strings.TrimSpace(command[index+1:])
+		}
+
+		switch command {
+		case "s", "step":
+			debugger.mode = debug_mode_step_in
+			return
+		case "n", "next", "":
+			debugger.mode, debugger.mode_depth = debug_mode_step_over, event.Depth
+			return
+		case "o", "out":
+			debugger.mode, debugger.mode_depth = debug_mode_step_out, event.Depth
+			return
+		case "c", "continue":
+			debugger.mode = debug_mode_continue
+			return
+		case "q", "quit":
+			debugger._Detach()
+			return
+
+		case "b", "break":
+			debugger.Breakpoints[command_arg] = true
+		case "d", "delete":
+			delete(debugger.Breakpoints, command_arg)
+		case "l", "list":
+			breakpoints := make([]string, 0)
+			for breakpoint := range debugger.Breakpoints {
+				breakpoints = append(breakpoints, breakpoint)
+			}
+			sort.Strings(breakpoints)
+			fmt.Fprintf(debugger.Output, "Breakpoints: %s\n", strings.Join(breakpoints, "  "))
+
+		case "w", "where":
+			for depth, stack_event := range debugger.stack {
+				fmt.Fprintf(debugger.Output, "%s%s [%s]\n", strings.Repeat("  ", depth), stack_event.Part.Value, stack_event.Part.Id)
+			}
+		case "i", "input":
+			debugger._PrintJson(event.Input)
+		case "a", "args":
+			debugger._PrintJson(event.Args)
+		case "p", "print":
+			if command_arg == "" {
+				debugger._PrintJson(event.UdnData)
+			} else {
+				debugger._PrintJson(MapGet(SimpleDottedStringToArray(command_arg, "."), event.UdnData))
+			}
+		case "set":
+			parts := strings.SplitN(command_arg, " ", 2)
+			if len(parts) != 2 {
+				fmt.Fprintf(debugger.Output, "Usage: set <path> <json>\n")
+				continue
+			}
+
+			var value interface{}
+			if err := json.Unmarshal([]byte(parts[1]), &value); err != nil {
+				fmt.Fprintf(debugger.Output, "Set: %s\n", err)
+				continue
+			}
+
+			MapSet(SimpleDottedStringToArray(parts[0], "."), JsonIntegers(value), event.UdnData)
+
+		case "h", "help":
+			fmt.Fprint(debugger.Output, udn_terminal_debugger_help)
+		default:
+			fmt.Fprintf(debugger.Output, "Unknown command: %s  (h for help)\n", command)
+		}
+	}
+}
+
+// Stop pausing, the execution runs to the end
+func (debugger *UdnTerminalDebugger) _Detach() {
+	debugger.mode = debug_mode_continue
+	debugger.Breakpoints = make(map[string]bool)
+}
+
+func (debugger *UdnTerminalDebugger) _PrintJson(value interface{}) {
+	output, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		fmt.Fprintf(debugger.Output, "%v\n", value)
+		return
+	}
+
+	fmt.Fprintf(debugger.Output, "%s\n", output)
+}
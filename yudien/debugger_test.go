@@ -0,0 +1,90 @@
+package yudien
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	. "github.com/ghowland/yudien/yudienutil"
+)
+
+type testUdnDebugger struct {
+	events []string
+}
+
+func (debugger *testUdnDebugger) BeforeUdnPart(event *UdnDebugEvent) {
+	debugger.events = append(debugger.events, fmt.Sprintf("%d>%s", event.Depth, event.Part.Value))
+}
+
+func (debugger *testUdnDebugger) AfterUdnPart(event *UdnDebugEvent) {
+	debugger.events = append(debugger.events, fmt.Sprintf("%d<%s=%v", event.Depth, event.Part.Value, event.Result.Result))
+}
+
+func TestUdnDebuggerHooks(t *testing.T) {
+	udn_schema := testUdnSchema()
+	udn_data := map[string]interface{}{}
+
+	debugger := &testUdnDebugger{}
+	SetUdnDebugger(udn_schema, debugger)
+
+	ProcessSingleUDNTarget(nil, udn_schema, "__input.[1,2].__iterate.__input.x.__end_iterate.__set.temp.done", nil, udn_data)
+
+	expected := "0>__input 0<__input=[1 2] 0>__iterate 1>__input 1<__input=x 1>__input 1<__input=x 0<__iterate=[x x] 0>__set 0<__set=[x x]"
+	if events := strings.Join(debugger.events, " "); events != expected {
+		t.Errorf("Unexpected debugger events:\n%s\nExpected:\n%s", events, expected)
+	}
+}
+
+func TestUdnTerminalDebugger(t *testing.T) {
+	udn := "__input.[1,2].__iterate.__input.x.__end_iterate.__set.temp.done"
+
+	tests := []struct {
+		commands string
+		paused   []string
+	}{
+		// Step over the __iterate's body
+		{"n\nn\nc\n", []string{"__input", "__iterate", "__set"}},
+		// Step into the body, and back out
+		{"n\ns\no\nc\n", []string{"__input", "__iterate", "  __input", "__set"}},
+		// Breakpoints by function name, and quitting when we run out of input
+		{"b __set\nc\n", []string{"__input", "__set"}},
+	}
+
+	for _, test := range tests {
+		udn_schema := testUdnSchema()
+		udn_data := map[string]interface{}{}
+
+		output := &bytes.Buffer{}
+		SetUdnDebugger(udn_schema, NewUdnTerminalDebugger(bufio.NewReader(strings.NewReader(test.commands)), output))
+
+		result := ProcessSingleUDNTarget(nil, udn_schema, udn, nil, udn_data)
+		if MapGet([]interface{}{"temp", "done"}, udn_data) == nil {
+			t.Errorf("%q: Execution did not finish: %v  %s", test.commands, result, output)
+		}
+
+		paused := make([]string, 0)
+		for _, line := range strings.Split(output.String(), "\n") {
+			line = strings.TrimPrefix(line, "debug> ")
+			if strings.HasPrefix(line, "-> ") {
+				paused = append(paused, strings.SplitN(line[3:], " [", 2)[0])
+			}
+		}
+
+		if strings.Join(paused, ",") != strings.Join(test.paused, ",") {
+			t.Errorf("%q: Paused at %v, expected %v\n%s", test.commands, paused, test.paused, output)
+		}
+	}
+
+	// udn_data can be changed while paused
+	udn_schema := testUdnSchema()
+	udn_data := map[string]interface{}{}
+
+	output := &bytes.Buffer{}
+	SetUdnDebugger(udn_schema, NewUdnTerminalDebugger(bufio.NewReader(strings.NewReader("b __get\nc\nset temp.value {\"a\": 5}\nc\n")), output))
+
+	if result := ProcessSingleUDNTarget(nil, udn_schema, "__input.1.__set.temp.value.__get.temp.value.a", nil, udn_data); result != int64(5) {
+		t.Errorf("udn_data was not changed while paused: %v\n%s", result, output)
+	}
+}
@@ -0,0 +1,109 @@
+package yudien
+
+import (
+	"database/sql"
+	. "github.com/ghowland/yudien/yudiencore"
+	. "github.com/ghowland/yudien/yudienutil"
+)
+
+// A function defined in UDN with __define, and called with __call.  Definitions last for the request, they are kept in udn_schema["defined_functions"].
+type UdnDefinedFunction struct {
+	Name   string
+	Params []string
+
+	// The __define part, the function body is the parts between it and its BlockEnd (__end_define)
+	Block *UdnPart
+}
+
+// Returns the functions defined in this request, creating the map if this is the first one
+func _GetUdnDefinedFunctions(udn_schema map[string]interface{}) map[string]*UdnDefinedFunction {
+	defined_functions, ok := udn_schema["defined_functions"].(map[string]*UdnDefinedFunction)
+	if !ok {
+		defined_functions = make(map[string]*UdnDefinedFunction)
+		udn_schema["defined_functions"] = defined_functions
+	}
+
+	return defined_functions
+}
+
+func UDN_Define(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart, args []interface{}, input interface{}, udn_data map[string]interface{}) UdnResult {
+	// Defines a function from the block until __end_define, it is not executed until it is called.  arg_0 is the name, the rest of the args are the parameter names, which __call sets as temp data.
+	if udn_start.BlockEnd == nil {
+		return UdnResultError("__define has no matching __end_define")
+	}
+	if udn_schema == nil {
+		return UdnResultError("Define: No udn_schema to store the function in")
+	}
+	if len(args) == 0 {
+		return UdnResultError("__define needs a function name")
+	}
+
+	defined_function := &UdnDefinedFunction{Name: GetResult(args[0], type_string).(string), Block: udn_start}
+	for _, arg := range args[1:] {
+		defined_function.Params = append(defined_function.Params, GetResult(arg, type_string).(string))
+	}
+
+	UdnLogLevel(udn_schema, log_trace, "Define: %s  Params: %v\n", defined_function.Name, defined_function.Params)
+
+	_GetUdnDefinedFunctions(udn_schema)[defined_function.Name] = defined_function
+
+	// Skip the function body, the input passes through
+	result := UdnResult{}
+	result.Result = input
+	result.NextUdnPart = udn_start.BlockEnd
+
+	return result
+}
+
+func UDN_Call(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart, args []interface{}, input interface{}, udn_data map[string]interface{}) UdnResult {
+	// Calls a function defined with __define.  The function gets its own stack frame, so its parameters and anything it sets with __set_temp are local to this call, and are removed when it returns.  Calls can be nested or recursive.
+	if udn_schema == nil {
+		return UdnResultError("Call: No udn_schema to find the function in")
+	}
+	if len(args) == 0 {
+		return UdnResultError("__call needs a function name")
+	}
+
+	function_name := GetResult(args[0], type_string).(string)
+
+	defined_function, ok := _GetUdnDefinedFunctions(udn_schema)[function_name]
+	if !ok {
+		return UdnResultError("Call: Unknown function: %s", function_name)
+	}
+
+	call_args := args[1:]
+	if len(call_args) > len(defined_function.Params) {
+		return UdnResultError("Call: %s takes %d arguments, found %d", function_name, len(defined_function.Params), len(call_args))
+	}
+
+	UdnLogLevel(udn_schema, log_trace, "Call: %s  Args: %s  Input: %s\n", function_name, SnippetData(call_args, 80), SnippetData(input, 60))
+
+	if message := PushUdnCallDepth(udn_schema, udn_data); message != "" {
+		return UdnResultError(message)
+	}
+
+	function_stack := PushUdnFunctionStack(udn_data)
+	function_stack["function"] = function_name
+
+	// The function cant __break or __continue our loops
+	caller_loop_depth := _SwapUdnLoopDepth(udn_schema, 0)
+
+	// Parameters are set into the new frame's temp data, missing args are nil
+	temp_udn_data := _GetUdnTempData(udn_data)
+	for index, param := range defined_function.Params {
+		if index < len(call_args) {
+			temp_udn_data[param] = call_args[index]
+		} else {
+			temp_udn_data[param] = nil
+		}
+	}
+
+	result := UdnResult{}
+	result.Result = _ExecuteUdnBlock(db, udn_schema, defined_function.Block, defined_function.Block.BlockEnd, input, udn_data)
+
+	_SwapUdnLoopDepth(udn_schema, caller_loop_depth)
+	PopUdnFunctionStack(udn_data)
+	PopUdnCallDepth(udn_data)
+
+	return result
+}
@@ -0,0 +1,60 @@
+package yudien
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUdnDefineCall(t *testing.T) {
+	udn_schema := testUdnSchema()
+	udn_data := map[string]interface{}{}
+
+	udn_list := []string{
+		"__define.double.x.__math.multiply.(__get_temp.x).2.__end_define",
+		"__define.factorial.n.__if.(__compare_equal.(__get_temp.n).0).__input.1.__else.__math.multiply.(__get_temp.n).(__call.factorial.(__math.subtract.(__get_temp.n).1)).__end_if.__end_define",
+		"__call.factorial.(__call.double.2).__set.result.factorial",
+	}
+
+	result := ProcessUDN(nil, udn_schema, udn_list, udn_data)
+
+	if result != int64(24) {
+		t.Fatalf("Unexpected result: %v (%T)  Error: %v", result, result, GetUdnError(udn_schema))
+	}
+
+	// Every call removes its frame and temp data when it returns
+	if udn_data["__function_stack"] != nil || udn_data["__temp"] != nil {
+		t.Errorf("Call frames were not removed: %v  %v", udn_data["__function_stack"], udn_data["__temp"])
+	}
+}
+
+func TestUdnCallScope(t *testing.T) {
+	udn_schema := testUdnSchema()
+	udn_data := map[string]interface{}{}
+
+	// The inner call sets its own "x", which does not change the outer call's "x"
+	udn_list := []string{
+		"__define.inner.x.__get_temp.x.__end_define",
+		"__define.outer.x.__call.inner.other.__set_temp.inner_result.__get_temp.x.__end_define",
+		"__call.outer.mine",
+	}
+
+	if result := ProcessUDN(nil, udn_schema, udn_list, udn_data); result != "mine" {
+		t.Fatalf("Unexpected result: %v", result)
+	}
+
+	if result := ProcessUDN(nil, udn_schema, []string{"__call.inner.1.2"}, udn_data); result != nil || GetUdnError(udn_schema) == nil {
+		t.Errorf("Too many args did not fail: %v", result)
+	}
+
+	if result := ProcessUDN(nil, udn_schema, []string{"__call.missing"}, udn_data); result != nil || GetUdnError(udn_schema) == nil {
+		t.Errorf("Unknown function did not fail: %v", result)
+	}
+
+	// No function name is an error, not a panic
+	for _, udn_value := range []string{"__define.__end_define", "__call"} {
+		ProcessUDN(nil, udn_schema, []string{udn_value}, udn_data)
+		if udn_error := GetUdnError(udn_schema); udn_error == nil || !strings.Contains(udn_error["message"].(string), "needs a function name") {
+			t.Errorf("%s: Unexpected error: %v", udn_value, udn_error)
+		}
+	}
+}
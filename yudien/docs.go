@@ -0,0 +1,178 @@
+package yudien
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Start of the Literals section of the function docs, which are not functions so are not in the signatures
+var udn_docs_literals = []string{
+	"Unquoted arguments, list items and map values are typed when they are parsed, so functions receive the value and not a string that needs converting:",
+	"",
+	"  - Integers: `1`, `-20` (int64).  Numbers with leading zeros, like `007`, stay strings.",
+	"  - Floats: `-2.5`, `3e2` (float64).  The \".\" separates arguments, so floats with a fraction are only recognised inside lists and maps: `[1.5,2.5]`, `{cost:1.5}`",
+	"  - Booleans: `true`, `false`",
+	"  - Null: `null` (nil)",
+	"",
+	"The args of functions that take the keys of a location in data (`__get`, `__set`, `__get_temp`, `__set_temp`, `__get_index`, `__input_get`, `__let`, `__var`) arent typed, any literal is a key: `__get.x.null` gets the key \"null\".",
+	"",
+	"Quote a value to keep it as a string: `'1'`, `'true'`.  Quoted strings take the escapes `\\'`, `\\\\`, `\\n`, `\\t` and `\\uXXXX`.  Any other backslash is kept as-is, so `'\\d+'` is still `\\d+`.",
+	"",
+	"```",
+	"__input.[1,'1',2.5,true,null]",
+	"```",
+	"",
+	"**Result:**",
+	"",
+	"```",
+	"[1, \"1\", 2.5, true, null]",
+	"```",
+}
+
+// Generate docs/yudien_functions.md from the registered function signatures.  Functions are documented in their group's section, in the order they were registered.  Functions without a known group go in an "Other" section at the end.
+func FormatUdnFunctionDocs() string {
+	groups := append([]UdnFunctionGroup{}, UdnFunctionGroups...)
+	groups = append(groups, UdnFunctionGroup{"other", "Other"})
+
+	group_functions := map[string][]*UdnFunctionSignature{}
+	for _, name := range UdnFunctionNames {
+		signature := UdnFunctionSignatures[name]
+
+		group_name := "other"
+		for _, group := range UdnFunctionGroups {
+			if group.Name == signature.Group {
+				group_name = group.Name
+			}
+		}
+
+		group_functions[group_name] = append(group_functions[group_name], signature)
+	}
+
+	lines := []string{"# Yudien (UDN) Functions", "", "0. [Literals](#literals)"}
+
+	group_count := 0
+	for _, group := range groups {
+		if len(group_functions[group.Name]) == 0 {
+			continue
+		}
+		group_count++
+
+		lines = append(lines, fmt.Sprintf("%d. [%s](#%s)", group_count, group.Title, group.Name))
+		for index, signature := range group_functions[group.Name] {
+			lines = append(lines, fmt.Sprintf("    %d. [%s - %s](#%s)", index+1, signature.Name, signature.Title, signature.Name))
+		}
+	}
+
+	lines = append(lines, "", "", "## Literals <a name=\"literals\"></a>", "")
+	lines = append(lines, udn_docs_literals...)
+
+	for _, group := range groups {
+		if len(group_functions[group.Name]) == 0 {
+			continue
+		}
+
+		lines = append(lines, "", "", fmt.Sprintf("## %s <a name=\"%s\"></a>", group.Title, group.Name))
+
+		for _, signature := range group_functions[group.Name] {
+			lines = append(lines, "", "")
+			lines = append(lines, _FormatUdnFunctionDoc(signature)...)
+		}
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func _FormatUdnFunctionDoc(signature *UdnFunctionSignature) []string {
+	lines := []string{fmt.Sprintf("### %s ::: %s <a name=\"%s\"></a>", signature.Name, signature.Title, signature.Name), ""}
+
+	if signature.Description != "" {
+		lines = append(lines, signature.Description, "")
+	}
+
+	if signature.DeprecatedBy != "" {
+		lines = append(lines, fmt.Sprintf("**Deprecated:** Use [%s](#%s)", signature.DeprecatedBy, signature.DeprecatedBy), "")
+	}
+
+	lines = append(lines, "**Go:** "+signature.GoName(), "")
+	lines = append(lines, "**Input:** "+_UdnDocsValue(signature.Input), "")
+
+	if len(signature.Args) == 0 {
+		lines = append(lines, "**Args:** None", "")
+	} else {
+		lines = append(lines, "**Args:**", "")
+		for index, arg := range signature.Args {
+			lines = append(lines, "  "+_FormatUdnArgDoc(index, arg))
+		}
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, "**Output:** "+_UdnDocsValue(signature.Output), "")
+
+	for index, example := range signature.Examples {
+		if example.Description != "" {
+			lines = append(lines, example.Description, "")
+		} else if index == 0 {
+			lines = append(lines, "**Example:**", "")
+		} else {
+			lines = append(lines, fmt.Sprintf("**Example %d:**", index+1), "")
+		}
+		lines = append(lines, "```", example.Udn, "```", "")
+
+		if example.Result != "" {
+			lines = append(lines, "**Result:**", "", "```", example.Result, "```", "")
+		}
+	}
+
+	if signature.BlockEnd != "" {
+		lines = append(lines, fmt.Sprintf("**End Block:** [%s](#%s)", signature.BlockEnd, signature.BlockEnd), "")
+	}
+	if signature.BlockBegin != "" {
+		lines = append(lines, fmt.Sprintf("**Begin Block:** [%s](#%s)", signature.BlockBegin, signature.BlockBegin), "")
+	}
+
+	lines = append(lines, "**Side Effect:** "+_UdnDocsValue(signature.SideEffect))
+	if signature.Writes {
+		lines = append(lines, "", "**Writes:** Changes data outside the execution, so it is not allowed by a read-only UdnPolicy")
+	}
+
+	if len(signature.Related) > 0 {
+		related := make([]string, 0, len(signature.Related))
+		for _, name := range signature.Related {
+			related = append(related, fmt.Sprintf("[%s](#%s)", name, name))
+		}
+		lines = append(lines, "", "**Related Functions:** "+strings.Join(related, ", "))
+	}
+
+	return lines
+}
+
+// Ex: "1. location (string, optional, variadic) :: Any number of args can be provided"
+func _FormatUdnArgDoc(index int, arg UdnArgSignature) string {
+	details := make([]string, 0)
+	if arg.Type != "" {
+		details = append(details, arg.Type)
+	}
+	if arg.Optional {
+		details = append(details, "optional")
+	}
+	if arg.Variadic {
+		details = append(details, "variadic")
+	}
+
+	doc := fmt.Sprintf("%d. %s", index, arg.Name)
+	if len(details) > 0 {
+		doc += " (" + strings.Join(details, ", ") + ")"
+	}
+	if arg.Description != "" {
+		doc += " :: " + arg.Description
+	}
+
+	return doc
+}
+
+func _UdnDocsValue(value string) string {
+	if value == "" {
+		return "None"
+	}
+	return value
+}
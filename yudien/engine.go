@@ -0,0 +1,192 @@
+package yudien
+
+import (
+	"fmt"
+	. "github.com/ghowland/yudien/yudiencore"
+	. "github.com/ghowland/yudien/yudiendata"
+	. "github.com/ghowland/yudien/yudienutil"
+	"os"
+)
+
+// An Engine is a Yudien configuration: the UDN functions it can execute, its logging, its authentication and its databases.  One process can have several engines (ex: serving two configurations, or tests running in parallel), and each execution uses the engine its udn_schema was made by (Engine.NewUdnSchema).
+// The package-level functions (Configure, RegisterUdnFunction, NewUdnSchema, ProcessUDN, ...) call the methods of DefaultEngine, or of the engine of their udn_schema.  The package globals (UdnFunctions, Debug_Udn_Log_Level, UdnLogger, Ldap, DevelopmentUsers, DefaultDatabase, DatasourceInstance, ...) are copies of DefaultEngine's settings, for code that still reads them, executions only use the engine's.
+type Engine struct {
+	Functions          map[string]UdnFunc
+	FunctionSignatures map[string]*UdnFunctionSignature
+	FunctionNames      []string // In the order they were registered, so the docs keep their order
+	FunctionPacks      map[string]FunctionPack
+
+	function_packs      map[string]string // Function name to the name of the pack that registered it.  Core functions arent in here.
+	function_namespaces map[string]bool   // Namespaces of the pack functions ("__ops" for "__ops.duty_shift_summary"), which the parser joins with the name after them
+
+	// Parsed UDN of this engine.  Parsing depends on the engine's function namespaces, so engines dont share parsed UDN.
+	ParseCache *UdnParseCache
+
+	LogConfig *LoggingConfig
+	LogLevel  int
+	Logger    Logger
+
+	Ldap             *LdapConfig
+	DevelopmentUsers map[string]StaticUser
+
+	DefaultDatabase *DatabaseConfig
+	Datasources     *Datasources
+
+	// Limits of every top-level execution, unless StartUdnExecution gives it others
+	Limits UdnExecutionLimits
+
+	// Every execution without its own profiler (SetUdnProfiler) is profiled into this one, if it is set
+	Profiler *UdnProfiler
+}
+
+// The engine of udn_schemas that werent made by an engine.  Set by InitUdn.
+var DefaultEngine *Engine
+
+// Returns a new engine, with the core UDN functions and the "custom" function pack registered, that isnt configured yet
+func NewEngine() *Engine {
+	engine := &Engine{
+		Functions:           map[string]UdnFunc{},
+		FunctionSignatures:  map[string]*UdnFunctionSignature{},
+		FunctionNames:       []string{},
+		FunctionPacks:       map[string]FunctionPack{},
+		function_packs:      map[string]string{},
+		function_namespaces: map[string]bool{},
+		ParseCache:          NewUdnParseCache(udn_parse_cache_default_size),
+		LogConfig:           &LoggingConfig{},
+		Logger:              &TextLogger{Output: os.Stdout},
+		Ldap:                &LdapConfig{},
+		DevelopmentUsers:    map[string]StaticUser{},
+		Datasources:         NewDatasources(),
+		Limits:              DefaultUdnExecutionLimits,
+	}
+
+	for _, signature := range _UdnCoreFunctionSignatures() {
+		engine.RegisterUdnFunction(signature)
+	}
+
+	// Cant fail, the custom functions dont collide with the core functions
+	engine.RegisterFunctionPack(UdnCustomFunctionPack{})
+
+	return engine
+}
+
+// Returns the engine executing udn_schema
+func GetUdnEngine(udn_schema map[string]interface{}) *Engine {
+	if engine, ok := udn_schema["engine"].(*Engine); ok {
+		return engine
+	}
+
+	return DefaultEngine
+}
+
+// Register a UDN function, so it can be called by name.  Registering a name again replaces the earlier function.
+func (engine *Engine) RegisterUdnFunction(signature *UdnFunctionSignature) {
+	if _, ok := engine.FunctionSignatures[signature.Name]; !ok {
+		engine.FunctionNames = append(engine.FunctionNames, signature.Name)
+	}
+
+	engine.FunctionSignatures[signature.Name] = signature
+	engine.Functions[signature.Name] = signature.Function
+}
+
+func (engine *Engine) Configure(default_database *DatabaseConfig, databases map[string]DatabaseConfig, logging *LoggingConfig, authentication *AuthenticationConfig) {
+	engine.LogConfig = logging
+	engine.LogLevel = ParseUdnLogLevel(logging.Level) // see yudiencore/core.go func UdnLogLevels
+
+	if logging.OutputPath != "" {
+		logger, err := NewJsonLinesLogger(logging.OutputPath, int64(logging.MaxSizeMb)*1024*1024, logging.MaxBackups)
+		if err != nil {
+			engine._Log(log_error, "Configure: Logging: %s\n", err)
+		} else {
+			engine.Logger = logger
+		}
+	}
+
+	engine._Log(log_info, "Configuring Yudien\n")
+
+	engine.Ldap = &authentication.LdapConfig
+	engine.DevelopmentUsers = authentication.DevelopmentUsers
+
+	engine.DefaultDatabase = default_database
+
+	engine._Log(log_info, "\n\nConfig: Logging: %v\n\n", logging)
+
+	// Without a default database there are no datasources, ex: executing against a stand-in (Datasources.StandIn)
+	if default_database != nil {
+		engine.Datasources.Init(*default_database, databases)
+	}
+}
+
+// Returns a udn_schema for executing with this engine.  Only the settings, no parsed UDN from a database, see PrepareSchemaUDN for that.
+func (engine *Engine) NewUdnSchema() map[string]interface{} {
+	udn_schema := make(map[string]interface{})
+
+	// By default, do not debug this request
+	udn_schema["udn_debug"] = false
+
+	// By default, logging is turned on
+	udn_schema["allow_logging"] = true
+
+	// Debug information, for rendering the debug output
+	UdnDebugReset(udn_schema)
+
+	engine._Attach(udn_schema)
+
+	return udn_schema
+}
+
+// The log level of executions with this engine
+func (engine *Engine) GetLogLevel() int {
+	return engine.LogLevel
+}
+
+// The default engine's level is also Debug_Udn_Log_Level, which logging outside of an execution uses
+func (engine *Engine) SetLogLevel(log_level int) {
+	if engine == DefaultEngine {
+		Debug_Udn_Log_Level = log_level
+	}
+
+	engine.LogLevel = log_level
+}
+
+// The Logger of executions with this engine
+func (engine *Engine) GetLogger() Logger {
+	return engine.Logger
+}
+
+// Where UdnDebugWriteHtml writes the HTML debug logs of executions with this engine: with the traces, in LoggingConfig.TracePath, or the temp directory if that isnt set
+func (engine *Engine) GetDebugHtmlPath() string {
+	if engine.LogConfig == nil {
+		return ""
+	}
+
+	return engine.LogConfig.TracePath
+}
+
+// Executions with udn_schema use this engine
+func (engine *Engine) _Attach(udn_schema map[string]interface{}) {
+	if udn_schema["engine"] != engine {
+		udn_schema["engine"] = engine
+	}
+}
+
+// Log without an execution
+func (engine *Engine) _Log(log_level int, format string, args ...interface{}) {
+	if log_level <= engine.GetLogLevel() {
+		engine.GetLogger().Log(log_level, fmt.Sprintf(format, args...), nil)
+	}
+}
+
+// Dataman options for the execution of udn_schema: Dataman uses the datasources of its engine, and its queries are cancelled with the execution (UdnExecutionDatamanContext).  options isnt changed.
+func _UdnDatamanOptions(udn_schema map[string]interface{}, options map[string]interface{}) map[string]interface{} {
+	udn_options := MapCopy(options)
+
+	// The default engine's datasources are the default for Dataman
+	if engine := GetUdnEngine(udn_schema); engine != DefaultEngine {
+		udn_options["datasources"] = engine.Datasources
+	}
+
+	udn_options["context"] = UdnExecutionDatamanContext(udn_schema)
+
+	return udn_options
+}
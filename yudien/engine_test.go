@@ -0,0 +1,234 @@
+package yudien
+
+import (
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/ghowland/yudien/yudiencore"
+	. "github.com/ghowland/yudien/yudiendata"
+)
+
+func TestUdnEngine(t *testing.T) {
+	engines := []*Engine{NewEngine(), NewEngine()}
+
+	for index, engine := range engines {
+		greeting := fmt.Sprintf("engine %d", index)
+
+		engine.RegisterUdnFunction(&UdnFunctionSignature{
+			Name: "__greeting",
+			Function: func(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart, args []interface{}, input interface{}, udn_data map[string]interface{}) UdnResult {
+				return UdnResult{Result: greeting}
+			},
+		})
+	}
+
+	// Each engine executes its own functions, and they arent registered with the default engine
+	for index, engine := range engines {
+		udn_schema := engine.NewUdnSchema()
+
+		if result := engine.ProcessSingleUDNTarget(nil, udn_schema, "__greeting", nil, map[string]interface{}{}); result != fmt.Sprintf("engine %d", index) {
+			t.Errorf("Engine %d: Unexpected result: %v", index, result)
+		}
+
+		if names := ProcessSingleUDNTarget(nil, udn_schema, "__help", nil, map[string]interface{}{}); !strings.Contains(fmt.Sprint(names), "__greeting") {
+			t.Errorf("Engine %d: __help does not list its function: %v", index, names)
+		}
+	}
+
+	if UdnFunctions["__greeting"] != nil {
+		t.Errorf("Engine function was registered with the default engine")
+	}
+
+	udn_schema := testUdnSchema()
+	if result := ProcessSingleUDNTarget(nil, udn_schema, "__input.1.__greeting", nil, map[string]interface{}{}); result != int64(1) {
+		t.Errorf("Default engine executed another engine's function: %v", result)
+	}
+}
+
+func TestUdnEngineLogging(t *testing.T) {
+	logger := &testLogger{}
+
+	engine := NewEngine()
+	engine.Logger = logger
+	engine.SetLogLevel(ParseUdnLogLevel("trace"))
+
+	udn_schema := engine.NewUdnSchema()
+	udn_schema["allow_logging"] = false
+	engine.ProcessSingleUDNTarget(nil, udn_schema, "__input.1", nil, map[string]interface{}{})
+
+	if len(logger.lines) == 0 {
+		t.Errorf("Engine's logger was not used")
+	}
+
+	// The default engine's level is unchanged
+	if Debug_Udn_Log_Level == ParseUdnLogLevel("trace") {
+		t.Errorf("Engine log level changed the default engine's")
+	}
+
+	// __log_level changes only this engine's level
+	engine.ProcessSingleUDNTarget(nil, udn_schema, "__log_level.info", nil, map[string]interface{}{})
+	if engine.LogLevel != ParseUdnLogLevel("info") || Debug_Udn_Log_Level == ParseUdnLogLevel("info") {
+		t.Errorf("Unexpected log levels: engine %d  default %d", engine.LogLevel, Debug_Udn_Log_Level)
+	}
+}
+
+// Executions use their engine's trace directory and profiler, and the default engine's settings are its own fields, not the package globals
+func TestUdnEngineSettings(t *testing.T) {
+	trace_dir, err := ioutil.TempDir("", "udn_trace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(trace_dir)
+
+	engine := NewEngine()
+	engine.LogConfig = &LoggingConfig{TracePath: trace_dir}
+	engine.Profiler = NewUdnProfiler()
+
+	udn_schema := engine.NewUdnSchema()
+	udn_schema["allow_logging"] = false
+	StartUdnTrace(udn_schema)
+	ProcessSingleUDNTarget(nil, udn_schema, "__input.1", nil, map[string]interface{}{})
+
+	if trace_path := FinishUdnTrace(udn_schema); filepath.Dir(trace_path) != trace_dir {
+		t.Errorf("Trace was not written into the engine's trace directory: %q", trace_path)
+	}
+	if len(engine.Profiler.Top(0)) == 0 {
+		t.Errorf("Execution was not profiled into the engine's profiler")
+	}
+	if default_schema := testUdnSchema(); GetUdnProfiler(default_schema) != nil || FinishUdnTrace(default_schema) != "" {
+		t.Errorf("Default engine used another engine's profiler or trace directory")
+	}
+
+	default_log_level, default_logger := DefaultEngine.LogLevel, DefaultEngine.Logger
+	defer func() { DefaultEngine.LogLevel, DefaultEngine.Logger = default_log_level, default_logger }()
+
+	logger := &testLogger{}
+	DefaultEngine.LogLevel = ParseUdnLogLevel("trace")
+	DefaultEngine.Logger = logger
+
+	default_schema := NewUdnSchema()
+	if GetUdnLogLevel(default_schema) != ParseUdnLogLevel("trace") || GetUdnLogger(default_schema) != logger {
+		t.Errorf("Default engine's log level and logger were not used")
+	}
+}
+
+func TestUdnEngineDatamanOptions(t *testing.T) {
+	engine := NewEngine()
+
+	options := map[string]interface{}{"db": "other"}
+
+	if engine_options := _UdnDatamanOptions(engine.NewUdnSchema(), options); engine_options["datasources"] != engine.Datasources || options["datasources"] != nil {
+		t.Errorf("Unexpected engine options: %v  %v", engine_options, options)
+	}
+
+	if default_options := _UdnDatamanOptions(testUdnSchema(), options); default_options["datasources"] != nil {
+		t.Errorf("Default engine options were changed: %v", default_options)
+	}
+
+	if DefaultEngine.Datasources != DefaultDatasources {
+		t.Errorf("Default engine does not use the default datasources")
+	}
+}
+
+// Records the datasources of the options of every call, so we can see which engine's Dataman was used
+type testDatasourcesStore struct {
+	*DatamanFileStore
+
+	datasources []interface{}
+}
+
+func (store *testDatasourcesStore) Get(collection_name string, record_id int64, options map[string]interface{}) map[string]interface{} {
+	store.datasources = append(store.datasources, options["datasources"])
+	return store.DatamanFileStore.Get(collection_name, record_id, options)
+}
+
+func (store *testDatasourcesStore) Set(collection_name string, record map[string]interface{}, options map[string]interface{}) map[string]interface{} {
+	store.datasources = append(store.datasources, options["datasources"])
+	return store.DatamanFileStore.Set(collection_name, record, options)
+}
+
+func (store *testDatasourcesStore) Filter(collection_name string, filter interface{}, options map[string]interface{}) []map[string]interface{} {
+	store.datasources = append(store.datasources, options["datasources"])
+	return store.DatamanFileStore.Filter(collection_name, filter, options)
+}
+
+func (store *testDatasourcesStore) Delete(collection_name string, record_id int64, options map[string]interface{}) map[string]interface{} {
+	store.datasources = append(store.datasources, options["datasources"])
+	return store.DatamanFileStore.Delete(collection_name, record_id, options)
+}
+
+func TestUdnEngineDatamanPaths(t *testing.T) {
+	file_store, _ := NewDatamanFileStore("")
+	file_store.Collections["user"] = []map[string]interface{}{{"_id": int64(1), "name": "a", "data_json": map[string]interface{}{"x": int64(1), "y": int64(2)}}, {"_id": int64(2), "name": "b"}}
+
+	store := &testDatasourcesStore{DatamanFileStore: file_store}
+
+	engine := NewEngine()
+	engine.Datasources.StandIn = store
+	engine.Ldap = &LdapConfig{Host: "127.0.0.1", Port: 1}
+	engine.DevelopmentUsers = map[string]StaticUser{"admin": {Username: "admin", Password: "secret"}}
+
+	statements := []string{
+		"__data_tombstone.'opsdb.user.2'",
+		"__data_field_map_delete.'opsdb.user.1.data_json||x'",
+		"__data_delete_filter.user.{name=b}",
+		"__login.admin.secret",
+	}
+
+	for _, statement := range statements {
+		store.datasources = nil
+
+		udn_schema := engine.NewUdnSchema()
+		engine.ProcessSingleUDNTarget(nil, udn_schema, statement, nil, map[string]interface{}{})
+
+		if udn_error := GetUdnError(udn_schema); udn_error != nil {
+			t.Errorf("%s: Unexpected error: %v", statement, udn_error["message"])
+		}
+		if len(store.datasources) == 0 {
+			t.Errorf("%s: Dataman was not called", statement)
+		}
+		for _, datasources := range store.datasources {
+			if datasources != engine.Datasources {
+				t.Errorf("%s: Dataman was called without the engine's datasources", statement)
+			}
+		}
+	}
+}
+
+func TestUdnEngineConfigureInitUdn(t *testing.T) {
+	default_engine := *DefaultEngine
+	log_level, logger, log_config, ldap, development_users := Debug_Udn_Log_Level, UdnLogger, UDNLogConfig, Ldap, DevelopmentUsers
+	defer func() {
+		*DefaultEngine = default_engine
+		Debug_Udn_Log_Level, UdnLogger, UDNLogConfig, Ldap, DevelopmentUsers = log_level, logger, log_config, ldap, development_users
+	}()
+
+	store, _ := NewDatamanFileStore("")
+	DefaultEngine.Datasources.StandIn = store
+	defer func() { DefaultEngine.Datasources.StandIn = nil }()
+
+	engine := DefaultEngine
+
+	authentication := &AuthenticationConfig{
+		LdapConfig:       LdapConfig{Host: "127.0.0.1", Port: 1},
+		DevelopmentUsers: map[string]StaticUser{"admin": {Username: "admin", Password: "secret"}},
+	}
+	Configure(nil, nil, &LoggingConfig{Level: LogLevelName(log_level)}, authentication)
+
+	// Like udn-repl, which configured before initializing
+	InitUdn()
+
+	if DefaultEngine != engine || DefaultEngine.DevelopmentUsers["admin"].Password != "secret" || DefaultEngine.Ldap.Port != 1 {
+		t.Fatalf("InitUdn replaced the configured default engine")
+	}
+
+	udn_schema := testUdnSchema()
+	if session := ProcessSingleUDNTarget(nil, udn_schema, "__login.admin.secret", nil, map[string]interface{}{}); session == nil || session == "" {
+		t.Errorf("Login with a development user failed after InitUdn: %v  Error: %v", session, GetUdnError(udn_schema))
+	}
+}
@@ -0,0 +1,129 @@
+package yudien
+
+import (
+	"fmt"
+	. "github.com/ghowland/yudien/yudiencore"
+	. "github.com/ghowland/yudien/yudienutil"
+	"runtime/debug"
+)
+
+// An error returned in UdnResult.Error is kept in udn_schema["udn_error"] while it unwinds.  Every executor stops when one is pending, until a __try block catches it (moving it to udn_data["error"]), or it reaches the top of the execution.
+//NOTE(g): This is kept in udn_schema and not udn_data, so uncaught errors dont end up in the udn_data that is returned to callers
+
+// Returns true if an error is unwinding, and no more UDN should be executed
+func UdnErrorPending(udn_schema map[string]interface{}) bool {
+	return udn_schema != nil && udn_schema["udn_error"] != nil
+}
+
+// Returns the pending (or last uncaught) error: {message, function, function_stack}, or nil
+func GetUdnError(udn_schema map[string]interface{}) map[string]interface{} {
+	if !UdnErrorPending(udn_schema) {
+		return nil
+	}
+
+	return udn_schema["udn_error"].(map[string]interface{})
+}
+
+// Start unwinding an error returned by a UDN function
+func SetUdnError(udn_schema map[string]interface{}, udn_function *UdnPart, args []interface{}, message string) {
+	if udn_schema == nil {
+		return
+	}
+
+	udn_error := make(map[string]interface{})
+	udn_error["message"] = message
+	udn_error["function"] = udn_function.Value
+	udn_error["function_stack"] = []interface{}{_UdnErrorStackFrame(udn_function, args)}
+
+	// Execution limit errors say which limit, and cant be caught
+	if limit := UdnExecutionAborted(udn_schema); limit != "" {
+		udn_error["limit"] = limit
+	}
+
+	udn_schema["udn_error"] = udn_error
+
+	UdnError(udn_schema, "UDN Error: %s: %s\n", udn_function.Value, message)
+}
+
+// Add a function we are unwinding through to the error's function_stack, so we know how we got to the function that failed.  Innermost function first.
+func UnwindUdnError(udn_schema map[string]interface{}, udn_function *UdnPart, args []interface{}) {
+	udn_error := GetUdnError(udn_schema)
+	if udn_error == nil {
+		return
+	}
+
+	udn_error["function_stack"] = append(udn_error["function_stack"].([]interface{}), _UdnErrorStackFrame(udn_function, args))
+}
+
+// Clear the pending error, and return it.  Used by __try, and at the start of a new top level execution, so an earlier uncaught error doesnt stop it.
+func ClearUdnError(udn_schema map[string]interface{}) map[string]interface{} {
+	udn_error := GetUdnError(udn_schema)
+
+	if udn_error != nil {
+		delete(udn_schema, "udn_error")
+	}
+
+	return udn_error
+}
+
+func _UdnErrorStackFrame(udn_function *UdnPart, args []interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"function": udn_function.Value,
+		"args":     SnippetData(args, 120),
+	}
+}
+
+// Turn a panic recovered while executing udn_start into an error, which unwinds like any other.  Called by ExecuteUdnPart.  Besides the usual error fields, the error has where it happened: the UDN "source" statement, the "udn_part_id", the "frames" of udn_data["__function_stack"], and the Go "go_stack".  It is also written to udn_schema["error_log"] as a JSON line.
+func RecoverUdnPanic(udn_schema map[string]interface{}, udn_start *UdnPart, udn_data map[string]interface{}, recovered interface{}) {
+	message := fmt.Sprintf("Panic: %v", recovered)
+
+	if udn_schema == nil {
+		UdnError(nil, "UDN Panic: %s: %s\n%s\n", udn_start.Value, message, debug.Stack())
+		return
+	}
+
+	if UdnErrorPending(udn_schema) {
+		// Our function panicked handling an error from something it executed, that error is the one to unwind
+		UnwindUdnError(udn_schema, udn_start, nil)
+	} else {
+		SetUdnError(udn_schema, udn_start, nil, message)
+	}
+
+	frames := make([]interface{}, 0)
+	function_stack, _ := udn_data["__function_stack"].([]map[string]interface{})
+	for _, function_stack_frame := range function_stack {
+		frame := map[string]interface{}{"uuid": function_stack_frame["uuid"]}
+
+		if scopes, ok := function_stack_frame["scopes"]; ok {
+			frame["scopes"] = SnippetData(scopes, 240)
+		}
+
+		frames = append(frames, frame)
+	}
+
+	udn_error := GetUdnError(udn_schema)
+	udn_error["panic"] = message
+	udn_error["source"] = udn_schema["udn_source"]
+	udn_error["udn_part_id"] = udn_start.Id
+	udn_error["frames"] = frames
+	udn_error["go_stack"] = string(debug.Stack())
+
+	UdnError(udn_schema, "UDN Panic: %s\n", JsonDumpData(udn_error))
+}
+
+// Set the UDN statement being executed, and return the previous one
+func _SwapUdnSource(udn_schema map[string]interface{}, source string) string {
+	if udn_schema == nil {
+		return ""
+	}
+
+	previous_source, _ := udn_schema["udn_source"].(string)
+
+	if source == "" {
+		delete(udn_schema, "udn_source")
+	} else {
+		udn_schema["udn_source"] = source
+	}
+
+	return previous_source
+}
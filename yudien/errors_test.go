@@ -0,0 +1,117 @@
+package yudien
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/ghowland/yudien/yudiencore"
+	. "github.com/ghowland/yudien/yudienutil"
+)
+
+func testUdnSchema() map[string]interface{} {
+	udn_schema := map[string]interface{}{"udn_debug": false, "allow_logging": false}
+	UdnDebugReset(udn_schema)
+	return udn_schema
+}
+
+func TestUdnErrorStopsPipe(t *testing.T) {
+	udn_schema := testUdnSchema()
+	udn_data := map[string]interface{}{}
+
+	result := ProcessSingleUDNTarget(nil, udn_schema, "__input.[1,2].__iterate.__input.'not json'.__json_decode.__end_iterate.__set.temp.x", nil, udn_data)
+
+	if result != nil || udn_data["temp"] != nil {
+		t.Fatalf("Execution continued after an error: %v  %v", result, udn_data)
+	}
+
+	udn_error := GetUdnError(udn_schema)
+	if udn_error == nil || udn_error["function"] != "__json_decode" {
+		t.Fatalf("Unexpected error: %v", udn_error)
+	}
+
+	function_stack := udn_error["function_stack"].([]interface{})
+	if len(function_stack) != 2 || function_stack[1].(map[string]interface{})["function"] != "__iterate" {
+		t.Fatalf("Unexpected function stack: %v", function_stack)
+	}
+
+	// The next execution starts clean
+	if ProcessSingleUDNTarget(nil, udn_schema, "__input.1", nil, udn_data) != int64(1) || GetUdnError(udn_schema) != nil {
+		t.Fatalf("Uncaught error leaked into the next execution")
+	}
+}
+
+func TestUdnTryCatch(t *testing.T) {
+	udn_schema := testUdnSchema()
+	udn_data := map[string]interface{}{}
+
+	result := ProcessSingleUDNTarget(nil, udn_schema, "__try.__input.'not json'.__json_decode.__input.unreached.__catch.__get.error.function.__end_try.__set.temp.caught", nil, udn_data)
+
+	if result != "__json_decode" || GetUdnError(udn_schema) != nil {
+		t.Fatalf("Error was not caught: %v  %v", result, GetUdnError(udn_schema))
+	}
+	if MapGet([]interface{}{"temp", "caught"}, udn_data) != "__json_decode" {
+		t.Fatalf("Execution did not continue after __end_try: %v", udn_data)
+	}
+
+	// Without an error, the catch block is skipped
+	result = ProcessSingleUDNTarget(nil, udn_schema, "__try.__input.ok.__catch.__input.failed.__end_try", nil, udn_data)
+	if result != "ok" {
+		t.Fatalf("Catch block executed without an error: %v", result)
+	}
+
+	// Inner __try blocks catch their own errors
+	result = ProcessSingleUDNTarget(nil, udn_schema, "__try.__try.__input.'x'.__json_decode.__catch.__input.inner.__end_try.__catch.__input.outer.__end_try", nil, udn_data)
+	if result != "inner" {
+		t.Fatalf("Nested try caught by the wrong block: %v", result)
+	}
+}
+
+func TestUdnPanicRecovered(t *testing.T) {
+	udn_schema := testUdnSchema()
+	udn_data := map[string]interface{}{}
+
+	// __exec_command without a command indexes past its arguments
+	result := ProcessSingleUDNTarget(nil, udn_schema, "__input.1.__exec_command", nil, udn_data)
+
+	udn_error := GetUdnError(udn_schema)
+	if result != nil || udn_error == nil || udn_error["function"] != "__exec_command" {
+		t.Fatalf("Panic was not recovered as an error: %v  %v", result, udn_error)
+	}
+	if udn_error["source"] != "__input.1.__exec_command" || udn_error["udn_part_id"] == "" || !strings.Contains(udn_error["go_stack"].(string), "UDN_ExecCommand") {
+		t.Errorf("Panic error is missing where it happened: %v", udn_error)
+	}
+	if !strings.Contains(udn_schema["error_log"].(string), "UDN Panic: {") {
+		t.Errorf("Panic was not written to the error log: %s", udn_schema["error_log"])
+	}
+	if _, ok := udn_schema["udn_source"]; ok {
+		t.Errorf("UDN source was left in udn_schema: %v", udn_schema["udn_source"])
+	}
+
+	// Inside a function, the error has its frames, and the function's frame and call depth are removed
+	udn_schema = testUdnSchema()
+	PushUdnFunctionStack(udn_data)
+	ProcessUDN(nil, udn_schema, []string{"__define.broken.__exec_command.__end_define", "__call.broken"}, udn_data)
+
+	udn_error = GetUdnError(udn_schema)
+	if udn_error == nil || udn_error["source"] != "__call.broken" || len(udn_error["frames"].([]interface{})) != 2 {
+		t.Errorf("Unexpected error from a function: %v", udn_error)
+	}
+	if _GetUdnFunctionStackDepth(udn_data) != 1 || udn_data["__call_depth"] != nil {
+		t.Errorf("Function frame or call depth was left behind: %v", udn_data)
+	}
+	PopUdnFunctionStack(udn_data)
+
+	// Concurrent blocks recover on their own goroutine
+	udn_schema = testUdnSchema()
+	ProcessUdnConcurrentBlocks(nil, udn_schema, [][]string{{"__input.1.__set.temp.ok"}, {"__exec_command"}}, udn_data)
+
+	if udn_error = GetUdnError(udn_schema); udn_error == nil || udn_error["function"] != "__exec_command" || MapGet([]interface{}{"temp", "ok"}, udn_data) != int64(1) {
+		t.Errorf("Unexpected concurrent block panic: %v  %v", udn_error, udn_data)
+	}
+
+	// __try catches it like any other error
+	result = ProcessSingleUDNTarget(nil, udn_schema, "__try.__exec_command.__catch.__get.error.panic.__end_try", nil, udn_data)
+	if message, _ := result.(string); !strings.HasPrefix(message, "Panic: ") {
+		t.Errorf("Panic was not caught: %v  %v", result, GetUdnError(udn_schema))
+	}
+}
@@ -0,0 +1,226 @@
+package yudien
+
+import (
+	"database/sql"
+	. "github.com/ghowland/yudien/yudiencore"
+	. "github.com/ghowland/yudien/yudienutil"
+	"reflect"
+	"sync"
+)
+
+// The Blocks of an execution group are run concurrently, and joined before the next group starts.  Each block gets its own copy of udn_data and udn_schema, so blocks never touch the same maps.  After the join, the changes each block made are merged back, in block order.
+//NOTE(g): Blocks should set different data (ex: each __data_filter into its own key).  If blocks change the same value, the last block wins, as if they had run sequentially.
+//NOTE(g): Like running sequentially, the changes of the blocks after the first block with an uncaught error are not merged.  They still ran (the blocks arent stopped when one fails), so their logs are kept.
+
+// udn_schema keys that are logs.  Each block logs into its own copy, and they are appended to ours in block order, so the logs dont interleave.
+var udn_schema_log_keys = []string{"error_log", "debug_log", "debug_output", "debug_html_chunk", "debug_output_html"}
+
+// Run the blocks of an execution group concurrently, and return the result of the last block, like running them sequentially does.  If a block has an uncaught error, the first one (in block order) is left pending in udn_schema, and the result is that block's.
+func ProcessUdnConcurrentBlocks(db *sql.DB, udn_schema map[string]interface{}, udn_group [][]string, udn_data map[string]interface{}) interface{} {
+	// Debug logging (HTML debug output, and log levels that write every function into the debug log) is only readable in execution order, so debugging runs the blocks one at a time
+	if len(udn_group) <= 1 {
+		return _ProcessUdnBlocksSequentially(db, udn_schema, udn_group, udn_data)
+	}
+
+	if _UdnDebugLogging(udn_schema) {
+		UdnLogLevel(udn_schema, log_trace, "Process Concurrent Blocks: %d: Running them sequentially, for debug logging\n", len(udn_group))
+		return _ProcessUdnBlocksSequentially(db, udn_schema, udn_group, udn_data)
+	}
+
+	UdnLogLevel(udn_schema, log_trace, "Process Concurrent Blocks: %d\n", len(udn_group))
+
+	// Copies are made before starting any goroutines, as nothing may read udn_data while it is being written.  udn_data isnt changed until the join, so it is what we find each block's changes against.
+	block_datas, err := _CopyUdnDataForBlocks(udn_data, len(udn_group))
+	if err != nil {
+		UdnError(udn_schema, "Process Concurrent Blocks: Cant copy udn_data for the blocks, running them sequentially: %s\n", err)
+		return _ProcessUdnBlocksSequentially(db, udn_schema, udn_group, udn_data)
+	}
+
+	block_schemas := make([]map[string]interface{}, len(udn_group))
+	block_results := make([]interface{}, len(udn_group))
+
+	for index := range udn_group {
+		block_schemas[index] = _CopyUdnSchemaForBlock(udn_schema)
+	}
+
+	var wait_group sync.WaitGroup
+
+	for index, udn_group_block := range udn_group {
+		wait_group.Add(1)
+
+		go func(index int, udn_group_block []string) {
+			defer wait_group.Done()
+
+			block_results[index] = ProcessUDN(db, block_schemas[index], udn_group_block, block_datas[index])
+		}(index, udn_group_block)
+	}
+
+	wait_group.Wait()
+
+	// Find all the blocks' changes before merging any of them, as merging changes udn_data
+	block_changes := make([]map[string]*_UdnDataChange, len(udn_group))
+	for index := range udn_group {
+		block_changes[index] = _GetUdnDataChanges(udn_data, block_datas[index])
+	}
+
+	var result interface{}
+
+	// Join, in block order.  After a block with an uncaught error only the logs are merged, as the blocks after it wouldnt have run sequentially.
+	for index := range udn_group {
+		if UdnErrorPending(udn_schema) {
+			_MergeUdnSchemaLogsFromBlock(udn_schema, block_schemas[index])
+			continue
+		}
+
+		_MergeUdnSchemaFromBlock(udn_schema, block_schemas[index])
+		_ApplyUdnDataChanges(udn_data, block_changes[index])
+
+		result = block_results[index]
+
+		if UdnErrorPending(block_schemas[index]) {
+			udn_schema["udn_error"] = GetUdnError(block_schemas[index])
+		}
+	}
+
+	return result
+}
+
+func _ProcessUdnBlocksSequentially(db *sql.DB, udn_schema map[string]interface{}, udn_group [][]string, udn_data map[string]interface{}) interface{} {
+	var result interface{}
+
+	for _, udn_group_block := range udn_group {
+		result = ProcessUDN(db, udn_schema, udn_group_block, udn_data)
+
+		// An uncaught error stops the rest of the blocks too, it will keep unwinding to our caller
+		if UdnErrorPending(udn_schema) {
+			break
+		}
+	}
+
+	return result
+}
+
+// Returns a copy of udn_data for each of count concurrent blocks.  Blocks can never share udn_data, so if it cant be copied, this is an error instead of the original.
+func _CopyUdnDataForBlocks(udn_data map[string]interface{}, count int) ([]map[string]interface{}, error) {
+	block_datas := make([]map[string]interface{}, count)
+
+	for index := range block_datas {
+		block_data, err := DeepCopyChecked(udn_data)
+		if err != nil {
+			return nil, err
+		}
+
+		block_datas[index], _ = block_data.(map[string]interface{})
+	}
+
+	return block_datas, nil
+}
+
+// Returns true if executing writes debug logs, or calls a debugger, that need to be in execution order
+func _UdnDebugLogging(udn_schema map[string]interface{}) bool {
+	return Debug_Udn || udn_schema["udn_debug"] == true || GetUdnLogLevel(udn_schema) >= log_debug || GetUdnDebugger(udn_schema) != nil || GetUdnTrace(udn_schema) != nil
+}
+
+// Returns a udn_schema for a concurrent block.  Settings are shared, but logs and execution state (errors, loop control, __define functions) are the block's own.
+func _CopyUdnSchemaForBlock(udn_schema map[string]interface{}) map[string]interface{} {
+	// The execution budget is shared, so make sure there is one before copying
+	GetUdnExecutionBudget(udn_schema)
+
+	block_schema := MapCopy(udn_schema)
+
+	for _, key := range udn_schema_log_keys {
+		block_schema[key] = ""
+	}
+
+	delete(block_schema, "udn_error")
+	delete(block_schema, "loop_control")
+	block_schema["loop_depth"] = 0
+
+	// Functions defined before the group can be called by all blocks, functions a block defines are its own until the join
+	block_defined_functions := make(map[string]*UdnDefinedFunction)
+	for name, defined_function := range _GetUdnDefinedFunctions(udn_schema) {
+		block_defined_functions[name] = defined_function
+	}
+	block_schema["defined_functions"] = block_defined_functions
+
+	_CopyUdnProfileStackForBlock(udn_schema, block_schema)
+
+	return block_schema
+}
+
+// Append a block's logs to ours, and keep the functions it defined
+func _MergeUdnSchemaFromBlock(udn_schema map[string]interface{}, block_schema map[string]interface{}) {
+	_MergeUdnSchemaLogsFromBlock(udn_schema, block_schema)
+
+	defined_functions := _GetUdnDefinedFunctions(udn_schema)
+	for name, defined_function := range _GetUdnDefinedFunctions(block_schema) {
+		defined_functions[name] = defined_function
+	}
+}
+
+func _MergeUdnSchemaLogsFromBlock(udn_schema map[string]interface{}, block_schema map[string]interface{}) {
+	for _, key := range udn_schema_log_keys {
+		log_value, _ := udn_schema[key].(string)
+		block_log_value, _ := block_schema[key].(string)
+
+		udn_schema[key] = log_value + block_log_value
+	}
+}
+
+// A value a block changed in its copy of udn_data.  Maps that were maps before and after are merged key by key (Changes), so blocks can set different keys in the same map (ex: __set.temp.a and __set.temp.b).  Any other changed value is replaced.
+type _UdnDataChange struct {
+	Value   interface{}
+	Deleted bool
+	Changes map[string]*_UdnDataChange
+}
+
+// Returns the changes a block made to its copy (block_data) of original_data
+func _GetUdnDataChanges(original_data map[string]interface{}, block_data map[string]interface{}) map[string]*_UdnDataChange {
+	changes := make(map[string]*_UdnDataChange)
+
+	for key, block_value := range block_data {
+		original_value, existed := original_data[key]
+
+		original_map, original_is_map := original_value.(map[string]interface{})
+		block_map, block_is_map := block_value.(map[string]interface{})
+
+		// A map that an earlier block also created is merged too
+		if !existed {
+			original_map, original_is_map = map[string]interface{}{}, true
+		}
+
+		if original_is_map && block_is_map {
+			map_changes := _GetUdnDataChanges(original_map, block_map)
+			if len(map_changes) > 0 || !existed {
+				changes[key] = &_UdnDataChange{Value: block_value, Changes: map_changes}
+			}
+		} else if !existed || !reflect.DeepEqual(original_value, block_value) {
+			changes[key] = &_UdnDataChange{Value: block_value}
+		}
+	}
+
+	// Anything the block deleted
+	for key := range original_data {
+		if _, ok := block_data[key]; !ok {
+			changes[key] = &_UdnDataChange{Deleted: true}
+		}
+	}
+
+	return changes
+}
+
+// Apply a block's changes (from _GetUdnDataChanges) to udn_data
+func _ApplyUdnDataChanges(udn_data map[string]interface{}, changes map[string]*_UdnDataChange) {
+	for key, change := range changes {
+		if change.Deleted {
+			delete(udn_data, key)
+			continue
+		}
+
+		if current_map, ok := udn_data[key].(map[string]interface{}); ok && change.Changes != nil {
+			_ApplyUdnDataChanges(current_map, change.Changes)
+		} else {
+			udn_data[key] = change.Value
+		}
+	}
+}
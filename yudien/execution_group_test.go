@@ -0,0 +1,125 @@
+package yudien
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	. "github.com/ghowland/yudien/yudiencore"
+	. "github.com/ghowland/yudien/yudienutil"
+	"github.com/mitchellh/copystructure"
+)
+
+func TestUdnConcurrentBlocks(t *testing.T) {
+	udn_schema := testUdnSchema()
+	udn_data := map[string]interface{}{"temp": map[string]interface{}{"kept": 1}, "removed": 1}
+
+	// The blocks of the first group set different keys, some in the same map, and the second group sees all of them
+	udn_data_json := `[
+		[["__input.1.__set.a"], ["__input.2.__set.temp.b", "__input.3.__set.temp.c"], ["__input.[1,2].__iterate.__set_temp.item.__end_iterate.__set.d"], ["__input.0.__set.removed"]],
+		[["__input.[(__get.a),(__get.temp.b),(__get.temp.c),(__get.temp.kept),(__get.d),(__get.removed)]"]]
+	]`
+
+	result := ProcessSchemaUDNSet(nil, udn_schema, udn_data_json, udn_data)
+
+	if JsonDumpData(result) != "[1,2,3,1,[1,2],0]" {
+		t.Fatalf("Unexpected result: %s  Error: %v", JsonDumpData(result), GetUdnError(udn_schema))
+	}
+	if len(udn_data["__function_stack"].([]map[string]interface{})) != 0 {
+		t.Errorf("Function stack was not popped: %v", udn_data["__function_stack"])
+	}
+
+	// A failing block doesnt stop the other blocks in its group, but like running sequentially, the changes of the blocks after it arent kept, and the group after it isnt run
+	udn_data_json = `[
+		[["__input.1.__set.before"], ["__input.'not json'.__json_decode"], ["__input.1.__set.after"]],
+		[["__input.1.__set.next_group"]]
+	]`
+
+	ProcessSchemaUDNSet(nil, udn_schema, udn_data_json, udn_data)
+
+	if udn_error := GetUdnError(udn_schema); udn_error == nil || udn_error["function"] != "__json_decode" {
+		t.Errorf("Block error was not left pending: %v", udn_error)
+	}
+	if udn_data["before"] != int64(1) || udn_data["after"] != nil || udn_data["next_group"] != nil {
+		t.Errorf("Unexpected block changes kept: before=%v after=%v next_group=%v", udn_data["before"], udn_data["after"], udn_data["next_group"])
+	}
+}
+
+// Debug logging runs the blocks one at a time, which is logged at the trace level
+func TestUdnConcurrentBlocksDebugLogging(t *testing.T) {
+	logger := &testLogger{}
+
+	engine := NewEngine()
+	engine.Logger = logger
+	engine.SetLogLevel(ParseUdnLogLevel("trace"))
+
+	udn_schema := engine.NewUdnSchema()
+	udn_data := map[string]interface{}{}
+
+	engine.ProcessSchemaUDNSet(nil, udn_schema, `[[["__input.1.__set.first"], ["__get.first.__set.second"]]]`, udn_data)
+
+	if udn_data["second"] != int64(1) {
+		t.Errorf("Blocks were not run sequentially: %v  Error: %v", udn_data["second"], GetUdnError(udn_schema))
+	}
+
+	logged := false
+	for _, line := range logger.lines {
+		if line.level == ParseUdnLogLevel("trace") && strings.Contains(line.message, "Running them sequentially, for debug logging") {
+			logged = true
+		}
+	}
+	if !logged {
+		t.Errorf("Running the blocks sequentially was not logged")
+	}
+}
+
+// copystructure fails to copy this, so udn_data with one in it cant be copied for concurrent blocks
+type testUncopyable struct{}
+
+func init() {
+	copystructure.Copiers[reflect.TypeOf(testUncopyable{})] = func(interface{}) (interface{}, error) {
+		return nil, errors.New("cant copy testUncopyable")
+	}
+}
+
+func TestUdnConcurrentBlocksUncopyable(t *testing.T) {
+	udn_schema := testUdnSchema()
+	udn_data := map[string]interface{}{"uncopyable": testUncopyable{}}
+
+	// The blocks run one at a time instead, so the second block sees what the first set
+	udn_data_json := `[[["__input.1.__set.first"], ["__get.first.__set.second"]]]`
+
+	ProcessSchemaUDNSet(nil, udn_schema, udn_data_json, udn_data)
+
+	if GetUdnError(udn_schema) != nil || udn_data["second"] != int64(1) {
+		t.Errorf("Blocks were not run sequentially: %v  Error: %v", udn_data["second"], GetUdnError(udn_schema))
+	}
+	if !strings.Contains(udn_schema["error_log"].(string), "cant copy testUncopyable") {
+		t.Errorf("Copy error was not logged: %s", udn_schema["error_log"])
+	}
+}
+
+func TestUdnDataChanges(t *testing.T) {
+	udn_data := map[string]interface{}{"temp": map[string]interface{}{"kept": 1, "changed": 1}, "list": []interface{}{1}, "removed": 1, "replaced": map[string]interface{}{"a": 1}}
+
+	block_data := DeepCopy(udn_data).(map[string]interface{})
+	block_data["temp"].(map[string]interface{})["changed"] = 2
+	block_data["new"] = map[string]interface{}{"a": 1}
+	block_data["replaced"] = "text"
+	delete(block_data, "removed")
+
+	other_block_data := DeepCopy(udn_data).(map[string]interface{})
+	other_block_data["new"] = map[string]interface{}{"b": 2}
+	other_block_data["list"] = []interface{}{1, 2}
+
+	changes := _GetUdnDataChanges(udn_data, block_data)
+	other_changes := _GetUdnDataChanges(udn_data, other_block_data)
+
+	_ApplyUdnDataChanges(udn_data, changes)
+	_ApplyUdnDataChanges(udn_data, other_changes)
+
+	if JsonDumpData(udn_data) != `{"list":[1,2],"new":{"a":1,"b":2},"replaced":"text","temp":{"changed":2,"kept":1}}` {
+		t.Errorf("Unexpected merged data: %s", JsonDumpData(udn_data))
+	}
+}
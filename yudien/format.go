@@ -0,0 +1,128 @@
+package yudien
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	. "github.com/ghowland/yudien/yudiencore"
+	"strings"
+)
+
+// Format a parsed UDN statement back into canonical UDN source.  Parsing the result gives the same UdnPart tree, so formatting is stable: Format(parse(Format(parse(x)))) == Format(parse(x))
+//
+// Canonical UDN separates functions and arguments with ".", list items and map entries with ",", and writes map keys as "key:value" (the older "key=value" is rewritten).  Quoted strings keep their quotes, and are escaped with backslashes (\' \\ \n \t)
+func Format(udn_start *UdnPart) string {
+	if udn_start == nil || udn_start.PartType == part_unknown {
+		return ""
+	}
+
+	return _FormatUdnChain(udn_start)
+}
+
+// Parse and format a single UDN statement
+func FormatUdnString(udn_value string) (string, error) {
+	udn_start, err := ParseUdnString(nil, nil, udn_value)
+	if err != nil {
+		return "", err
+	}
+
+	return Format(udn_start), nil
+}
+
+// Format all the statements in a UDN execution group JSON, as stored in udn_stored_function.udn_data_json and widget data.  The JSON is re-encoded with one statement per line.  A plain UDN string is formatted as a single statement, like ValidateSchemaUDNSet.
+func FormatSchemaUDNSet(udn_data_json string) (string, error) {
+	udn_execution_group := UdnExecutionGroup{}
+
+	err := json.Unmarshal([]byte(udn_data_json), &udn_execution_group.Blocks)
+	if err != nil {
+		return FormatUdnString(udn_data_json)
+	}
+
+	for _, udn_group := range udn_execution_group.Blocks {
+		for _, udn_group_block := range udn_group {
+			for index, udn_value := range udn_group_block {
+				udn_group_block[index], err = FormatUdnString(udn_value)
+				if err != nil {
+					return "", fmt.Errorf("%s: %s", udn_value, err)
+				}
+			}
+		}
+	}
+
+	// Dont escape <, > and &, they are common in templates and would make the stored UDN unreadable
+	buffer := bytes.Buffer{}
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	err = encoder.Encode(udn_execution_group.Blocks)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(buffer.String()), nil
+}
+
+// Format a function and its arguments, and every function after it in the NextUdnPart chain
+func _FormatUdnChain(udn_start *UdnPart) string {
+	items := make([]string, 0)
+
+	for udn_current := udn_start; udn_current != nil; udn_current = udn_current.NextUdnPart {
+		if udn_current.PartType == part_function {
+			items = append(items, udn_current.Value)
+		}
+
+		for _, child := range udn_current.Children {
+			items = append(items, _FormatUdnValue(child))
+		}
+	}
+
+	return strings.Join(items, ".")
+}
+
+// Format a single argument, list item or map value
+func _FormatUdnValue(part *UdnPart) string {
+	switch part.PartType {
+	case part_string:
+		return _FormatUdnQuotedString(part.Value)
+	case part_compound:
+		return "(" + _FormatUdnChain(part) + ")"
+	case part_list:
+		items := make([]string, 0, len(part.Children))
+		for _, child := range part.Children {
+			items = append(items, _FormatUdnValue(child))
+		}
+		// A function in a list is chained after the list, not added to its items
+		if part.NextUdnPart != nil {
+			items = append(items, _FormatUdnChain(part.NextUdnPart))
+		}
+		return "[" + strings.Join(items, ",") + "]"
+	case part_map:
+		items := make([]string, 0, len(part.Children))
+		for _, map_key := range part.Children {
+			items = append(items, _FormatUdnMapKey(map_key))
+		}
+		// Same as lists, a function in a map is chained after the map
+		if part.NextUdnPart != nil {
+			items = append(items, _FormatUdnChain(part.NextUdnPart))
+		}
+		return "{" + strings.Join(items, ",") + "}"
+	default:
+		// Items are written as they were parsed.  Only part_string is quoted, so an item never turns into a string when it is parsed again.
+		return part.Value
+	}
+}
+
+func _FormatUdnMapKey(map_key *UdnPart) string {
+	if len(map_key.Children) == 0 {
+		return map_key.Value + ":"
+	}
+
+	return map_key.Value + ":" + _FormatUdnValue(map_key.Children[0])
+}
+
+var udn_string_escaper = strings.NewReplacer("\\", "\\\\", "'", "\\'", "\n", "\\n", "\t", "\\t")
+
+func _FormatUdnQuotedString(value string) string {
+	return "'" + udn_string_escaper.Replace(value) + "'"
+}
@@ -0,0 +1,111 @@
+package yudien
+
+import (
+	"regexp"
+	"testing"
+)
+
+var udnPartIdRegex = regexp.MustCompile(`0x[0-9a-f]+`)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		statement string
+		canonical string
+	}{
+		{"__input.Testing123.__set.temp.testing.__get.temp.testing", "__input.Testing123.__set.temp.testing.__get.temp.testing"},
+		{"__input.[a, b,c].__join.'-'", "__input.[a, b,c].__join.'-'"},
+		{"__data_set.web_widget_type.{_id=1,name='Base Page'}", "__data_set.web_widget_type.{_id:1,name:'Base Page'}"},
+		{"__data_filter.web_widget_type.{name=(__input.['=', 'Base Page'])}", "__data_filter.web_widget_type.{name:(__input.['=','Base Page'])}"},
+		{"__if.(__compare_equal.(__get.cur).2).__input.two.__else.__input.other.__end_if", "__if.(__compare_equal.(__get.cur).2).__input.two.__else.__input.other.__end_if"},
+		{"__input.'it&QUOTE;s'.__upper", "__input.'it\\'s'.__upper"},
+		{"__input.'a\\\\b\\nc'", "__input.'a\\\\b\\nc'"},
+		{"__input.[1, 2.5,-3e2,true,null,x.y]", "__input.[1, 2.5,-3e2,true,null,x,y]"},
+		{"__execute.'__input.Testing123'", "__execute.'__input.Testing123'"},
+		{"__input.{k=(__get.a.b),v=[1,(__input.z)],m={x=[]}}", "__input.{k:(__get.a.b),v:[1,(__input.z)],m:{x:[]}}"},
+		{"__comment.hello.this is a comment", "__comment.hello.this is a comment"},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		udn_part, err := ParseUdnString(nil, nil, test.statement)
+		if err != nil {
+			t.Fatalf("%s: %s", test.statement, err)
+		}
+
+		formatted := Format(udn_part)
+		if formatted != test.canonical {
+			t.Errorf("Format(%s):\n  got:  %s\n  want: %s", test.statement, formatted, test.canonical)
+			continue
+		}
+
+		// Round trip: the formatted source must parse to the same tree, and format the same again
+		round_trip_part, err := ParseUdnString(nil, nil, formatted)
+		if err != nil {
+			t.Fatalf("%s: formatted source does not parse: %s", formatted, err)
+		}
+
+		original_tree := udnPartIdRegex.ReplaceAllString(DescribeUdnPart(udn_part), "ID")
+		round_trip_tree := udnPartIdRegex.ReplaceAllString(DescribeUdnPart(round_trip_part), "ID")
+		if original_tree != round_trip_tree {
+			t.Errorf("%s: round trip changed the tree:\n%s\n---\n%s", test.statement, original_tree, round_trip_tree)
+		}
+
+		if Format(round_trip_part) != formatted {
+			t.Errorf("%s: formatting is not stable: %s", test.statement, Format(round_trip_part))
+		}
+	}
+}
+
+func TestFormatSchemaUDNSet(t *testing.T) {
+	formatted, err := FormatSchemaUDNSet(`[[["__input.{a=1}", "__set.temp.x"]]]`)
+	if err != nil {
+		t.Fatalf("FormatSchemaUDNSet: %s", err)
+	}
+
+	expected := "[\n  [\n    [\n      \"__input.{a:1}\",\n      \"__set.temp.x\"\n    ]\n  ]\n]"
+	if formatted != expected {
+		t.Errorf("FormatSchemaUDNSet:\n%s", formatted)
+	}
+
+	if _, err := FormatSchemaUDNSet(`[[["__input.(__get.x"]]]`); err == nil {
+		t.Errorf("FormatSchemaUDNSet did not return the parse error")
+	}
+}
+
+// parse(Format(parse(x))) is the same tree as parse(x), for the udn_test_cases corpus and syntax the formatter used to change
+func TestFormatRoundTrip(t *testing.T) {
+	if err := RegisterFunctionPack(&testFunctionPack{name: "ops", functions: []*UdnFunctionSignature{testPackFunction("__ops.duty_shift_summary")}}); err != nil {
+		t.Fatal(err)
+	}
+	defer UnregisterFunctionPack("ops")
+
+	statements := []string{
+		"__input.{a=1,__get.x}.__set.temp.y",
+		"__input.[1,__get.x.y]",
+		"__ops.duty_shift_summary.week.__input.x",
+		"__input.[1.5,2].{a:1.5}",
+		"__input.{a:b:c}",
+	}
+	statements = append(statements, parserBaselineStatements(t)...)
+
+	for _, statement := range statements {
+		udn_part, err := ParseUdnString(nil, nil, statement)
+		if err != nil {
+			t.Fatalf("%s: %s", statement, err)
+		}
+
+		formatted := Format(udn_part)
+
+		round_trip_part, err := ParseUdnString(nil, nil, formatted)
+		if err != nil {
+			t.Errorf("%s: formatted source %q does not parse: %s", statement, formatted, err)
+			continue
+		}
+
+		original_tree := udnPartIdRegex.ReplaceAllString(DescribeUdnPart(udn_part), "ID")
+		round_trip_tree := udnPartIdRegex.ReplaceAllString(DescribeUdnPart(round_trip_part), "ID")
+		if original_tree != round_trip_tree {
+			t.Errorf("%s: round trip through %q changed the tree:\n%s\n---\n%s", statement, formatted, original_tree, round_trip_tree)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package yudien
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// A set of UDN functions that is registered (and unregistered) together, so customer code can live in its own Go module instead of custom_functions.go.  Names are like core names ("__duty_shift_summary"), or namespaced ("__ops.duty_shift_summary") so packs cant collide with core functions added later.
+type FunctionPack interface {
+	Name() string
+	Functions() []*UdnFunctionSignature
+}
+
+// Optional for a FunctionPack.  Init is called with the engine it is being registered with, before its functions are registered.  An error stops the registration.
+type FunctionPackInit interface {
+	Init(engine *Engine) error
+}
+
+// The customer specific functions that used to be hard-wired into InitUdn.  NewEngine registers it, and UnregisterFunctionPack("custom") removes them.
+type UdnCustomFunctionPack struct{}
+
+func (pack UdnCustomFunctionPack) Name() string {
+	return "custom"
+}
+
+func (pack UdnCustomFunctionPack) Functions() []*UdnFunctionSignature {
+	return _UdnCustomFunctionSignatures()
+}
+
+// "__name", or "__namespace.name"
+var udn_function_pack_name_regex = regexp.MustCompile(`^__\w+(\.\w+)?$`)
+
+// Register all the functions of pack.  Nothing is registered if the pack name is already registered, a name is invalid, or a name collides with a function that is already registered (core, or another pack's).
+func (engine *Engine) RegisterFunctionPack(pack FunctionPack) error {
+	pack_name := pack.Name()
+	if pack_name == "" {
+		return fmt.Errorf("Function pack has no name")
+	}
+	if engine.FunctionPacks[pack_name] != nil {
+		return fmt.Errorf("Function pack is already registered: %s", pack_name)
+	}
+
+	signatures := pack.Functions()
+
+	// Check everything before Init or registering anything, so a failed pack doesnt leave half its functions behind
+	pack_names := map[string]bool{}
+	collisions := []string{}
+
+	for _, signature := range signatures {
+		if !udn_function_pack_name_regex.MatchString(signature.Name) || strings.Contains(signature.Name, ".__") {
+			return fmt.Errorf("Function pack: %s: Invalid function name: %s (must be __name or __namespace.name)", pack_name, signature.Name)
+		}
+		if pack_names[signature.Name] {
+			return fmt.Errorf("Function pack: %s: Function is in the pack twice: %s", pack_name, signature.Name)
+		}
+		pack_names[signature.Name] = true
+
+		if _, ok := engine.FunctionSignatures[signature.Name]; ok {
+			collisions = append(collisions, fmt.Sprintf("%s (%s)", signature.Name, engine._FunctionPackOf(signature.Name)))
+		}
+
+		// A namespace cant be a function, or "__get.x" would stop being __get with an arg
+		if namespace := _UdnFunctionNamespace(signature.Name); namespace != "" {
+			if _, ok := engine.FunctionSignatures[namespace]; ok {
+				collisions = append(collisions, fmt.Sprintf("%s (namespace is a %s function)", namespace, engine._FunctionPackOf(namespace)))
+			}
+		} else if engine.function_namespaces[signature.Name] {
+			collisions = append(collisions, fmt.Sprintf("%s (is a namespace)", signature.Name))
+		}
+	}
+
+	if len(collisions) > 0 {
+		return fmt.Errorf("Function pack: %s: Function names are already registered: %s", pack_name, strings.Join(collisions, ", "))
+	}
+
+	if pack_init, ok := pack.(FunctionPackInit); ok {
+		if err := pack_init.Init(engine); err != nil {
+			return fmt.Errorf("Function pack: %s: Init: %s", pack_name, err)
+		}
+	}
+
+	for _, signature := range signatures {
+		if namespace := _UdnFunctionNamespace(signature.Name); namespace != "" {
+			engine._AddFunctionNamespace(namespace)
+		}
+
+		engine.RegisterUdnFunction(signature)
+		engine.function_packs[signature.Name] = pack_name
+	}
+
+	engine.FunctionPacks[pack_name] = pack
+
+	return nil
+}
+
+// Remove all the functions that pack_name registered, and the namespaces no other pack's functions are in
+func (engine *Engine) UnregisterFunctionPack(pack_name string) error {
+	if engine.FunctionPacks[pack_name] == nil {
+		return fmt.Errorf("Function pack is not registered: %s", pack_name)
+	}
+
+	function_names := make([]string, 0, len(engine.FunctionNames))
+	for _, name := range engine.FunctionNames {
+		if engine.function_packs[name] == pack_name {
+			delete(engine.Functions, name)
+			delete(engine.FunctionSignatures, name)
+			delete(engine.function_packs, name)
+		} else {
+			function_names = append(function_names, name)
+		}
+	}
+	engine.FunctionNames = function_names
+
+	delete(engine.FunctionPacks, pack_name)
+
+	engine._RemoveUnusedFunctionNamespaces()
+
+	return nil
+}
+
+// Names of the registered packs, sorted
+func (engine *Engine) FunctionPackNames() []string {
+	names := make([]string, 0, len(engine.FunctionPacks))
+	for name := range engine.FunctionPacks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Register a function pack with the default engine
+func RegisterFunctionPack(pack FunctionPack) error {
+	err := DefaultEngine.RegisterFunctionPack(pack)
+
+	UdnFunctionNames = DefaultEngine.FunctionNames
+
+	return err
+}
+
+// Unregister a function pack from the default engine.  Ex: UnregisterFunctionPack("custom") removes the built-in __custom_* functions.
+func UnregisterFunctionPack(pack_name string) error {
+	err := DefaultEngine.UnregisterFunctionPack(pack_name)
+
+	UdnFunctionNames = DefaultEngine.FunctionNames
+
+	return err
+}
+
+// The pack that registered a function, or "core"
+func (engine *Engine) _FunctionPackOf(name string) string {
+	if pack_name, ok := engine.function_packs[name]; ok {
+		return pack_name
+	}
+
+	return "core"
+}
+
+// Returns "__ops" for "__ops.duty_shift_summary", or "" for names without a namespace
+func _UdnFunctionNamespace(name string) string {
+	if index := strings.Index(name, "."); index != -1 {
+		return name[:index]
+	}
+
+	return ""
+}
+
+// Namespaces are used by the parser, so UDN this engine parsed before is dropped from its parse cache
+func (engine *Engine) _AddFunctionNamespace(namespace string) {
+	if !engine.function_namespaces[namespace] {
+		engine.function_namespaces[namespace] = true
+
+		// Anything cached was parsed with "__namespace.name" as a function and an arg
+		engine.ParseCache.Clear()
+	}
+}
+
+func (engine *Engine) _RemoveUnusedFunctionNamespaces() {
+	used := map[string]bool{}
+	for _, name := range engine.FunctionNames {
+		if namespace := _UdnFunctionNamespace(name); namespace != "" {
+			used[namespace] = true
+		}
+	}
+
+	for namespace := range engine.function_namespaces {
+		if !used[namespace] {
+			delete(engine.function_namespaces, namespace)
+
+			// Anything cached was parsed with "__namespace.name" as one function
+			engine.ParseCache.Clear()
+		}
+	}
+}
+
+// Join namespaces with the name after them, from UDN text split on dots: ["__ops", "duty_shift_summary", "x"] is ["__ops.duty_shift_summary", "x"]
+func _JoinUdnFunctionNamespaces(dot_split_array []string, namespaces map[string]bool) []string {
+	if len(namespaces) == 0 {
+		return dot_split_array
+	}
+
+	joined := make([]string, 0, len(dot_split_array))
+
+	for index := 0; index < len(dot_split_array); index++ {
+		item := dot_split_array[index]
+
+		if index+1 < len(dot_split_array) && namespaces[item] {
+			name := dot_split_array[index+1]
+			if name != "" && !strings.HasPrefix(name, "__") {
+				item = item + "." + name
+				index++
+			}
+		}
+
+		joined = append(joined, item)
+	}
+
+	return joined
+}
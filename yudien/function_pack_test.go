@@ -0,0 +1,189 @@
+package yudien
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	. "github.com/ghowland/yudien/yudiencore"
+)
+
+type testFunctionPack struct {
+	name      string
+	functions []*UdnFunctionSignature
+	init_err  error
+	engines   []*Engine
+}
+
+func (pack *testFunctionPack) Name() string {
+	return pack.name
+}
+
+func (pack *testFunctionPack) Functions() []*UdnFunctionSignature {
+	return pack.functions
+}
+
+func (pack *testFunctionPack) Init(engine *Engine) error {
+	pack.engines = append(pack.engines, engine)
+	return pack.init_err
+}
+
+// Returns its name and args, so we can see what was called
+func testPackFunction(name string) *UdnFunctionSignature {
+	return &UdnFunctionSignature{
+		Name: name,
+		Function: func(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart, args []interface{}, input interface{}, udn_data map[string]interface{}) UdnResult {
+			return UdnResult{Result: fmt.Sprintf("%s%v", name, args)}
+		},
+	}
+}
+
+func TestFunctionPack(t *testing.T) {
+	engine := NewEngine()
+
+	pack := &testFunctionPack{name: "ops", functions: []*UdnFunctionSignature{testPackFunction("__ops.duty_shift_summary"), testPackFunction("__ops_status")}}
+
+	if err := engine.RegisterFunctionPack(pack); err != nil {
+		t.Fatal(err)
+	}
+	if len(pack.engines) != 1 || pack.engines[0] != engine {
+		t.Errorf("Init was not called with the engine: %v", pack.engines)
+	}
+
+	udn_schema := engine.NewUdnSchema()
+
+	// Namespaced names are one function, with the rest as args, and namespaces dont change other functions
+	tests := map[string]interface{}{
+		"__ops.duty_shift_summary.week.1":                                "__ops.duty_shift_summary[week 1]",
+		"__ops.duty_shift_summary":                                       "__ops.duty_shift_summary[]",
+		"__input.x.__ops.duty_shift_summary.(__input.y)":                 "__ops.duty_shift_summary[y]",
+		"__ops_status.a":                                                 "__ops_status[a]",
+		"__input.[1,2].__iterate.__ops.duty_shift_summary.__end_iterate": []interface{}{"__ops.duty_shift_summary[]", "__ops.duty_shift_summary[]"},
+	}
+	for udn_value, expected := range tests {
+		if result := engine.ProcessSingleUDNTarget(nil, udn_schema, udn_value, nil, map[string]interface{}{}); fmt.Sprint(result) != fmt.Sprint(expected) {
+			t.Errorf("%s: Expected %v, got %v", udn_value, expected, result)
+		}
+	}
+
+	// The default engine doesnt have the pack or its namespace, so __ops is an unknown function there
+	if issues := Validate("__ops.duty_shift_summary.week"); len(issues) != 1 || issues[0].Function != "__ops" {
+		t.Errorf("Validate: Unexpected issues: %v", issues)
+	}
+
+	// Registered once
+	if err := engine.RegisterFunctionPack(pack); err == nil || !strings.Contains(err.Error(), "already registered") {
+		t.Errorf("Pack was registered twice: %v", err)
+	}
+
+	// Unregistering removes only the pack's functions, and the namespace
+	if err := engine.UnregisterFunctionPack("ops"); err != nil {
+		t.Fatal(err)
+	}
+	if engine.Functions["__ops.duty_shift_summary"] != nil || engine.FunctionSignatures["__ops_status"] != nil || engine.Functions["__input"] == nil {
+		t.Errorf("Unexpected functions after unregistering: %v", engine.FunctionNames)
+	}
+	for _, name := range engine.FunctionNames {
+		if strings.HasPrefix(name, "__ops") {
+			t.Errorf("Unregistered function is still in the names: %s", name)
+		}
+	}
+	if udn_part, err := ParseUdnString(nil, engine.NewUdnSchema(), "__ops.duty_shift_summary.week"); err != nil || udn_part.Value != "__ops" {
+		t.Errorf("Namespace is still parsed after unregistering: %v", err)
+	}
+	if err := engine.UnregisterFunctionPack("ops"); err == nil {
+		t.Errorf("Unregistered a pack that isnt registered")
+	}
+}
+
+func TestFunctionPackCollisions(t *testing.T) {
+	engine := NewEngine()
+
+	if err := engine.RegisterFunctionPack(&testFunctionPack{name: "first", functions: []*UdnFunctionSignature{testPackFunction("__first.run")}}); err != nil {
+		t.Fatal(err)
+	}
+
+	function_count := len(engine.FunctionNames)
+
+	tests := map[string]*testFunctionPack{
+		"core":           {name: "bad", functions: []*UdnFunctionSignature{testPackFunction("__bad_new"), testPackFunction("__get")}},
+		"(first)":        {name: "bad", functions: []*UdnFunctionSignature{testPackFunction("__first.run")}},
+		"custom":         {name: "bad", functions: []*UdnFunctionSignature{testPackFunction("__custom_login")}},
+		"namespace":      {name: "bad", functions: []*UdnFunctionSignature{testPackFunction("__get.thing")}},
+		"is a namespace": {name: "bad", functions: []*UdnFunctionSignature{testPackFunction("__first")}},
+		"Invalid":        {name: "bad", functions: []*UdnFunctionSignature{testPackFunction("__bad.__nested")}},
+		"twice":          {name: "bad", functions: []*UdnFunctionSignature{testPackFunction("__bad_new"), testPackFunction("__bad_new")}},
+		"Init":           {name: "bad", functions: []*UdnFunctionSignature{testPackFunction("__bad_new")}, init_err: errors.New("no config")},
+	}
+
+	for expected, pack := range tests {
+		err := engine.RegisterFunctionPack(pack)
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("%s: Unexpected error: %v", expected, err)
+		}
+
+		// Nothing from a failed pack is registered
+		if len(engine.FunctionNames) != function_count || engine.Functions["__bad_new"] != nil || engine.FunctionPacks["bad"] != nil {
+			t.Errorf("%s: Failed pack was registered", expected)
+		}
+	}
+}
+
+// Namespaces belong to the engine that registered them, so another engine's pack cant change how this engine parses its functions
+func TestFunctionPackNamespaceEngines(t *testing.T) {
+	engine_a := NewEngine()
+	engine_b := NewEngine()
+
+	if err := engine_a.RegisterFunctionPack(&testFunctionPack{name: "a", functions: []*UdnFunctionSignature{testPackFunction("__zzfoo")}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine_b.RegisterFunctionPack(&testFunctionPack{name: "b", functions: []*UdnFunctionSignature{testPackFunction("__zzfoo.bar")}}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		engine   *Engine
+		udn      string
+		expected string
+	}{
+		{engine_a, "__zzfoo.x", "__zzfoo[x]"},
+		{engine_a, "__zzfoo.bar.x", "__zzfoo[bar x]"},
+		{engine_b, "__zzfoo.bar.x", "__zzfoo.bar[x]"},
+	}
+
+	for _, test := range tests {
+		udn_schema := test.engine.NewUdnSchema()
+		if result := test.engine.ProcessSingleUDNTarget(nil, udn_schema, test.udn, nil, map[string]interface{}{}); fmt.Sprint(result) != test.expected {
+			t.Errorf("%s: Expected %v, got %v  Error: %v", test.udn, test.expected, result, GetUdnError(udn_schema))
+		}
+	}
+}
+
+func TestFunctionPackCustom(t *testing.T) {
+	engine := NewEngine()
+
+	if engine.FunctionPacks["custom"] == nil || engine.Functions["__custom_login"] == nil {
+		t.Fatalf("Custom functions are not registered by default: %v", engine.FunctionPackNames())
+	}
+
+	if err := engine.UnregisterFunctionPack("custom"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range engine.FunctionNames {
+		if engine.FunctionSignatures[name].Group == "custom" {
+			t.Errorf("Custom function is still registered: %s", name)
+		}
+	}
+
+	// Only this engine's were unregistered
+	if UdnFunctions["__custom_login"] == nil {
+		t.Errorf("Custom functions were unregistered from the default engine")
+	}
+
+	if err := engine.RegisterFunctionPack(UdnCustomFunctionPack{}); err != nil || engine.Functions["__custom_login"] == nil {
+		t.Errorf("Custom functions were not registered again: %v", err)
+	}
+}
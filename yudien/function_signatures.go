@@ -0,0 +1,2406 @@
+package yudien
+
+// All the core UDN functions, registered by NewEngine.  docs/yudien_functions.md is generated from these (and the registered function packs) with cmd/udn-docs, so edit the docs here.
+func _UdnCoreFunctionSignatures() []*UdnFunctionSignature {
+	return []*UdnFunctionSignature{
+		// Data Access
+		{
+			Name:     "__get",
+			Title:    "Get Global Data",
+			Group:    "data_access",
+			Function: UDN_Get,
+			KeyArgs:  true,
+			Input:    "Ignored",
+			Args: []UdnArgSignature{
+				{Name: "location", Type: "string", Description: "If quoted, this can contain dots, of each arg will become part of a \"dotted string\" to access the global data"},
+				{Name: "location_parts", Type: "string", Description: "Any number of args can be provided, all strings", Optional: true, Variadic: true},
+			},
+			Output:     "list of maps :: []map[string]interface",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.Testing123.__set.temp.testing.__get.temp.testing", Result: "Testing123"},
+				{Description: "Alternate Example, single dotted string uses the same Global Data:", Udn: "__input.Testing123.__set.temp.testing.__get.'temp.testing'"},
+			},
+			Related: []string{"__set"},
+		},
+		{
+			Name:     "__set",
+			Title:    "Set Global Data",
+			Group:    "data_access",
+			Function: UDN_Set,
+			KeyArgs:  true,
+			Input:    "Ignored",
+			Args: []UdnArgSignature{
+				{Name: "location", Type: "string", Description: "If quoted, this can contain dots, of each arg will become part of a \"dotted string\" to access the global data"},
+				{Name: "location_parts", Type: "string", Description: "Any number of args can be provided, all strings", Optional: true, Variadic: true},
+				{Name: "value", Type: "Any", Description: "The final data can be any value, and is set into the location"},
+			},
+			Output:     "list of maps :: []map[string]interface",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.Testing123.__set.temp.testing.__get.temp.testing", Result: "Testing123"},
+				{Description: "Alternate Example, single dotted string uses the same Global Data:", Udn: "__input.Testing123.__set.'temp.testing'.__get.temp.testing"},
+			},
+			Related: []string{"__get"},
+		},
+		{
+			Name:        "__get_index",
+			Title:       "Get Input Data",
+			Group:       "data_access",
+			Function:    UDN_GetIndex,
+			KeyArgs:     true,
+			Description: "Note: similar to __get, however the global udn_data is not used. Data comes directly from input",
+			Input:       "Any",
+			Args: []UdnArgSignature{
+				{Name: "location", Type: "string", Description: "If quoted, this can contain dots, of each arg will become part of a \"dotted string\" to access the input data"},
+				{Name: "location_parts", Type: "string", Description: "Any number of args can be provided, all strings", Optional: true, Variadic: true},
+			},
+			Output:     "Any depending on what is specified",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.{key1=value1}.__get_index.key1", Result: "value1"},
+				{Description: "Alternate Example:", Udn: "__input.[{key1=value1}, {key2=value2}].__get_index.1.key2", Result: "value2"},
+			},
+			Related: []string{"__set_index"},
+		},
+		{
+			Name:        "__set_index",
+			Title:       "Set Input Data",
+			Group:       "data_access",
+			Function:    UDN_SetIndex,
+			Description: "Note: similar to __set, however data is not stored in the global udn_data. Output is directly piped out to result and not stored. Data comes directly from input and is modified and piped out.",
+			Input:       "Any",
+			Args: []UdnArgSignature{
+				{Name: "location", Type: "string", Description: "If quoted, this can contain dots, of each arg will become part of a \"dotted string\" to access the input data"},
+				{Name: "value", Type: "string", Description: "The last argument is the value that is set to the specified location in the input string"},
+			},
+			Output:     "The updated input string with the specified updated value",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.{key1=value1}.__set_index.key1.value2", Result: "{key1: value2}"},
+				{Description: "Alternate Example:", Udn: "__input.[{key1=value1}, {key2=value2}].__set_index.1.key2.value3", Result: "[{key1=value1, key2=value3}]"},
+			},
+			Related: []string{"__get_index"},
+		},
+		{
+			Name:        "__get_first",
+			Title:       "Get first non-nil Global Data",
+			Group:       "data_access",
+			Function:    UDN_GetFirst,
+			Description: "Takes an array of N strings, which are dotted for udn_data accessing.  The first value that isnt nil is returned.  nil is returned if they all are.",
+			Input:       "Ignored",
+			Args: []UdnArgSignature{
+				{Name: "location", Type: "string", Description: "Dotted string ('location.goes.here')"},
+				{Name: "more_locations", Type: "string", Description: "Any number of args can be provided, same as the first argument", Optional: true, Variadic: true},
+			},
+			Output:     "Any",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.'Hello World'.__set.special.field.__get_first.'not.a.real.place'.'special.field'", Result: "Hello World"},
+			},
+		},
+		{
+			Name:        "__get_temp",
+			Title:       "Get Temporary Data",
+			Group:       "data_access",
+			Function:    UDN_GetTemp,
+			KeyArgs:     true,
+			Description: "Just like __get, except uses a portion of the Global Data space behind a UUID for this ProcessSchemaUDNSet() or __function call.  It allows names to be re-used, which they cannot be in the normal Global Data space, as it is global.",
+			Input:       "Ignored",
+			Args: []UdnArgSignature{
+				{Name: "location", Type: "string", Description: "If quoted, this can contain dots, of each arg will become part of a \"dotted string\" to access the global data"},
+				{Name: "location_parts", Type: "string", Description: "Any number of args can be provided, all strings", Optional: true, Variadic: true},
+			},
+			Output:     "Any",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.Testing123.__set_temp.temp.testing.__get_temp.temp.testing", Result: "Testing123"},
+				{Description: "Alternate Example, single dotted string uses the same Global Data:", Udn: "__input.Testing123.__set_temp.'temp.testing'.__get_temp.temp.testing"},
+			},
+			Related: []string{"__set_temp"},
+		},
+		{
+			Name:        "__set_temp",
+			Title:       "Set Global Data",
+			Group:       "data_access",
+			Function:    UDN_SetTemp,
+			KeyArgs:     true,
+			Description: "Just like __set, except uses a portion of the Global Data space behind a UUID for this ProcessSchemaUDNSet() or __function call.  It allows names to be re-used, which they cannot be in the normal Global Data space, as it is global.",
+			Input:       "Ignored",
+			Args: []UdnArgSignature{
+				{Name: "location", Type: "string", Description: "If quoted, this can contain dots, of each arg will become part of a \"dotted string\" to access the global data"},
+				{Name: "location_parts", Type: "string", Description: "Any number of args can be provided, all strings", Optional: true, Variadic: true},
+				{Name: "value", Type: "Any", Description: "The final data can be any value, and is set into the location"},
+			},
+			Output:     "list of maps :: []map[string]interface",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.Testing123.__set_temp.testing.__get_temp.testing", Result: "Testing123"},
+				{Description: "Alternate Example, single dotted string uses the same Global Data:", Udn: "__input.Testing123.__set_'temp.testing'.__get_temp.testing"},
+			},
+			Related: []string{"__get_temp"},
+		},
+		{
+			Name:        "__increment",
+			Title:       "Increment Value",
+			Group:       "data_access",
+			Function:    UDN_Increment,
+			Description: "Given arg[0], increment value by 1. Output incremented value (float64/int64)",
+			Input:       "int (starting value)",
+			Args: []UdnArgSignature{
+				{Name: "amount", Type: "int", Description: "Value to be incremented by, default is 1 if not provided", Optional: true},
+			},
+			Output:     "int - incremented value",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.99.__increment", Result: "100"},
+				{Description: "Alternate Example,", Udn: "__input.99.__increment.2", Result: "101"},
+			},
+			Related: []string{"__decrement"},
+		},
+		{
+			Name:        "__decrement",
+			Title:       "Decrement Value",
+			Group:       "data_access",
+			Function:    UDN_Decrement,
+			Description: "Given arg[0], decrement value by 1. Output decremented value (float64/int64)",
+			Input:       "int",
+			Args: []UdnArgSignature{
+				{Name: "amount", Type: "int", Description: "Value to be decremented by, default is 1 if not provided", Optional: true},
+			},
+			Output:     "int - decremented value",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.100.__decrement", Result: "99"},
+				{Udn: "__input.100.__decrement.2", Result: "98"},
+			},
+			Related: []string{"__increment"},
+		},
+		{
+			Name:        "__true",
+			Title:       "True",
+			Group:       "data_access",
+			Function:    UDN_True,
+			Description: "Returns `true` value",
+			Input:       "None",
+			Output:      "boolean - `true`",
+			SideEffect:  "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__true", Result: "true"},
+			},
+			Related:  []string{"__false"},
+			ArgCount: &UdnArgCount{0, 0},
+		},
+		{
+			Name:        "__false",
+			Title:       "False",
+			Group:       "data_access",
+			Function:    UDN_False,
+			Description: "Returns `false` value",
+			Input:       "None",
+			Output:      "boolean - `false`",
+			SideEffect:  "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__false", Result: "false"},
+			},
+			Related:  []string{"__true"},
+			ArgCount: &UdnArgCount{0, 0},
+		},
+		{
+			Name:        "__length",
+			Title:       "Length or Size of input",
+			Group:       "data_access",
+			Function:    UDN_Length,
+			Description: "Returns the length or size of the input.  Only valid for arrays, maps and strings.  Otherwise returns 1.",
+			Input:       "array/map/string",
+			Args: []UdnArgSignature{
+				{Name: "value", Type: "array/map/string", Description: "Overrides the input, so this is counted for length instead", Optional: true},
+			},
+			Output:     "int - Length or size of the countable input",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.1234.__length", Result: "4"},
+			},
+		},
+		{
+			Name:        "__nil",
+			Title:       "Nil",
+			Group:       "data_access",
+			Function:    UDN_Nil,
+			Description: "Returns nil",
+			ArgCount:    &UdnArgCount{0, 0},
+		},
+		{
+			Name:        "__get_temp_key",
+			Title:       "Get Temp Key",
+			Group:       "data_access",
+			Function:    UDN_GetTempKey,
+			Description: "Get the uuid of the current stack frame for temp variables",
+		},
+		{
+			Name:        "__temp_clear",
+			Title:       "Clear Temp Data",
+			Group:       "data_access",
+			Function:    UDN_ClearTemp,
+			Description: "Clears the temp data of the current stack frame (__get_temp/__set_temp).  If keys are given, only they are removed.",
+			Input:       "Any",
+			Args: []UdnArgSignature{
+				{Name: "keys", Type: "string", Description: "Temp data keys to remove.  Without keys, all the temp data is removed", Optional: true, Variadic: true},
+			},
+			Output:     "Pass Through Input",
+			SideEffect: "Removes temp data",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.1.__set_temp.a.__input.2.__set_temp.b.__temp_clear.a.__get_temp.b", Result: "2"},
+			},
+			Related: []string{"__get_temp", "__set_temp"},
+		},
+		{
+			Name:        "__let",
+			Title:       "Set Variable",
+			Group:       "data_access",
+			Function:    UDN_Let,
+			KeyArgs:     true,
+			Description: "Sets the input into a variable in the current block, like __set does into Global Data.  Each __iterate and __while loop and each __if block has its own variables, which are removed when it finishes.  A stored function or __call starts with no variables, it cannot see the caller's.",
+			Input:       "Any",
+			Args: []UdnArgSignature{
+				{Name: "variable", Type: "string", Description: "Variable name.  If quoted, this can contain dots, like __set"},
+				{Name: "location_parts", Type: "string", Description: "Any number of args can be provided, to set into a map in the variable", Optional: true, Variadic: true},
+			},
+			Output:     "Pass Through Input",
+			SideEffect: "Sets the variable in the current block",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.[1,2,3].__iterate.__let.item.__math.multiply.(__var.item).10.__end_iterate", Result: "[10, 20, 30]"},
+			},
+			Related: []string{"__var", "__set_temp"},
+		},
+		{
+			Name:        "__var",
+			Title:       "Get Variable",
+			Group:       "data_access",
+			Function:    UDN_Var,
+			KeyArgs:     true,
+			Description: "Gets a variable set with __let.  Blocks can read the variables of the blocks they are in, the innermost one is used.  Variables that are not set are nil.",
+			Input:       "Ignored",
+			Args: []UdnArgSignature{
+				{Name: "variable", Type: "string", Description: "Variable name"},
+				{Name: "location_parts", Type: "string", Description: "Any number of args can be provided, to get from a map in the variable", Optional: true, Variadic: true},
+			},
+			Output:     "Any",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.10.__let.total.__if.1.__var.total.__end_if", Result: "10"},
+			},
+			Related: []string{"__let", "__get_temp"},
+		},
+
+		// Database
+		{
+			Name:        "__data_get",
+			Title:       "Dataman Get",
+			Group:       "database",
+			Function:    UDN_DataGet,
+			Description: "Just like __set, except uses a portion of the Global Data space behind a UUID for this ProcessSchemaUDNSet() or __function call.  It allows names to be re-used, which they cannot be in the normal Global Data space, as it is global.",
+			Input:       "Ignored",
+			Args: []UdnArgSignature{
+				{Name: "table", Type: "string", Description: "Table/Collection name"},
+				{Name: "record_id", Type: "int", Description: "Record ID.  Primary key."},
+				{Name: "options", Type: "options", Description: "Options.  Example: {\"db\": \"eventsum\"}"},
+			},
+			Output:     "Map :: map[string]interface",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__data_get.web_widget_type.1", Result: "{_id: 1, name: \"Base Page\"}"},
+			},
+			Related: []string{"__data_set", "__data_filter", "__query"},
+		},
+		{
+			Name:        "__data_set",
+			Title:       "Dataman Set",
+			Group:       "database",
+			Function:    UDN_DataSet,
+			Writes:      true,
+			Description: "Just like __set, except uses a portion of the Global Data space behind a UUID for this ProcessSchemaUDNSet() or __function call.  It allows names to be re-used, which they cannot be in the normal Global Data space, as it is global.",
+			Input:       "Ignored",
+			Args: []UdnArgSignature{
+				{Name: "table", Type: "string", Description: "Table/Collection name"},
+				{Name: "record", Type: "map", Description: "Record field data to put back in"},
+				{Name: "options", Type: "options", Description: "Options.  Example: {\"db\": \"eventsum\"}"},
+			},
+			Output:     "Map :: map[string]interface",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__data_set.web_widget_type.{_id=1,name='Base Page'}", Result: "{_id: 1, name: \"Base Page\"}"},
+			},
+			Related:  []string{"__data_get", "__data_filter"},
+			ArgCount: &UdnArgCount{2, -1},
+		},
+		{
+			Name:     "__data_filter",
+			Title:    "Dataman Filter",
+			Group:    "database",
+			Function: UDN_DataFilter,
+			Description: `Just like __set, except uses a portion of the Global Data space behind a UUID for this ProcessSchemaUDNSet() or __function call.  It allows names to be re-used, which they cannot be in the normal Global Data space, as it is global.
+
+* Note that it is necessary to create a list first to adhere to the dataman requirements`,
+			Input: "Ignored",
+			Args: []UdnArgSignature{
+				{Name: "table", Type: "string", Description: "If quoted, this can contain dots, of each arg will become part of a \"dotted string\" to access the global data"},
+				{Name: "filter", Type: "string", Description: "Any number of args can be provided, all strings", Optional: true, Variadic: true},
+				{Name: "value", Type: "Any", Description: "The final data can be any value, and is set into the location"},
+				{Name: "options", Type: "options", Description: "Options.  Example: {\"db\": \"eventsum\"}"},
+			},
+			Output:     "list of maps :: []map[string]interface",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__data_filter.web_widget_type.{name=(__input.['=', 'Base Page'])}", Result: "[{_id: 1, name: \"Base Page\"}]"},
+			},
+			Related: []string{"__data_get", "__data_set"},
+		},
+		{
+			Name:        "__query",
+			Title:       "Stored SQL Querying",
+			Group:       "database",
+			Function:    UDN_QueryById,
+			Writes:      true,
+			Description: "*PARTIALLY DEPRICATED:* Only use `__query` when `__data_get` and `__data_filter` absolutely wont work.  Dataman makes working with data much more consistent and also takes care of integrite problems.  Especially only use Dataman for writing data, as there are additional constraints.",
+			Input:       "Ignored",
+			Args: []UdnArgSignature{
+				{Name: "query_id", Type: "int", Description: "datasource_query.id record primary key"},
+				{Name: "query_args", Type: "map", Description: "data arguments for the query, are short templated into the stored SQL", Optional: true},
+			},
+			Output:     "list of maps :: []map[string]interface",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__query.25"},
+			},
+			Related: []string{"__data_get", "__data_filter"},
+		},
+		{
+			Name:        "__change_get",
+			Title:       "Change Get",
+			Group:       "database",
+			Function:    UDN_DataGet,
+			Description: "Dataman Get.  Will become the default, using change management.",
+		},
+		{
+			Name:        "__change_set",
+			Title:       "Change Set",
+			Group:       "database",
+			Function:    UDN_DataSet,
+			Writes:      true,
+			Description: "Dataman Set.  Will become the default, using change management.",
+			Args: []UdnArgSignature{
+				{Name: "table", Type: "string", Description: "Table/Collection name"},
+				{Name: "record", Type: "map", Description: "Record field data to put back in"},
+				{Name: "options", Type: "options", Description: "Options.  Example: {\"db\": \"eventsum\"}", Optional: true},
+			},
+			ArgCount: &UdnArgCount{2, -1},
+		},
+		{
+			Name:        "__change_submit",
+			Title:       "Change Submit",
+			Group:       "database",
+			Function:    UDN_ChangeDataSubmit,
+			Writes:      true,
+			Description: "This accepts dotted notation and figures out what records/fields are being effected.  Example:  {\"opsdb.schema_table_field.1050.name\":\"_id\"}",
+		},
+		{
+			Name:        "__change_filter",
+			Title:       "Change Filter",
+			Group:       "database",
+			Function:    UDN_DataFilter,
+			Description: "Dataman Filter.  Will become the default, using change management.",
+		},
+		{
+			Name:        "__change_filter_full",
+			Title:       "Change Filter Full",
+			Group:       "database",
+			Function:    UDN_DataFilterFull,
+			Description: "Updated version of DatamanFilter that takes in JSON and allows multi-constraints",
+		},
+		{
+			Name:        "__safe_data_get",
+			Title:       "Safe Data Get",
+			Group:       "database",
+			Function:    UDN_SafeDataGet,
+			Description: "Safe Dataman Get - Always connects to the correct database, and checks to ensure that",
+		},
+		{
+			Name:        "__safe_data_filter",
+			Title:       "Safe Data Filter",
+			Group:       "database",
+			Function:    UDN_SafeDataFilter,
+			Description: "Safe Dataman Filter - Correct DB and added filter args guarantee restricted access",
+		},
+		{
+			Name:        "__safe_data_filter_full",
+			Title:       "Safe Data Filter Full",
+			Group:       "database",
+			Function:    UDN_SafeDataFilterFull,
+			Description: "Safe Updated version of DatamanFilter that takes in JSON and allows multi-constraints",
+		},
+		{
+			Name:        "__data_filter_full",
+			Title:       "Data Filter Full",
+			Group:       "database",
+			Function:    UDN_DataFilterFull,
+			Description: "Updated version of DatamanFilter that takes in JSON and allows multi-constraints",
+		},
+		{
+			Name:        "__data_delete",
+			Title:       "Data Delete",
+			Group:       "database",
+			Function:    UDN_DataDelete,
+			Writes:      true,
+			Description: "Dataman Delete",
+		},
+		{
+			Name:        "__data_delete_filter",
+			Title:       "Data Delete Filter",
+			Group:       "database",
+			Function:    UDN_DataDeleteFilter,
+			Writes:      true,
+			Description: "Dataman Delete Filter",
+		},
+		{
+			Name:        "__data_tombstone",
+			Title:       "Data Tombstone",
+			Group:       "database",
+			Function:    UDN_DataTombstone,
+			Writes:      true,
+			Description: "Dataman \"Delete\" with a Tombstone marker: _is_deleted=true",
+			Args: []UdnArgSignature{
+				{Name: "record_label", Type: "string", Description: "Record label: database.table.record_id"},
+			},
+			ArgCount: &UdnArgCount{1, -1},
+		},
+		{
+			Name:        "__data_field_map_delete",
+			Title:       "Data Field Map Delete",
+			Group:       "database",
+			Function:    UDN_DataFieldMapDelete,
+			Writes:      true,
+			Description: "Data field map delete - Go into JSON data and delete things",
+			Args: []UdnArgSignature{
+				{Name: "field_label", Type: "string", Description: "Field label of the JSON field to delete in"},
+			},
+			ArgCount: &UdnArgCount{1, -1},
+		},
+
+		// Conditions and Looping
+		{
+			Name:     "__if",
+			Title:    "Conditional If",
+			Group:    "looping",
+			Function: UDN_IfCondition,
+			Input:    "Any",
+			Args: []UdnArgSignature{
+				{Name: "condition", Type: "Any", Description: "Converted to a boolean.  The block runs if it is true"},
+			},
+			Output:     "Last Output Function Result",
+			SideEffect: "Loops over all functions in the block (between __if and matching __end_if)",
+			Examples: []UdnFunctionExample{
+				{Udn: "__if.1.__debug_output.__end_if"},
+			},
+			Related:  []string{"__else_if"},
+			BlockEnd: "__end_if",
+			ArgCount: &UdnArgCount{1, 1},
+		},
+		{
+			Name:     "__else_if",
+			Title:    "Conditional Else, If",
+			Group:    "looping",
+			Function: UDN_ElseIfCondition,
+			Input:    "Any",
+			Args: []UdnArgSignature{
+				{Name: "condition", Type: "Any", Description: "Converted to a boolean.  The block runs if it is true, and no earlier __if or __else_if block ran"},
+			},
+			Output:     "Last Output Function Result",
+			SideEffect: "Loops over all functions in the block (between __else_if and matching __end_if or next __else_if)",
+			Examples: []UdnFunctionExample{
+				{Udn: "__if.0.__debug_output.__else_if.__debug_output.__end_if"},
+			},
+			ArgCount: &UdnArgCount{1, 1},
+		},
+		{
+			Name:       "__end_if",
+			Title:      "End If/ElseIf Block",
+			Group:      "looping",
+			Function:   nil,
+			Input:      "Any",
+			Output:     "Last Output Function Result",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__if.1.__debug_output.__end_if"},
+			},
+			Related:    []string{"__if"},
+			BlockBegin: "__if",
+			ArgCount:   &UdnArgCount{0, 0},
+		},
+		{
+			Name:        "__not",
+			Title:       "Not - Reverses boolean test (1, \"1\", true)",
+			Group:       "looping",
+			Function:    UDN_Not,
+			Description: "- Boolean, String, Integer: true, false, \"1\", \"0\", 1, 0",
+			Input:       "Boolean value: true, 1, \"1\", false, 0, \"0\"",
+			Output:      "Boolean: \"1\", \"0\"",
+			SideEffect:  "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__if.(__not.0).__debug_output.__end_if"},
+			},
+			Related: []string{"__not_nil", "__if"},
+		},
+		{
+			Name:       "__not_nil",
+			Title:      "Not Nil - Returns \"1\" (true) if not nil",
+			Group:      "looping",
+			Function:   UDN_NotNil,
+			Input:      "nil or Not",
+			Output:     "Boolean: \"1\", \"0\"",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__if.(__get.thing.that.exists.__not_nil).__debug_output.__end_if"},
+			},
+			Related: []string{"__not", "__is_nil", "__if"},
+		},
+		{
+			Name:       "__is_nil",
+			Title:      "Is Nil - Returns \"1\" (true) if is nil",
+			Group:      "looping",
+			Function:   UDN_IsNil,
+			Input:      "nil or Not",
+			Output:     "Boolean: \"1\", \"0\"",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__if.(__get.thing.that.doesnt.exist.__is_nil).__debug_output.__end_if"},
+			},
+			Related: []string{"__not", "__not_nil", "__if"},
+		},
+		{
+			Name:       "__iterate",
+			Title:      "Iterate",
+			Group:      "looping",
+			Function:   UDN_Iterate,
+			Input:      "Any",
+			Output:     "First Element of Array",
+			SideEffect: "Loops over all functions in the block (between __iterate and matching __end_iterate)",
+			Examples: []UdnFunctionExample{
+				{Udn: "__iterate.__debug_output.__end_iterate"},
+			},
+			Related:  []string{"__break", "__continue", "__parallel_iterate"},
+			BlockEnd: "__end_iterate",
+		},
+		{
+			Name:       "__end_iterate",
+			Title:      "End Iterate",
+			Group:      "looping",
+			Function:   nil,
+			Input:      "Any",
+			Output:     "Array of All iterate block runs",
+			SideEffect: "None",
+			Related:    []string{"__iterate"},
+			BlockBegin: "__iterate",
+			ArgCount:   &UdnArgCount{0, 0},
+		},
+		{
+			Name:        "__parallel_iterate",
+			Title:       "Parallel Iterate",
+			Group:       "looping",
+			Function:    UDN_ParallelIterate,
+			Description: "Like __iterate, but executes the block for up to arg_0 items at the same time.  Each worker has its own copy of udn_data (including _iterate_index), and the changes are merged back when all items are done, so items should not set the same data.  Useful for a __http_request or __data_get per item.",
+			Input:       "Array",
+			Args: []UdnArgSignature{
+				{Name: "workers", Type: "Int", Description: "Maximum number of items executed at once.  Debug logging uses 1, so the log is in order."},
+			},
+			Output:     "Array of All block runs, in input order.  Items that had an error are nil.",
+			SideEffect: "Errors dont stop the loop, they are set in udn_data[\"iterate_errors\"] as a list of {index, message, function, function_stack}.  __continue leaves the item out of the results, __break drops its item and all items after it.",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.[1,2,3].__parallel_iterate.2.__math.multiply.(__input).10.__end_parallel_iterate", Result: "[10, 20, 30]"},
+			},
+			Related:  []string{"__iterate", "__break", "__continue"},
+			BlockEnd: "__end_parallel_iterate",
+			ArgCount: &UdnArgCount{1, 1},
+		},
+		{
+			Name:       "__end_parallel_iterate",
+			Title:      "End Parallel Iterate",
+			Group:      "looping",
+			Function:   nil,
+			Input:      "Any",
+			Output:     "Array of All parallel iterate block runs",
+			SideEffect: "None",
+			Related:    []string{"__parallel_iterate"},
+			BlockBegin: "__parallel_iterate",
+			ArgCount:   &UdnArgCount{0, 0},
+		},
+		{
+			Name:        "__while",
+			Title:       "While",
+			Group:       "looping",
+			Function:    UDN_While,
+			Description: "While takes a condition (arg_0) and a max (arg_1:int) number of iterations, so it cannot run forever",
+			Input:       "None",
+			Args: []UdnArgSignature{
+				{Name: "condition", Type: "String", Description: "UDN code that is executed, and then checked like an __if result, which becomes boolean each while loop, breaking the while on a False result"},
+				{Name: "max_count", Type: "Int", Description: "Maximum number of times the while should execute.  Must be positive integer, 0/-1 will never execute.  Ensures it does not infinitely loop."},
+			},
+			Output:     "None",
+			SideEffect: "Loops over all functions in the block (between __while and matching __end_while), as long as the condition is true, up to the maximum number of times (arg 1)",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.10__set_temp_counter.__while.(__not.(__compare.(__get_temp.counter).0)).__math.input.(__get_temp.counter).__decrement.__set_temp.counter.__debug_output.__end_iterate"},
+			},
+			Related:  []string{"__break", "__continue"},
+			BlockEnd: "__end_while",
+			ArgCount: &UdnArgCount{2, 2},
+		},
+		{
+			Name:       "__end_while",
+			Title:      "End While",
+			Group:      "looping",
+			Function:   nil,
+			Input:      "Any",
+			Output:     "Array of All while block runs",
+			SideEffect: "None",
+			Related:    []string{"__while"},
+			BlockBegin: "__while",
+			ArgCount:   &UdnArgCount{0, 0},
+		},
+		{
+			Name:        "__break",
+			Title:       "Break",
+			Group:       "looping",
+			Function:    UDN_Break,
+			Description: "Stops the nearest __iterate or __while loop, from anywhere in its block (including inside __if blocks).  It is an error outside of a loop, and a function (__call, __function) cannot break its caller's loop.",
+			Input:       "Any",
+			Output:      "Pass Through",
+			SideEffect:  "Skips the rest of the loop block, and any remaining loops.  The current loop is not added to the loop's result list, earlier loops are.",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.[1,2,3,4].__iterate.__if.(__compare_equal.(__input).3).__break.__end_if.__end_iterate", Result: "[1, 2]"},
+			},
+			Related:  []string{"__continue", "__iterate", "__while"},
+			ArgCount: &UdnArgCount{0, 0},
+		},
+		{
+			Name:        "__continue",
+			Title:       "Continue",
+			Group:       "looping",
+			Function:    UDN_Continue,
+			Description: "Skips the rest of the current loop of the nearest __iterate or __while, from anywhere in its block (including inside __if blocks), and starts the next loop.  It is an error outside of a loop.",
+			Input:       "Any",
+			Output:      "Pass Through",
+			SideEffect:  "Skips the rest of the loop block.  The current loop is not added to the loop's result list, so __continue filters the items of an __iterate.",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.[1,2,3,4].__iterate.__if.(__compare_equal.(__input).2).__continue.__end_if.__end_iterate", Result: "[1, 3, 4]"},
+			},
+			Related:  []string{"__break", "__iterate", "__while"},
+			ArgCount: &UdnArgCount{0, 0},
+		},
+		{
+			Name:     "__try",
+			Title:    "Try",
+			Group:    "looping",
+			Function: UDN_Try,
+			Description: `Executes the functions in the block.  If any of them return an error, the rest of the block is skipped, and the __catch block is executed instead.  Without an error, the __catch block is skipped.
+
+Errors that are not caught stop the UDN execution, and are returned to the caller of the top level UDN.`,
+			Input:      "Any",
+			Output:     "Output of the last function in the block that was executed (try or catch)",
+			SideEffect: "When an error is caught, it is put in udn_data[\"error\"] as a map:  message (string), function (the function that failed), function_stack (array of maps: function, args, from the function that failed out to the outermost function).  A function that panicked also has: panic (the panic value), source (the UDN statement), udn_part_id, frames (the __function_stack frames), go_stack",
+			Examples: []UdnFunctionExample{
+				{Udn: "__try.__input.{name=test}.__data_set.'test_table'.__catch.__get.error.message.__end_try", Result: "Data Set: test_table: ..."},
+			},
+			Related:  []string{"__catch", "__end_try"},
+			BlockEnd: "__end_try",
+			ArgCount: &UdnArgCount{0, 0},
+		},
+		{
+			Name:        "__catch",
+			Title:       "Catch",
+			Group:       "looping",
+			Function:    nil,
+			Description: "Starts the block of functions executed when the __try block has an error.  The catch block gets the same input as the __try block.  __catch is optional, a __try without one ignores errors.",
+			Input:       "Any",
+			Output:      "Same as Input",
+			SideEffect:  "None",
+			Related:     []string{"__try", "__end_try"},
+			ArgCount:    &UdnArgCount{0, 0},
+		},
+		{
+			Name:       "__end_try",
+			Title:      "End Try",
+			Group:      "looping",
+			Function:   nil,
+			Input:      "Any",
+			Output:     "Output of the try or catch block",
+			SideEffect: "None",
+			Related:    []string{"__try"},
+			BlockBegin: "__try",
+			ArgCount:   &UdnArgCount{0, 0},
+		},
+		{
+			Name:        "__switch",
+			Title:       "Switch",
+			Group:       "looping",
+			Function:    UDN_Switch,
+			Description: "Executes the first __case arm with an arg equal to the input (compared like __compare_equal), or the __default arm if no __case matches.  Each arm runs until the next __case/__default or __end_switch.  Easier to read than a chain of __if/__else_if for mapping values.",
+			Input:       "Any",
+			Output:      "Output of the executed arm, or the input if no arm was executed",
+			SideEffect:  "Executes one arm of the block (between __switch and matching __end_switch)",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.warning.__switch.__case.ok.__input.green.__case.warning.degraded.__input.yellow.__default.__input.red.__end_switch", Result: "yellow"},
+			},
+			Related:  []string{"__case", "__default", "__end_switch", "__if"},
+			BlockEnd: "__end_switch",
+			ArgCount: &UdnArgCount{0, 0},
+		},
+		{
+			Name:        "__case",
+			Title:       "Case",
+			Group:       "looping",
+			Function:    nil,
+			Description: "Starts a __switch arm, which is executed if any of the args are equal to the __switch input.  Args are only processed until an earlier __case matches.  The arm gets the same input as the __switch.",
+			Input:       "Any",
+			Args: []UdnArgSignature{
+				{Name: "value", Type: "Any", Description: "Value to compare against the __switch input", Variadic: true},
+			},
+			Output:     "Same as Input",
+			SideEffect: "None",
+			Related:    []string{"__switch", "__default", "__end_switch"},
+			ArgCount:   &UdnArgCount{1, -1},
+		},
+		{
+			Name:        "__default",
+			Title:       "Default",
+			Group:       "looping",
+			Function:    nil,
+			Description: "Starts the __switch arm that is executed when no __case matches.  It is optional, and there can only be one.",
+			Input:       "Any",
+			Output:      "Same as Input",
+			SideEffect:  "None",
+			Related:     []string{"__switch", "__case", "__end_switch"},
+			ArgCount:    &UdnArgCount{0, 0},
+		},
+		{
+			Name:       "__end_switch",
+			Title:      "End Switch",
+			Group:      "looping",
+			Function:   nil,
+			Input:      "Any",
+			Output:     "Output of the executed arm",
+			SideEffect: "None",
+			Related:    []string{"__switch"},
+			BlockBegin: "__switch",
+			ArgCount:   &UdnArgCount{0, 0},
+		},
+		{
+			Name:        "__compare_equal",
+			Title:       "Conditon to Check for Equality",
+			Group:       "looping",
+			Function:    UDN_CompareEqual,
+			Description: "Compare equality, takes 2 args and compares them.  Returns 1 if true, 0 if false.  For now, avoiding boolean types...",
+			Input:       "Ignored",
+			Args: []UdnArgSignature{
+				{Name: "left", Type: "Any", Description: "Converted to a string for comparison"},
+				{Name: "right", Type: "Any", Description: "Converted to a string for comparison"},
+			},
+			Output:     "Boolean: \"1\", \"0\"",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__if.(__compare_equal.Tom.Jerry).__input.1.__else.__input.0.__end_if", Result: "0"},
+			},
+			Related:  []string{"__compare_not_equal", "__if"},
+			ArgCount: &UdnArgCount{2, 2},
+		},
+		{
+			Name:        "__compare_not_equal",
+			Title:       "Conditon to Check for Non-Equality",
+			Group:       "looping",
+			Function:    UDN_CompareNotEqual,
+			Description: "Compare equality, takes 2 args and compares them.  Returns 1 if true, 0 if false.  For now, avoiding boolean types...",
+			Input:       "Ignored",
+			Args: []UdnArgSignature{
+				{Name: "left", Type: "Any", Description: "Converted to a string for comparison"},
+				{Name: "right", Type: "Any", Description: "Converted to a string for comparison"},
+			},
+			Output:     "Boolean: \"1\", \"0\"",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__if.(__compare_not_equal.Tom.Jerry).__input.1.__else.__input.0.__end_if", Result: "1"},
+			},
+			Related:  []string{"__compare_equal", "__if"},
+			ArgCount: &UdnArgCount{2, 2},
+		},
+		{
+			Name:        "__else",
+			Title:       "Else",
+			Group:       "looping",
+			Function:    UDN_ElseCondition,
+			Description: "Runs its functions if the __if condition (and any __else_if conditions) were false.  Part of an __if block, closed by its __end_if.",
+			ArgCount:    &UdnArgCount{0, 0},
+		},
+		{
+			Name:        "__end_else",
+			Title:       "End Else",
+			Group:       "looping",
+			Function:    nil,
+			Description: "Not used, __else is closed by the __end_if of its block.",
+		},
+		{
+			Name:        "__end_else_if",
+			Title:       "End Else If",
+			Group:       "looping",
+			Function:    nil,
+			Description: "Not used, __else_if is closed by the __end_if of its block.",
+		},
+
+		// Execution Control
+		//TODO(g): This takes any input as the first arg, and then passes it along, so we can type in new input to go down the pipeline...
+		{
+			Name:     "__input",
+			Title:    "Input",
+			Group:    "execution",
+			Function: UDN_Input,
+			Input:    "Any",
+			Args: []UdnArgSignature{
+				{Name: "value", Type: "Any", Description: "This overrides the Input coming into this function", Optional: true},
+			},
+			Output:     "Any.  Passes through Input or Arg[0]",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.Testing123.__set.temp.testing.__get.temp.testing", Result: "Testing123"},
+			},
+		},
+		{
+			Name:        "__input_get",
+			Title:       "Retrieves field from current Input as Map",
+			Group:       "execution",
+			Function:    UDN_InputGet,
+			KeyArgs:     true,
+			Description: "Gets information from the input, accessing it like __get",
+			Input:       "Map ::: map[string]interface",
+			Args: []UdnArgSignature{
+				{Name: "key", Type: "string", Description: "Index of the field for the Input"},
+			},
+			Output:     "Any.  Passes through Input or Arg[0]",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.{name=Bob}.__input_get.name", Result: "Bob"},
+			},
+		},
+		//TODO(g): This uses the udn_stored_function.name as the first argument, and then uses the current input to pass to the function, returning the final result of the function.		Uses the web_site.udn_stored_function_domain_id to determine the stored function
+		{
+			Name:        "__function",
+			Title:       "Calls a UDN Stored Function",
+			Group:       "execution",
+			Function:    UDN_StoredFunction,
+			Description: "This uses the udn_stored_function.name as the first argument, and then uses the current input to pass to the function, returning the final result of the function.\t\tUses the web_site.udn_stored_function_domain_id to determine the stored function",
+			Input:       "Any",
+			Args: []UdnArgSignature{
+				{Name: "function_name", Type: "string", Description: "Index of the field for the Input"},
+				{Name: "function_args", Type: "Any (options, variadic)", Description: "Any arguments from this point are stored as an Array in the Global Data location \"function_arg\"", Variadic: true},
+			},
+			Output:     "Any",
+			SideEffect: "Any",
+			Examples: []UdnFunctionExample{
+				{Udn: "__function.test_function.arg0.arg1.arg2", Result: "Anything!!!"},
+			},
+			Related:  []string{"__execute"},
+			ArgCount: &UdnArgCount{1, -1},
+		},
+		{
+			Name:        "__execute",
+			Title:       "Execute UDN from String",
+			Group:       "execution",
+			Function:    UDN_Execute,
+			Description: "Execute a single UDN string.  Combines the 2-tuple normally used to a single string.  Also removes the concurrency blocks, making it a single string and not a next JSON array of 2-tuple strings.",
+			Input:       "Ignored",
+			Args: []UdnArgSignature{
+				{Name: "udn", Type: "string", Description: "UDN code in a single string (Source/Target not separated)"},
+			},
+			Output:     "Any",
+			SideEffect: "Any",
+			Examples: []UdnFunctionExample{
+				{Udn: "__execute.'__input.Testing123'", Result: "Testing123"},
+			},
+			Related: []string{"__function", "__validate"},
+		},
+		{
+			Name:        "__validate",
+			Title:       "Validate UDN without Executing",
+			Group:       "execution",
+			Function:    UDN_Validate,
+			Description: "Checks UDN against the registered functions, without executing it.  Takes a single UDN string, or the JSON array of UDN statements used by stored functions and widget data.  Reports unknown functions, blocks without a matching __end_* (or __end_* without a block), wrong argument counts, and deprecated function names.  The same checks are available from the command line with cmd/udn-validate.",
+			Input:       "string :: UDN to validate, if no args are given",
+			Args: []UdnArgSignature{
+				{Name: "udn", Type: "string", Description: "UDN to validate (optional, overrides input)"},
+			},
+			Output:     "Array of Maps :: []interface{} of {severity, statement, function, message}.  Empty if there are no issues.",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__validate.'[[[\"__input.1.__end_if\"]]]'", Result: "[{\"function\": \"__end_if\", \"message\": \"no matching __if to close\", \"severity\": \"error\", \"statement\": \"__input.1.__end_if\"}]"},
+			},
+			Related: []string{"__execute"},
+		},
+		{
+			Name:        "__help",
+			Title:       "Help",
+			Group:       "execution",
+			Function:    UDN_Help,
+			Description: "Returns the signature of a function: its args, input, output, examples and which Go function implements it.  With no function name, returns the sorted list of all the function names.",
+			Input:       "String :: Function name, if arg_0 is not given",
+			Args: []UdnArgSignature{
+				{Name: "function_name", Type: "string", Description: "Function to describe", Optional: true},
+			},
+			Output:     "Map of the function signature, or Array of function names",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__help.'__group_by'.__get_index.args.0.name", Result: "method"},
+			},
+			Related: []string{"__validate"},
+		},
+		{
+			Name:        "__define",
+			Title:       "Define Function",
+			Group:       "execution",
+			Function:    UDN_Define,
+			Description: "Defines a function from the block until __end_define, which is run with __call.  The block is not executed when it is defined.  Definitions last for the request, so a function defined in one stored function can be called by the UDN executed after it.  Defining a name again replaces it.",
+			Input:       "Any",
+			Args: []UdnArgSignature{
+				{Name: "function_name", Type: "string", Description: "Name to call the function with"},
+				{Name: "params", Type: "string", Description: "Parameter names.  __call sets its args into these temp data names, so they are accessed with __get_temp", Optional: true, Variadic: true},
+			},
+			Output:     "Pass Through Input",
+			SideEffect: "Defines the function for the rest of the request",
+			Examples: []UdnFunctionExample{
+				{Udn: "__define.double.x.__math.multiply.(__get_temp.x).2.__end_define.__call.double.21", Result: "42"},
+				{Description: "Functions can call themselves:", Udn: "__define.factorial.n.__if.(__compare_equal.(__get_temp.n).0).__input.1.__else.__math.multiply.(__get_temp.n).(__call.factorial.(__math.subtract.(__get_temp.n).1)).__end_if.__end_define.__call.factorial.5", Result: "120"},
+			},
+			Related:  []string{"__call"},
+			BlockEnd: "__end_define",
+			ArgCount: &UdnArgCount{1, -1},
+		},
+		{
+			Name:       "__end_define",
+			Title:      "End Define",
+			Group:      "execution",
+			Input:      "Any",
+			Output:     "Pass Through Input",
+			Related:    []string{"__define"},
+			BlockBegin: "__define",
+			ArgCount:   &UdnArgCount{0, 0},
+		},
+		{
+			Name:        "__call",
+			Title:       "Call Function",
+			Group:       "execution",
+			Function:    UDN_Call,
+			Description: "Calls a function defined with __define.  The call has its own temp data (__get_temp/__set_temp), which starts with the parameters set to the args, and is removed when the function returns.  Nested and recursive calls each get their own.  Missing args are nil.",
+			Input:       "Any :: Input to the function body",
+			Args: []UdnArgSignature{
+				{Name: "function_name", Type: "string", Description: "Function defined with __define"},
+				{Name: "args", Type: "Any", Description: "Set into the function's parameters, in order", Optional: true, Variadic: true},
+			},
+			Output:     "Output of the last function in the body",
+			SideEffect: "Any",
+			Examples: []UdnFunctionExample{
+				{Udn: "__define.add.a.b.__math.add.(__get_temp.a).(__get_temp.b).__end_define.__call.add.2.3", Result: "5"},
+			},
+			Related:  []string{"__define", "__function"},
+			ArgCount: &UdnArgCount{1, -1},
+		},
+
+		// Text
+		{
+			Name:        "__template",
+			Title:       "String Template From Value",
+			Group:       "text",
+			Function:    UDN_StringTemplateFromValue,
+			Description: "Does a __get from the args...",
+			Input:       "Map :: map[string]interface{}",
+			Args: []UdnArgSignature{
+				{Name: "text", Type: "string", Description: "Text to be templated, using Go's text/template function"},
+				{Name: "data", Type: "Map", Description: "Overrides the Input map value, if present", Optional: true},
+			},
+			Output:     "string",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.{name=\"Bob\"}.__template.'Name: {{index .Map \"name\"}}'", Result: "\"Name: Bob\""},
+			},
+			Related: []string{"__template_wrap", "__template_short", "__format", "__template_map"},
+		},
+		{
+			Name:     "__template_wrap",
+			Title:    "String Template From Value",
+			Group:    "text",
+			Function: UDN_StringTemplateMultiWrap,
+			Description: `Takes N-2 tuple args, after 0th arg, which is the wrap_key, (also supports a single arg templating, like __template, but not the main purpose).  For each N-Tuple, the new map data gets "value" set by the previous output of the last template, creating a rolling "wrap" function.
+
+NOTE(g): I dont know how this function is used at this point.  It was useful, but I dont see an example to explain it.  It's extremely overloaded, but powerful.`,
+			Input: "Map :: map[string]interface{}",
+			Args: []UdnArgSignature{
+				{Name: "text", Type: "string", Description: "Text to be templated, using Go's text/template function"},
+				{Name: "data", Type: "Map", Description: "Overrides the Input map value, if present", Optional: true},
+			},
+			Output:     "string",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.{name=Bob,job=Programmer}.__template_wrap.'Name: {{index .Map \"name\"}}'.{name=Bob}.'Job: {{index .Map \"job\"}}'.{job=Programmer}", Result: "\"Name: Bob\""},
+			},
+			Related: []string{"__template", "__template_short", "__format", "__template_map"},
+		},
+		//TODO(g): Like format, for templating.  Takes 3*N args: (key,text,map), any number of times.  Performs template and assigns key into the input map
+		{
+			Name:        "__template_map",
+			Title:       "String Template From Value",
+			Group:       "text",
+			Function:    UDN_MapTemplate,
+			Description: "Like format, for templating.  Takes 3*N **Args:** (key,text,map), any number of times.  Performs template and assigns key into the input map",
+			Input:       "Ignored",
+			Args: []UdnArgSignature{
+				{Name: "set_key", Type: "String", Description: "Set key.  This is where we will set the value once templated."},
+				{Name: "text", Type: "String", Description: "Template text.  This is the text to be templated."},
+				{Name: "data", Type: "Map", Description: "This is the data to be templated into the 2nd arg."},
+			},
+			Output:     "Passed Through Input",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__template_map.'location.saved'.'Name: {{index .Map \"name\"}}'.{name=Bob}.__get.location.saved", Result: "\"Name: Bob\""},
+			},
+			Related: []string{"__template_wrap", "__template_short", "__format", "__template"},
+		},
+		//TODO(g): Updates a map with keys and string formats.  Uses the map to format the strings.  Takes N args, doing each arg in sequence, for order control
+		{
+			Name:        "__format",
+			Title:       "Format Strings from Map",
+			Group:       "text",
+			Function:    UDN_MapStringFormat,
+			Description: "Updates a map with keys and string formats.  Uses the map to format the strings.  Takes N args, doing each arg in sequence, for order control",
+			Input:       "Map :: map[string]interface",
+			Args: []UdnArgSignature{
+				{Name: "set_key", Type: "String", Description: "Set key.  This is where we will set the value once templated."},
+				{Name: "format", Type: "String", Description: "Format string.  This is the data to be templated into the 0th arg location."},
+				{Name: "more_set_keys", Type: "String", Description: "Set key. Indefinite pairs of String/Map args", Optional: true, Variadic: true},
+				{Name: "more_formats", Type: "Map", Description: "Format string.  Indefinite pairs of String/Map args", Optional: true, Variadic: true},
+			},
+			Output:     "Passed Through Input",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.{name=Bob,job=Programmer}.__format.'location.saved.name'.'Name: {index .Map \"name\"}'.'location.saved.job'.'Job: {index .Map \"job\"}.__get.location.saved.name'", Result: "\"Name: Bob\""},
+			},
+			Related: []string{"__template_wrap", "__template_short", "__template"},
+		},
+		{
+			Name:        "__template_short",
+			Title:       "String Template From Value",
+			Group:       "text",
+			Function:    UDN_StringTemplateFromValueShort,
+			Description: "Like __template, but uses {{{name}} instead of {index .Map \"name\"}",
+			Input:       "Map :: map[string]interface",
+			Args: []UdnArgSignature{
+				{Name: "text", Type: "String", Description: "Set key.  This is where we will set the value once templated."},
+				{Name: "data", Type: "Map", Description: "This overrides the Input, if present", Optional: true},
+			},
+			Output:     "String",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.{name=Bob,job=Programmer}.__template_short.'Name: {{{name}}}'", Result: "\"Name: Bob\""},
+			},
+			Related: []string{"__template_wrap", "__format", "__template"},
+		},
+		{
+			Name:        "__string_append",
+			Title:       "String Append",
+			Group:       "text",
+			Function:    UDN_StringAppend,
+			Description: "Appends to an existing string, or creates a string if nil (not present in Global Data).  Args work like __get",
+			Input:       "String",
+			Args: []UdnArgSignature{
+				{Name: "location", Type: "string", Description: "If quoted, this can contain dots, of each arg will become part of a \"dotted string\" to access the global data"},
+				{Name: "location_parts", Type: "string", Description: "Any number of args can be provided, all strings", Optional: true, Variadic: true},
+			},
+			Output:     "String",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.'The Quick '.__set.temp.test.__input.'Brown Fox'.__string_append.temp.test.__get.temp.test", Result: "\"The Quick Brown Fox\""},
+			},
+			Related: []string{"__string_clear", "__concat"},
+		},
+		{
+			Name:        "__string_clear",
+			Title:       "String Clear",
+			Group:       "text",
+			Function:    UDN_StringClear,
+			Description: "This is only needed when re-using a Global Data label, you can start appending to an non-existent location and it will start it with an empty string.",
+			Input:       "String",
+			Args: []UdnArgSignature{
+				{Name: "location", Type: "string", Description: "If quoted, this can contain dots, of each arg will become part of a \"dotted string\" to access the global data"},
+				{Name: "location_parts", Type: "string", Description: "Any number of args can be provided, all strings", Optional: true, Variadic: true},
+			},
+			Output:     "String",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__string_clear.temp.test"},
+			},
+			Related:  []string{"__string_append"},
+			ArgCount: &UdnArgCount{1, -1},
+		},
+		{
+			Name:        "__concat",
+			Title:       "String Concatenate",
+			Group:       "text",
+			Function:    UDN_StringConcat,
+			Description: "TODO(g): Not Yet Implemented",
+			Input:       "String",
+			Args: []UdnArgSignature{
+				{Name: "location", Type: "string", Description: "If quoted, this can contain dots, of each arg will become part of a \"dotted string\" to access the global data"},
+				{Name: "location_parts", Type: "string", Description: "Any number of args can be provided, all strings", Optional: true, Variadic: true},
+			},
+			Output:     "String",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: ""},
+			},
+			Related: []string{"__string_clear", "__string_append"},
+		},
+		{
+			Name:        "__upper",
+			Title:       "String Uppercase",
+			Group:       "text",
+			Function:    UDN_StringUpper,
+			Description: "Upper case a string",
+			Input:       "String",
+			Args: []UdnArgSignature{
+				{Name: "value", Type: "string", Description: "string that will be set to uppercase"},
+			},
+			Output:     "String (upper case)",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "\"__upper.hElLo\"", Result: "HELLO"},
+			},
+			Related:  []string{"__lower"},
+			ArgCount: &UdnArgCount{1, -1},
+		},
+		{
+			Name:        "__lower",
+			Title:       "String Lowercase",
+			Group:       "text",
+			Function:    UDN_StringLower,
+			Description: "Lower case a string",
+			Input:       "String",
+			Args: []UdnArgSignature{
+				{Name: "value", Type: "string", Description: "string that will be set to lowercase"},
+			},
+			Output:     "String (lower case)",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "\"__lower.hElLo\"", Result: "hello"},
+			},
+			Related:  []string{"__upper"},
+			ArgCount: &UdnArgCount{1, -1},
+		},
+		{
+			Name:        "__split",
+			Title:       "String Split",
+			Group:       "text",
+			Function:    UDN_StringSplit,
+			Description: "Split a string",
+			Input:       "String that will be split",
+			Args: []UdnArgSignature{
+				{Name: "separator", Type: "string", Description: "string that is used as the separator"},
+			},
+			Output:     "List (of strings)",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "\"__input.'hello.world.how.are.you'.__split.'.'\"", Result: "[hello, world, how, are, you]"},
+			},
+			Related: []string{"__concat", "__string_append"},
+		},
+		{
+			Name:        "__json_decode",
+			Title:       "JSON Decode",
+			Group:       "text",
+			Function:    UDN_JsonDecode,
+			Description: "Decodes a string to Go data: map[string]interface is assumed if using Global Data",
+			Input:       "String",
+			Output:      "Map :: map[string]interface",
+			SideEffect:  "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.'{\"a\": 1}'.__json_decode", Result: "{a: 1}"},
+			},
+			Related: []string{"__json_encode"},
+		},
+		{
+			Name:        "__json_encode",
+			Title:       "JSON Encode",
+			Group:       "text",
+			Function:    UDN_JsonEncode,
+			Description: "Encodes Go data into a JSON string",
+			Input:       "Any",
+			Output:      "String",
+			SideEffect:  "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.{a=1}.__json_encode", Result: "{\"a\": \"1\"}"},
+			},
+			Related: []string{"__json_decode"},
+		},
+		{
+			Name:        "__base64_decode",
+			Title:       "Base64 Decode",
+			Group:       "text",
+			Function:    UDN_Base64Decode,
+			Description: "Decodes a string from base64 into a normal string",
+			Input:       "String",
+			Output:      "Map :: map[string]interface",
+			SideEffect:  "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.'todo'.__base64_decode", Result: "todo"},
+			},
+			Related: []string{"__base64_encode"},
+		},
+		{
+			Name:        "__base64_encode",
+			Title:       "Base64 Encode",
+			Group:       "text",
+			Function:    UDN_Base64Encode,
+			Description: "Encodes a string into base64, needed for passing around in web pages where quoting issues or spacing won't allow regular text, or binary transmission",
+			Input:       "Any",
+			Output:      "String",
+			SideEffect:  "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.todo.__json_encode", Result: "todo"},
+			},
+			Related: []string{"__base64_decode"},
+		},
+		{
+			Name:        "__html_encode",
+			Title:       "HTML Encode",
+			Group:       "text",
+			Function:    UDN_HtmlEncode,
+			Description: "Escapes HTML characters",
+			Input:       "String",
+			Output:      "String",
+			SideEffect:  "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.'1 < 2'.__html_encode", Result: "1 &lt; 2"},
+			},
+		},
+		{
+			Name:        "__markdown_format",
+			Title:       "Markdown Format as HTML",
+			Group:       "text",
+			Function:    UDN_StringMarkdownFormat,
+			Description: "Converts text in Markdown format to HTML.",
+			Input:       "String",
+			Output:      "String",
+			SideEffect:  "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.'1 < 2'.__markdown_format", Result: "1 &lt; 2"},
+			},
+		},
+		{
+			Name:        "__num_to_string",
+			Title:       "Number To String",
+			Group:       "text",
+			Function:    UDN_NumberToString,
+			Description: "Given input number (int/int64/float64) and optional precision (int), outputs string (with specified precision/ original number)",
+			Input:       "number (int/int64/float64)",
+			Args: []UdnArgSignature{
+				{Name: "precision", Type: "int", Description: "arithmetic precision (number of decimal places)", Optional: true},
+			},
+			Output:     "string (with specified precision/original number)",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__math.input.'999.99'.__num_to_string.4", Result: "\"999.9900\""},
+				{Description: "Alternate Example, no argument/precision specified:", Udn: "__math.input.999.__num_to_string", Result: "\"999\""},
+			},
+		},
+		//TODO(g): DEPRICATE.  Longer name, same function.
+		{
+			Name:         "__template_string",
+			Title:        "Template String",
+			Group:        "text",
+			Function:     UDN_StringTemplateFromValue,
+			Description:  "Templates the string passed in as arg_0.  Longer name for __template, same function.",
+			DeprecatedBy: "__template",
+		},
+		{
+			Name:        "__string_replace",
+			Title:       "String Replace",
+			Group:       "text",
+			Function:    UDN_StringReplace,
+			Description: "Replaces all instances of arg_0 in the input string with arg_1",
+			Args: []UdnArgSignature{
+				{Name: "old", Type: "string", Description: "String to replace"},
+				{Name: "new", Type: "string", Description: "String to replace it with.  ||QUOTE|| becomes a single quote"},
+			},
+			ArgCount: &UdnArgCount{2, -1},
+		},
+		{
+			Name:        "__string_ends_with",
+			Title:       "String Ends With",
+			Group:       "text",
+			Function:    UDN_StringEndsWith,
+			Description: "Returns boolean, if matches end of string",
+		},
+		{
+			Name:        "__string_begins_with",
+			Title:       "String Begins With",
+			Group:       "text",
+			Function:    UDN_StringEndsWith,
+			Description: "Returns boolean, if matches beginning of string",
+		},
+		{
+			Name:        "__json_encode_data",
+			Title:       "JSON Encode Data",
+			Group:       "text",
+			Function:    UDN_JsonEncodeData,
+			Description: "Encode JSON - Format as data.  No indenting, etc.",
+		},
+		{
+			Name:        "__uuid",
+			Title:       "UUID",
+			Group:       "text",
+			Function:    UDN_Uuid,
+			Description: "Returns a UUID string",
+			ArgCount:    &UdnArgCount{0, 0},
+		},
+		{
+			Name:        "__join",
+			Title:       "Join",
+			Group:       "text",
+			Function:    UDN_StringJoin,
+			Description: "Join an array into a string on a separator string",
+			Args: []UdnArgSignature{
+				{Name: "separator", Type: "string", Description: "String put between each item"},
+			},
+			ArgCount: &UdnArgCount{1, -1},
+		},
+
+		// Maps
+		{
+			Name:        "__map_key_set",
+			Title:       "Map Key Set",
+			Group:       "map",
+			Function:    UDN_MapKeySet,
+			Description: "Sets N keys, like __format, but with no formatting",
+			Input:       "Map",
+			Args: []UdnArgSignature{
+				{Name: "key", Type: "String", Description: "Key/field to set in the Map", Variadic: true},
+				{Name: "value", Type: "Any", Description: "Value to set in the Map key/field", Variadic: true},
+			},
+			Output:     "Map",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.{name=Bob}.__map_key_set.job.Programmer", Result: "{name=Bob,job=Programmer}"},
+			},
+			Related: []string{"__map_key_delete"},
+		},
+		{
+			Name:        "__map_key_delete",
+			Title:       "Map Key Delete",
+			Group:       "map",
+			Function:    UDN_MapKeyDelete,
+			Description: "Deletes N keys",
+			Input:       "Map",
+			Args: []UdnArgSignature{
+				{Name: "key", Type: "String", Description: "Key/field to delete in the Map", Variadic: true},
+			},
+			Output:     "Map",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.{name=Bob,job=Programming}.__map_key_delete.job", Result: "{name=Bob}"},
+			},
+			Related: []string{"__map_key_set"},
+		},
+		{
+			Name:        "__map_copy",
+			Title:       "Map Copy",
+			Group:       "map",
+			Function:    UDN_MapCopy,
+			Description: "Creates a new Map which is a copy/clone of the current one, so you can modify it without changing the original",
+			Input:       "Map",
+			Args: []UdnArgSignature{
+				{Name: "key", Type: "String", Description: "Key/field to delete in the Map", Variadic: true},
+			},
+			Output:     "Map",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.{name=Bob,job=Programming}.__map_copy", Result: "{name=Bob,job=Programming}"},
+			},
+		},
+		{
+			Name:        "__map_update",
+			Title:       "Map Update",
+			Group:       "map",
+			Function:    UDN_MapUpdate,
+			Description: "Creates a new Map which is a copy/clone of the current one, so you can modify it without changing the original",
+			Input:       "Map",
+			Args: []UdnArgSignature{
+				{Name: "update", Type: "Map", Description: "Update map to overlay on top of input"},
+				{Name: "map", Type: "Map", Description: "Override the input map, passing in an arg1 map", Optional: true},
+			},
+			Output:     "Map",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.{name=Bob}.__map_update.{job=Programming}", Result: "{name=Bob,job=Programming}"},
+			},
+		},
+		{
+			Name:     "__map_template_key",
+			Title:    "Map Template Key",
+			Group:    "map",
+			Function: UDN_MapTemplateKey,
+			Description: `Creates a new Map which has keys that are templated versions of the previos map.  The values remain the same.
+
+This is useful for things such as prefixing a UUID in front of keys, so that they can be injected into HTML pages.`,
+			Input: "Map",
+			Args: []UdnArgSignature{
+				{Name: "template", Type: "String", Description: "Template string (text/template)"},
+				{Name: "data", Type: "Map", Description: "Map of values to use for templating.  A \"key\" key will be added with each key's string, so that it can be used in the templating process."},
+				{Name: "map", Type: "Map", Description: "Map that overrides input Map.", Optional: true},
+			},
+			Output:     "Map",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.{name=Bob}.__map_template_key.'{{uuid}}_{{key}}'.{uuid=1234}", Result: "{1234_name=Bob}"},
+			},
+		},
+		{
+			Name:     "__map_filter_key",
+			Title:    "Map Filter Key",
+			Group:    "map",
+			Function: UDN_MapFilterKey,
+			Description: `Returns a new map, which has a filtered set of keys based on an array of strings passed in as arg0.
+
+This is useful for things such as prefixing a UUID in front of keys, so that they can be injected into HTML pages.`,
+			Input: "Map",
+			Args: []UdnArgSignature{
+				{Name: "keys", Type: "Array of Strings", Description: "Keys to match for filtering map keys"},
+			},
+			Output:     "Map",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.{item1=Something1,item2=Something2,item3=Something3}.__map_filter_key.[item2,item3]", Result: "{item2=Something2,item3=Something3}"},
+			},
+		},
+		{
+			Name:     "__group_by",
+			Title:    "Group by on a list of Maps",
+			Group:    "map",
+			Function: UDN_GroupBy,
+			Description: `Given a list of maps, group by an aggregate field
+
+**Grouping methods:**
+
+1. sum
+2. count`,
+			Input: "None",
+			Args: []UdnArgSignature{
+				{Name: "method", Type: "string", Description: "method to group on"},
+				{Name: "data", Type: "list of maps", Description: "source of data to operate on"},
+				{Name: "aggregate_field", Type: "string", Description: "aggregated field"},
+				{Name: "group_field", Type: "string", Description: "field to group on"},
+			},
+			Output:     "Aggregated map",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: `__input.[{order_id:101,category:monitor,cost:(__math.input.80)},{order_id:102,category:monitor,cost:(__math.input.82)},{order_id:103,category:laptop,cost:(__math.input.100)}].__set.data,
+__group_by.sum.(__get.data).cost.category.__set.set_api_result`, Result: "[{category:monitor,cost:162},{category:laptop,cost:100}]"},
+			},
+		},
+		{
+			Name:        "__map_filter_array_contains",
+			Title:       "Map Filter Array Contains",
+			Group:       "map",
+			Function:    UDN_MapFilterArrayContains,
+			Description: "Filters elements in a map, if one of their keys contains at array we are comparing for containing values of another array",
+		},
+
+		// Array
+		{
+			Name:        "__array_append",
+			Title:       "Array Append",
+			Group:       "array",
+			Function:    UDN_ArrayAppend,
+			Description: "Appends the input into the specified target location (args)",
+			Input:       "Item to append into the array",
+			Args: []UdnArgSignature{
+				{Name: "location", Type: "Any", Description: "Target array name"},
+			},
+			Output:     "Array",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: `__input.[1,2,3].__set.test
+__input.4.__array_append.test`, Result: "[1,2,3,4]"},
+			},
+		},
+		{
+			Name:        "__array_append_array",
+			Title:       "Array Append Array",
+			Group:       "array",
+			Function:    UDN_ArrayAppendArray,
+			Description: "Appends the input (array) into the specified target location (args)",
+			Input:       "Array of items",
+			Args: []UdnArgSignature{
+				{Name: "location", Type: "Any", Description: "Target array name"},
+			},
+			Output:     "Array",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: `__input.[1,2,3].__set.test
+__input.[4,5,6].__array_append.test`, Result: "[1,2,3,4,5,6]"},
+			},
+		},
+		{
+			Name:        "__array_remove",
+			Title:       "Array Remove",
+			Group:       "array",
+			Function:    UDN_ArrayRemove,
+			Description: "Removes first entry of the element (input)",
+			Input:       "Item to remove from the array",
+			Args: []UdnArgSignature{
+				{Name: "location", Type: "Map", Description: "Target array name"},
+			},
+			Output:     "Array",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input[\"Alice\", \"Bob\"].__set.test.array.__input.[\"Alice\"].__array_remove.test_array", Result: "[\"Bob\"]"},
+			},
+		},
+		{
+			Name:        "__array_index",
+			Title:       "Array Index",
+			Group:       "array",
+			Function:    UDN_ArrayIndex,
+			Description: "Returns the index (int) of the first entry in the array of the input element",
+			Input:       "Item to find the index of in the array",
+			Args: []UdnArgSignature{
+				{Name: "location", Type: "Map", Description: "Target array name"},
+			},
+			Output:     "Array",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input[\"Alice\", \"Bob\"].__set.test.array.__input.[\"Bob\"].__array_remove.test_array", Result: "0"},
+			},
+			ArgCount: &UdnArgCount{1, -1},
+		},
+		{
+			Name:     "__array_slice",
+			Title:    "Array Slice",
+			Group:    "array",
+			Function: UDN_ArraySlice,
+			Description: `Splits the array based on the start and end index (args)
+
+Note: for positive indices the end index is non-inclusive. For negative indices the start index is non inclusive. Also, for positive indices the first element of the array is at 0. For negative indices the last element is at -1.`,
+			Input: "Array",
+			Args: []UdnArgSignature{
+				{Name: "start", Type: "Int", Description: "Start index (can be positive or negative)"},
+				{Name: "end", Type: "Int", Description: "End index (can be positive or negative) - if end index not provided then end index is assumed to be end of array"},
+			},
+			Output:     "Array Slice based on start & end index",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.[1,2,3,4,5,6].__array_slice.0.6", Result: "[1,2,3,4,5,6]"},
+				{Udn: "__input.[1,2,3,4,5,6].__array_slice.-2.-1", Result: "[6]"},
+				{Udn: "__input.[1,2,3,4,5,6].__array_slice.-7.-1", Result: "[1,2,3,4,5,6]"},
+			},
+		},
+		{
+			Name:        "__array_divide",
+			Title:       "Array Divide",
+			Group:       "array",
+			Function:    UDN_ArrayDivide,
+			Description: "Breaks an array up into a set of arrays, based on a divisor.  Ex: divide=4, a 14 item array will be 4 arrays, of 4/4/4/2 items each.",
+			Input:       "Array",
+			Args: []UdnArgSignature{
+				{Name: "columns", Type: "Integer", Description: "\"Columns\" to break up the \"Row\" of the Array, into many \"Rows\" of max \"Column\""},
+			},
+			Output:     "Array",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.[1,2,3,4].__array_divide.2", Result: "[[1,2],[3,4]]"},
+			},
+			ArgCount: &UdnArgCount{1, -1},
+		},
+		{
+			Name:        "__array_contains",
+			Title:       "Array Contains",
+			Group:       "array",
+			Function:    UDN_ArrayContains,
+			Description: "Returns boolean, if the array specified by args contains all the elements in the input (single or array of elements)",
+			Input:       "Item or Array of Items to see if they all exist in the specified array",
+			Args: []UdnArgSignature{
+				{Name: "location", Type: "Map", Description: "Target array name"},
+			},
+			Output:     "Bool",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input[\"Alice\", \"Bob\"].__set.test.array.__input.[\"Bob\"].__array_remove.test_array", Result: "0"},
+			},
+		},
+		{
+			Name:        "__array_map_update",
+			Title:       "Array Map Update",
+			Group:       "array",
+			Function:    UDN_ArrayMapUpdate,
+			Description: "Takes an array of maps, overwrites all the update map key/values into the maps",
+			Input:       "Array of Maps",
+			Args: []UdnArgSignature{
+				{Name: "update", Type: "Map", Description: "Key/Values to overwrite into incoming map"},
+			},
+			Output:     "Array of Maps",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.[{age=10},{age=20}].__array_map_update.{color=1}", Result: "[{age=10,color=1},{age=20,color=1}]"},
+			},
+			ArgCount: &UdnArgCount{1, -1},
+		},
+		{
+			Name:        "__array_map_remap",
+			Title:       "Array Map Remap",
+			Group:       "array",
+			Function:    UDN_ArrayMapRemap,
+			Description: "Takes an array of maps, and makes a new array of maps, based on the arg[0] (map) mapping (key_new=key_old)",
+			Input:       "Array of Maps",
+			Args: []UdnArgSignature{
+				{Name: "remap", Type: "Map", Description: "Keys of this map will be replaced in every Map in the Array with the value"},
+			},
+			Output:     "Array of Maps",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.[{age=10},{age=20}].__array_map_remap.{age=8}", Result: "[{age=8},{age=8}]"},
+			},
+			ArgCount: &UdnArgCount{1, -1},
+		},
+		{
+			Name:        "__array_map_find",
+			Title:       "Array Map Find",
+			Group:       "array",
+			Function:    UDN_ArrayMapFind,
+			Description: "Takes an array of maps, and returns the first entry that matches all key values of the arg0 map, or nil",
+			Input:       "Array of Maps",
+			Args: []UdnArgSignature{
+				{Name: "find", Type: "Map", Description: "This is a key/value map to check against the array of maps, returning the first map which matches all keys/values."},
+			},
+			Output:     "Map",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.[{age=10,name=Joe},{age=20,name=Bob}].__array_map_find.{age=10}", Result: "{age=10,name=Joe}"},
+			},
+			ArgCount: &UdnArgCount{1, -1},
+		},
+		//TODO(g): Remove once we transition everything to FilterUpdate, was incorrectly named as the first of it's kind. -- --  Finds all map elements that match the find map, and updates them with a passed in map, so we can find the map in-place of matching records.  Returns the array of maps
+		{
+			Name:        "__array_map_find_update",
+			Title:       "Array Map Find Update - Depricated use __array_map_filter_update",
+			Group:       "array",
+			Function:    UDN_ArrayMapFindUpdate,
+			Description: "Takes an array of maps, and matches it against all the arguments in the first map.  For all elements that match, they are updated with the second map.  The entire array is returned.",
+			Input:       "Array of Maps",
+			Args: []UdnArgSignature{
+				{Name: "find", Type: "Map", Description: "This is a key/value map to check against the array of maps, returning the first map which matches all keys/values."},
+				{Name: "update", Type: "Map", Description: "This map is used to update all maps that match key/values in arg0"},
+			},
+			Output:     "Array of Maps",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.[{age=10,name=Joe},{age=20,name=Bob}].__array_map_find_update.{age=10}.{selected=selected}", Result: "[{age=10,name=Joe,selected=selected},{age=20,name=Bob}]"},
+			},
+			DeprecatedBy: "__array_map_filter_update",
+		},
+		//TODO(g): Update to FilterUpdate and replace code that uses it.  Unless worth having 2 functions.  Want to keep the name spaces and argument order/types similar accross all of them to avoid the PHP curse.  -- -- Finds all map elements that match the find map, and updates them with a passed in map, so we can find the map in-place of matching records.  Returns the array of maps
+		{
+			Name:        "__array_map_filter_update",
+			Title:       "Array Map Filter Update",
+			Group:       "array",
+			Function:    UDN_ArrayMapFilterUpdate,
+			Description: "Takes an array of maps, and matches it against all the arguments in the first map.  For all elements that match, they are updated with the second map.  The entire array is returned.",
+			Input:       "Array of Maps",
+			Args: []UdnArgSignature{
+				{Name: "find", Type: "Map", Description: "This is a key/value map to check against the array of maps, returning the first map which matches all keys/values."},
+				{Name: "update", Type: "Map", Description: "This map is used to update all maps that match key/values in arg0"},
+			},
+			Output:     "Array of Maps",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.[{age=10,name=Joe},{age=20,name=Bob}].__array_map_filter_update.{age=10}.{selected=selected}", Result: "[{age=10,name=Joe,selected=selected},{age=20,name=Bob}]"},
+			},
+			ArgCount: &UdnArgCount{2, -1},
+		},
+		{
+			Name:        "__array_map_filter_in",
+			Title:       "Array Map Filter In",
+			Group:       "array",
+			Function:    UDN_ArrayMapFilterIn,
+			Description: "Takes an array of maps, and matches against a set of filtered elements, which must all be inside of the array of options available in the arg0 array.",
+			Input:       "Array of Maps",
+			Args: []UdnArgSignature{
+				{Name: "values", Type: "Array", Description: "This is an array of elements, which the corresponding key in the input array map must be inside of to match the filter."},
+			},
+			Output:     "Array of Maps",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.[{age=10,name=Joe},{age=20,name=Bob}].__array_map_filter_in.{age=[10,15]}", Result: "[{age=10,name=Joe}]"},
+			},
+			ArgCount: &UdnArgCount{1, -1},
+		},
+		{
+			Name:        "__array_map_filter_contains",
+			Title:       "Array Map Filter Contains Any",
+			Group:       "array",
+			Function:    UDN_ArrayMapFilterContains,
+			Description: "Takes an array of maps, and matches against a set of filtered elements, which must all be inside of the array of options available in the arg0 array.",
+			Input:       "Array of Maps",
+			Args: []UdnArgSignature{
+				{Name: "values", Type: "Array", Description: "This is an array of elements, which the corresponding key in the input array map, whose value is an any, that contains at least 1 of the items in the corresponding key in the filter map's array."},
+				{Name: "options", Type: "Map", Description: "This is an Options map.  Current options:  \"all\" (boolean).  If true, every filter Contains List must be matched on each key.  If false (0), only 1 set of Contains List must be matched to match the filter.", Optional: true},
+			},
+			Output:     "Array of Maps",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.[{age=10,name=Joe},{age=20,name=Bob}].__array_map_filter_contains.{age=[10,20],name=[Bob]}", Result: "[{age=20,name=Bob}]"},
+				{Udn: "__input.[{age=10,name=Joe},{age=20,name=Bob}].__array_map_filter_contains.{age=[10],name=[Bob]}.{all=0}", Result: "[{age=10,name=Joe},{age=20,name=Bob}]"},
+			},
+		},
+		{
+			Name:        "__array_map_filter_array_contains",
+			Title:       "Array Map Filter Array Contains",
+			Group:       "array",
+			Function:    UDN_ArrayMapFilterArrayContains,
+			Description: "Takes an array of maps, and matches a list against a list, to see if any of the items match, as the default match filter",
+			Input:       "Array of Maps",
+			Args: []UdnArgSignature{
+				{Name: "key", Type: "String", Description: "Key in each of the maps which contains an array, that we will test"},
+				{Name: "values", Type: "Array", Description: "This is an array of elements, which the corresponding key in the input array map, whose value is an any, that contains at least 1 of the items in the corresponding key in the filter map's array."},
+				{Name: "options", Type: "Map", Description: "This is an Options map.  Current options:  \"all\" (boolean).  If true, every filter Contains List must be matched on each key.  If false (0), only 1 set of Contains List must be matched to match the filter.", Optional: true},
+			},
+			Output:     "Array of Maps",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.[{age=10,name=Joe,groups[ops,eng,admin]},{age=20,name=Bob,groups=[ops]}].__array_map_filter_contains.{groups=[admin,eng]}", Result: "{age=10,name=Joe,groups[ops,eng,admin]}"},
+			},
+		},
+		{
+			Name:        "__array_map_template",
+			Title:       "Array Map Template",
+			Group:       "array",
+			Function:    UDN_ArrayMapTemplate,
+			Description: "Takes an array of maps, iterates over each map, and performs N templates updating keys with the map's contents (key/values)",
+			Input:       "Array of Maps",
+			Args: []UdnArgSignature{
+				{Name: "key", Type: "String", Description: "This is the map key to update/set", Variadic: true},
+				{Name: "template", Type: "String", Description: "This is the text/template data, uses the map's data", Variadic: true},
+				{Name: "more_keys", Type: "String", Description: "This is the map key to update/set", Optional: true, Variadic: true},
+				{Name: "more_templates", Type: "String", Description: "This is the text/template data, uses the map's data", Optional: true, Variadic: true},
+			},
+			Output:     "Array of Maps",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.[{age=10,name=Joe},{age=20,name=Bob}].__array_map_template.label.'{{index .Map \"name\"}}: {{index .Map \"age\"}}'", Result: "[{age=10,name=Joe,label='Joe: 10',selected=selected},{age=20,name=Bob,label='Bob: 20'}]"},
+			},
+		},
+		{
+			Name:        "__array_map_to_map",
+			Title:       "Array Map Find",
+			Group:       "array",
+			Function:    UDN_ArrayMapToMap,
+			Description: "Takes an array of maps, and returns a map where the key is the specified arg0 string key in all maps in the array",
+			Input:       "Array of Maps",
+			Args: []UdnArgSignature{
+				{Name: "key", Type: "String", Description: "Key to get from array maps, and key to set in result map"},
+				{Name: "array", Type: "List of Maps", Description: "Can be passed in as arg1 instead of input", Optional: true},
+			},
+			Output:     "Map",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.[{age=10,name=Joe},{age=20,name=Bob}].__array_map_to_map.name", Result: "{Joe={age=10,name=Joe},Bob={age=20,name=Bob}}"},
+			},
+		},
+		{
+			Name:        "__array_map_to_series",
+			Title:       "Array Map To Series",
+			Group:       "array",
+			Function:    UDN_ArrayMapToSeries,
+			Description: "Takes an array of maps, and returns an array, using only values from a single key.  Used for making time series or graph axes.",
+			Input:       "Array of Maps",
+			Args: []UdnArgSignature{
+				{Name: "key", Type: "String", Description: "Key to get from array maps, and key to set in result map"},
+				{Name: "array", Type: "List of Maps", Description: "Can be passed in as arg1 instead of input", Optional: true},
+			},
+			Output:     "Map",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.[{age=10,name=Joe},{age=20,name=Bob}].__array_map_to_map.name", Result: "{Joe={age=10,name=Joe},Bob={age=20,name=Bob}}"},
+			},
+		},
+		{
+			Name:        "__array_map_key_set",
+			Title:       "Array Map Key Set",
+			Group:       "array",
+			Function:    UDN_ArrayMapKeySet,
+			Description: "Takes an array of maps, sets a variable number of key/value pairs",
+			Input:       "Array of Maps",
+			Args: []UdnArgSignature{
+				{Name: "key", Type: "String", Description: "Key to set in each map", Variadic: true},
+				{Name: "value", Type: "Any", Description: "Value to be set into the key", Variadic: true},
+				{Name: "more_keys", Type: "String", Description: "Key to set in each map", Optional: true, Variadic: true},
+				{Name: "more_values", Type: "Any", Description: "Value to be set into the key", Optional: true, Variadic: true},
+			},
+			Output:     "Array of Maps",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.[{age=10,name=Joe},{age=20,name=Bob}].__array_map_key_set.uuid.1234", Result: "[{age=10,name=Joe,uuid=1234},{age=20,name=Bob,uuid=1234}]"},
+			},
+		},
+		{
+			Name:        "__array_string_join",
+			Title:       "Array of String Join",
+			Group:       "array",
+			Function:    UDN_ArrayStringJoin,
+			Description: "Join an array of strings (or converted to string) with a separator",
+			Input:       "Array of Strings",
+			Args: []UdnArgSignature{
+				{Name: "separator", Type: "String", Description: "Separator to join strings with"},
+				{Name: "array", Type: "Array of Strings", Description: "Override input with arg1", Optional: true},
+			},
+			Output:     "String",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.[Joe,Bob,Tom].__array_string_join.','", Result: "'Joe,Bob,Tom'"},
+			},
+		},
+		{
+			Name:        "__array_contains_any",
+			Title:       "Array Contains Any",
+			Group:       "array",
+			Function:    UDN_ArrayContainsAny,
+			Description: "Returns boolean, if the specific array contains any of the input.  Input can be individual elemnent or an arry (converts to an array).",
+		},
+
+		// Time
+		{
+			Name:        "__string_to_time",
+			Title:       "Convert String to Time",
+			Group:       "time",
+			Function:    UDN_StringToTime,
+			Description: "Given arg[0] string in the format 'YYYY-MM-DD hh:mm:ss' or 'YYYY-MM-DDThh:mm:ss.sssZ' (including milliseconds), return the go time.Time object.",
+			Input:       "string :: This string must be of the format 'YYYY-MM-DD hh:mm:ss' or 'YYYY-MM-DDThh:mm:ss.sssZ' (including milliseconds). Otherwise, an empty result will be returned.",
+			Output:      "time.time object",
+			SideEffect:  "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.'2018-01-01 00:00:00'.__string_to_time", Result: "time.Time object (Representing the first day of 2018 at midnight)"},
+			},
+		},
+		{
+			Name:        "__get_current_time",
+			Title:       "Get Current Time",
+			Group:       "time",
+			Function:    UDN_GetCurrentTime,
+			Description: "Given arg[0] string in the format 'YYYY-DD-MM hh:mm:ss'. If specific number given for YYYY, DD, MM, hh, mm, ss, use that number instead. Outputs go time.Time object of current time (UTC).",
+			Input:       "Ignored",
+			Args: []UdnArgSignature{
+				{Name: "format", Type: "string", Description: "string format ‘YYYY-DD-MM hh:mm:ss’ - desired numbers can be specified to replace YYYY, DD, MM, hh, mm, ss", Optional: true},
+			},
+			Output:     "time.time object",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__get_current_time.'YYYY-MM-01 hh:mm:ss'", Result: "time.Time object (First day of the current month (UTC))"},
+				{Description: "Alternate Example, no arguments specified:", Udn: "__get_current_time", Result: "time.Time object (Current time (UTC))"},
+			},
+		},
+		{
+			Name:        "__get_local_time",
+			Title:       "Get Local Time",
+			Group:       "time",
+			Function:    UDN_GetLocalTime,
+			Description: "If given arg[0] string, a specified timezone in the IANA Time Zone database, such as \"America/Chicago\". If given \"\" or \"local\" or no argument, outputs go time.Time object of current local time. Otherwise, outputs time.Time object, current time in the specified timezone.",
+			Input:       "Ignored",
+			Args: []UdnArgSignature{
+				{Name: "timezone", Type: "string", Description: "specified timezone in the IANA Time Zone database (https://en.wikipedia.org/wiki/List_of_tz_database_time_zones)", Optional: true},
+			},
+			Output:     "time.time object",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__get_local_time.'America/Chicago'", Result: "time.Time object (current time in America/Chicago timezone)"},
+				{Description: "Alternate Example, no arguments specified:", Udn: "__get_local_time", Result: "time.Time object (Current local time)"},
+			},
+		},
+		{
+			Name:        "__time_to_epoch",
+			Title:       "Convert time.Time to a int64 unix time in seconds",
+			Group:       "time",
+			Function:    UDN_TimeToEpoch,
+			Description: "Given arg[0] time.Time object, convert to int64 unix time in seconds",
+			Input:       "time.Time object",
+			Output:      "int :: Unix time in seconds",
+			SideEffect:  "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.'2018-01-01 00:00:00'.__string_to_time.__time_to_epoch", Result: "1514764800"},
+			},
+		},
+		{
+			Name:        "__time_to_epoch_ms",
+			Title:       "Convert time.Time to a int64 unix time in milliseconds",
+			Group:       "time",
+			Function:    UDN_TimeToEpochMs,
+			Description: "Given arg[0] time.Time object, convert to int64 unix time in milliseconds",
+			Input:       "time.Time object",
+			Output:      "int :: Unix time in milliseconds",
+			SideEffect:  "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.'2018-01-01 00:00:00'.__string_to_time.__time_to_epoch", Result: "1514764800000"},
+			},
+		},
+		{
+			Name:        "__time",
+			Title:       "Time Object",
+			Group:       "time",
+			Function:    UDN_Time,
+			Description: "Returns a time.Time object, default is Now.  All arguments are optional, and modify the time in a positive or negative way",
+			Input:       "None",
+			Args: []UdnArgSignature{
+				{Name: "years", Type: "int", Description: "Year - Modifies year from current time.  Uses AddDate", Optional: true},
+				{Name: "months", Type: "int", Description: "Month - Modifies month from current time.  Uses AddDate", Optional: true},
+				{Name: "days", Type: "int", Description: "Day - Modifies day from current time.  Uses AddDate", Optional: true},
+				{Name: "duration", Type: "string", Description: "Duration - Modifies ns/ms/s/h from current time.  Uses ParseDuration", Optional: true},
+			},
+			Output:     "time.Time object",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__input.'2018-01-01 00:00:00'.__string_to_time.__time_to_epoch", Result: "1514764800000"},
+			},
+		},
+		{
+			Name:        "__time_string",
+			Title:       "Time String",
+			Group:       "time",
+			Function:    UDN_TimeString,
+			Description: "Return string of the time",
+		},
+		{
+			Name:        "__time_string_date",
+			Title:       "Time String Date",
+			Group:       "time",
+			Function:    UDN_TimeStringDate,
+			Description: "Return string of the date",
+		},
+		{
+			Name:        "__time_series_get",
+			Title:       "Time Series Get",
+			Group:       "time",
+			Function:    UDN_TimeSeriesGet,
+			Description: "Time Series: Get",
+		},
+		{
+			Name:        "__time_series_filter",
+			Title:       "Time Series Filter",
+			Group:       "time",
+			Function:    UDN_TimeSeriesFilter,
+			Description: "Time Series: Filter",
+		},
+
+		// Math
+		{
+			Name:        "__math",
+			Title:       "Math Functions",
+			Group:       "math",
+			Function:    UDN_Math,
+			Description: "Performs a set of math functions\n\n**Functions:**\n\n```\n__math.input.arg0 (returns a int/float)\n__math.sum.arg0.arg1 or __math.+.arg0.arg1 (returns arg0 + arg1)\n__math.subtract.arg0.arg1 or __math.-.arg0.arg1 (returns arg0 - arg1)\n__math.multiply.arg0.arg1 or __math.*.arg0.arg1 (returns arg0 * arg1)\n__math.divide.arg0.arg1 or __math./.arg0.arg1 (returns arg0 / arg1)\n```",
+			Input:       "None",
+			Args: []UdnArgSignature{
+				{Name: "method", Type: "string", Description: "specify the math function called"},
+				{Name: "values", Type: "int/float", Description: "Arguments for the math function", Variadic: true},
+			},
+			Output:     "int/float :: result of the math function",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__math.input.8", Result: "8 (int, __math.input also converts a string like '8')"},
+				{Udn: "__math.add.8.9", Result: "17"},
+				{Udn: "__math.multiply.'1.1'.'5.5'", Result: "6.05"},
+			},
+		},
+
+		// Rendering
+		{
+			Name:        "__widget",
+			Title:       "Execute UDN from String",
+			Group:       "rendering",
+			Function:    UDN_Widget,
+			Description: "All widgets are cached in memory, this just accesses that cache and returns the Widget string.",
+			Input:       "Ignored",
+			Args: []UdnArgSignature{
+				{Name: "widget_name", Type: "string", Description: "Name of widget"},
+			},
+			Output:     "String",
+			SideEffect: "Any",
+			Examples: []UdnFunctionExample{
+				{Udn: "__widget.button", Result: "<button type=\"button\" class=\"btn btn-{{index .Map \"color\"}}\" onclick=\"{{index .Map \"onclick\"}}\"><i class=\"{{index .Map \"icon\"}} position-left\"></i> {{index .Map \"value\"}}</button>"},
+			},
+			ArgCount: &UdnArgCount{1, -1},
+		},
+		{
+			Name:        "__render_data",
+			Title:       "Render Data Widget",
+			Group:       "rendering",
+			Function:    UDN_RenderDataWidgetInstance,
+			Description: "Renders a Data Widget Instance.  Performs all the operations needed to render a Data Widget Instance to a web page via an API call, or other accessing method.",
+			Input:       "Ignored",
+			Args: []UdnArgSignature{
+				{Name: "web_data_widget_instance_id", Type: "Integer", Description: "web_data_widget_instance.id"},
+				{Name: "widget_instance", Type: "Map", Description: "A map to update the \"widget_instance\" Global Data, to include external data in the rendering process"},
+			},
+			Output:     "String",
+			SideEffect: "Any",
+			Examples: []UdnFunctionExample{
+				{Udn: "__render_data.dialog_target.34.{control=(__get.param.data.__json_decode)}", Result: "...HTML/CSS/JS..."},
+			},
+		},
+
+		// Networking
+		{
+			Name:        "__set_http_response",
+			Title:       "Set http response code",
+			Group:       "networking",
+			Function:    UDN_SetHttpResponseCode,
+			Description: "Sets the returning http response code",
+			Input:       "None",
+			Args: []UdnArgSignature{
+				{Name: "code", Type: "string", Description: "the http code (string) to be returned"},
+			},
+			Output:     "Nothing. The request's http return code will be set",
+			SideEffect: "The request's http return code will be set",
+			Examples: []UdnFunctionExample{
+				{Udn: "__set_http_response.404", Result: "Nothing"},
+			},
+		},
+		{
+			Name:        "__http_request",
+			Title:       "Send http request",
+			Group:       "networking",
+			Function:    UDN_HttpRequest,
+			Description: "Sends a http request with a given method (POST|PUT|DELETE|GET) to a url endpoint.",
+			Input:       "map or array of maps to be encoded in the request body sends alone with the POST, PUT, DELETE",
+			Args: []UdnArgSignature{
+				{Name: "method", Type: "string", Description: "request method, should be one of \"POST\",\"GET\",\"DELETE\",\"PUT\""},
+				{Name: "url", Type: "string", Description: "the url that the request goes to"},
+				{Name: "timeout", Type: "int", Description: "the seconds after which the request will be timeout. By default, is 10 secs", Optional: true},
+			},
+			Output:     "if error occurrs, returns an error, which can be handled with [__try](#__try). If a \"GET\" request, returns the decoded json(application/json) or text string(other content-type), otherwise returns the response status code.",
+			SideEffect: "If POST/PUT/DELETE request, will change the state on the target url.",
+			Examples: []UdnFunctionExample{
+				{Udn: "__http_request.'GET'.'http://eventsum.infra.prod.wish.com/detail?event_id=3002'.'10'", Result: "{ \"event_name\": \"AttributeError\", \"event_type\": \"python\", \"raw_data\": ...}"},
+				{Udn: "__input.[{name=group1,info=group1_info}].__http_request.'POST'.'http://eventsum.infra.prod.wish.com/group'", Result: "201"},
+			},
+		},
+
+		// User
+		{
+			Name:        "__login",
+			Title:       "LDAP User Login",
+			Group:       "user",
+			Function:    UDN_Login,
+			Writes:      true,
+			Description: "Authenticates against LDAP server",
+			Input:       "Ignored",
+			Args: []UdnArgSignature{
+				{Name: "username", Type: "String", Description: "User name"},
+				{Name: "password", Type: "String", Description: "Password"},
+			},
+			Output:     "String",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__login.bob.pass", Result: "0tE44fJhc8Ne81jsILc6TuUZCkX"},
+			},
+			ArgCount: &UdnArgCount{2, 2},
+		},
+
+		// Special
+		{
+			Name:        "__ddd_render",
+			Title:       "Render DDD Widget Editor Dialog",
+			Group:       "special",
+			Function:    UDN_DddRender,
+			Writes:      true,
+			Description: "Returns HTML/CSS/JS necessary to render a dialog editing window for DDD spec data.",
+			Input:       "Ignored",
+			Args: []UdnArgSignature{
+				{Name: "dom_target_id", Type: "String", Description: "DOM Target ID"},
+				{Name: "web_data_widget_instance_id", Type: "Int64", Description: "web_data_widget_instance.id"},
+				{Name: "widget_instance", Type: "Map", Description: "Widget Instance Update Map"},
+				{Name: "udn_update", Type: "Map", Description: "UDN Update Map", Optional: true},
+			},
+			Output:     "String",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__ddd_render.'0'.0.0.0.(__get.temp.item.ddd_id).'temp.item.static_data_json'.(__get.temp.item.static_data_json).0", Result: "...HTML/CSS/JS..."},
+			},
+			ArgCount: &UdnArgCount{8, 8},
+		},
+		{
+			Name:        "__exec_command",
+			Title:       "Execute Command",
+			Group:       "special",
+			Function:    UDN_ExecCommand,
+			Writes:      true,
+			Description: "Execute command line command. arg0 appname, arg1-n space delimited are args.",
+		},
+
+		// Debugging
+		{
+			Name:       "__debug_output",
+			Title:      "Debug Output Printing",
+			Group:      "debugging",
+			Function:   UDN_DebugOutput,
+			Input:      "Any",
+			Output:     "Pass Through Input",
+			SideEffect: "Prints input to the debug log",
+			Examples: []UdnFunctionExample{
+				{Udn: "__debug_output"},
+			},
+		},
+		{
+			Name:        "__test_return",
+			Title:       "Test Return",
+			Group:       "debugging",
+			Function:    UDN_TestReturn,
+			Description: "Return some data as a result",
+			Args: []UdnArgSignature{
+				{Name: "value", Type: "Any", Description: "Returned as the result"},
+			},
+			ArgCount: &UdnArgCount{1, -1},
+		},
+		{
+			Name:        "__test",
+			Title:       "Test",
+			Group:       "debugging",
+			Function:    UDN_Test,
+			Description: "Test function, returns a fixed string",
+		},
+		{
+			Name:        "__test_different",
+			Title:       "Test Different",
+			Group:       "debugging",
+			Function:    UDN_TestDifferent,
+			Description: "Test function, returns a different fixed string than __test",
+		},
+		{
+			Name:        "__debug_get_all_data",
+			Title:       "Debug Get All Data",
+			Group:       "debugging",
+			Function:    UDN_DebugGetAllUdnData,
+			Description: "Returns all the Global Data (udn_data)",
+		},
+		{
+			Name:        "__log_level",
+			Title:       "Log Level",
+			Group:       "debugging",
+			Function:    UDN_SetLogLevel,
+			Description: "Set the log level",
+			Args: []UdnArgSignature{
+				{Name: "log_level", Type: "string", Description: "Log level name, ex: trace, debug, info"},
+			},
+			ArgCount: &UdnArgCount{1, -1},
+		},
+
+		// Comments
+		{
+			Name:     "__comment",
+			Title:    "UDN Comments",
+			Group:    "comments",
+			Function: UDN_Comment,
+			Input:    "Any",
+			Args: []UdnArgSignature{
+				{Name: "comment", Type: "Any", Optional: true, Variadic: true},
+			},
+			Output:     "Pass Through Input",
+			SideEffect: "None",
+			Examples: []UdnFunctionExample{
+				{Udn: "__comment.hello.this is a comment"},
+			},
+		},
+
+		// Not implemented yet
+		//"__watch": UDN_WatchSyncronization,
+		//"___watch_timeout": UDN_WatchTimeout,				//TODO(g): Should this just be an arg to __watch?  I think so...  Like if/else, watch can control the flow...
+		//"__end_watch": nil,
+		//"__template": UDN_StringTemplate,					// Does a __get from the args...
+		//"__change_delete":    UDN_DataDelete,    // Dataman Delete
+		//"__change_delete_filter":    UDN_DataDeleteFilter,    // Dataman Delete Filter
+		//"__change_ensure_exists":    UDN_ChangeEnsureExists,    // Ensure that the specified data exists in the database.  Does not have Dataman equivalent functions, wrapper.
+		//"__change_ensure_not_exists":    UDN_ChangeEnsureNotExists,    // Ensure that the specified data DOES NOT exist in the database.  Does not have Dataman equivalent functions, wrapper.
+		//"__safe_data_set":    UDN_SafeDataSet,    // Safe Dataman Set - Ensures this is the correct business before allowing the set
+		//"__safe_data_delete":    UDN_SafeDataDelete,    // Safe Dataman Delete
+		//"__safe_data_delete_filter":    UDN_SafeDataDeleteFilter,    // Safe Dataman Delete Filter
+		//"__ddd_move": UDN_DddMove,				// DDD Move position.current.x.y:  Takes X/Y args, attempted to move:  0.1.1 ^ 0.1.0 < 0.1 > 0.1.0 V 0.1.1
+		//"__ddd_get": UDN_DddGet,					// DDD Get.current.{}
+		//"__ddd_set": UDN_DddSet,					// DDD Set.current.{}
+		//"__ddd_delete": UDN_DddDelete,			// DDD Delete.current: Delete the current item (and all it's sub-items).  Append will be used with __ddd_set/move
+		//"__render_page": UDN_RenderPage,			// Render a page, and return it's widgets so they can be dynamically updated
+		//"__map_update_prefix": UDN_MapUpdatePrefix,			//TODO(g): Merge a the specified map into the input map, with a prefix, so we can do things like push the schema into the row map, giving us access to the field names and such
+		//"__map_clear": UDN_MapClear,			//TODO(g): Clears everything in a map "bucket", like: __map_clear.'temp'
+		//"__function_domain": UDN_StoredFunctionDomain,			//TODO(g): Just like function, but allows specifying the udn_stored_function_domain.id as well, so we can use different namespaces.
+		//"__capitalize": UDN_StringCapitalize,			//TODO(g): This capitalizes words, title-style
+		//"__pluralize": UDN_StringPluralize,			//TODO(g): This pluralizes words, or tries to at least
+		//"__starts_with": UDN_StringStartsWith,			//TODO(g): Returns bool if a string starts with the specified arg[0] string
+		//"__ends_with": UDN_StringEndsWith,			//TODO(g): Returns bool if a string starts with the specified arg[0] string
+		//"__get_session_data": UDN_SessionDataGet,			//TODO(g): Get something from a safe space in session data (cannot conflict with internal data)
+		//"__set_session_data": UDN_SessionDataGet,			//TODO(g): Set something from a safe space in session data (cannot conflict with internal data)
+		//"__custom_metric_filter": UDN_Custom_Metric_Filter,   			// CUSTOM: Fetch Metrics by name/labelset
+		//"__custom_metric_get_values": UDN_Custom_Metric_Get_Values,   			// CUSTOM: Get TS values for list of metrics
+		//"__custom_metric_rule_match_percent": UDN_Custom_Metric_Rule_Match_Percent,   	// CUSTOM: Returns a scalar, % of matches in the rules
+		//"__custom_metric_handle_outage": UDN_Custom_Metric_Handle_Outage,   	// CUSTOM: Handles an any outages from health check failures on metrics
+		//"__custom_metric_process_open_outages": UDN_Custom_Metric_Process_Open_Outages,   	// CUSTOM: Handles an any outages from health check failures on metrics
+		//"__customer_duty_responsibility_user_shift_next": UDN_Custom_Duty_Responsibility_User_Shift_Next,   	//CUSTOM: ....
+		//"__customer_duty_responsibility_user_shift_previous": UDN_Custom_Duty_Responsibility_User_Shift_Previous,   	//CUSTOM: ....
+	}
+}
+
+// The customer specific UDN functions (duty rosters, metrics, outages, dashboards), registered as the "custom" FunctionPack.  Implemented in custom_functions.go.
+func _UdnCustomFunctionSignatures() []*UdnFunctionSignature {
+	return []*UdnFunctionSignature{
+		{
+			Name:        "__custom_populate_schedule_duty_responsibility",
+			Title:       "Populate Schedule Duty Responsibility",
+			Group:       "custom",
+			Function:    UDN_Custom_PopulateScheduleDutyResponsibility,
+			Writes:      true,
+			Description: "CUSTOM: Populate Schedule for Duty Responsibilities",
+			ArgCount:    &UdnArgCount{4, -1},
+		},
+		//NOTE(g): This requires database tables to exist in the DB that match the functions requirements, which most UDN functions dont require.  Putting it in Custom code for now, but it will eventually be integrated into normal UDN once I have the schema population routines implemented.
+		{
+			Name:        "__code",
+			Title:       "Code",
+			Group:       "custom",
+			Function:    UDN_Custom_Code,
+			Description: "Code Execution from data.  First argument is DB to use, second is code_id, third is input_data override.",
+		},
+		{
+			Name:        "__custom_health_check_promql",
+			Title:       "Health Check PromQL",
+			Group:       "custom",
+			Function:    UDN_Custom_Health_Check_PromQL,
+			Writes:      true,
+			Description: "CUSTOM: Health check from a PromQL query",
+			ArgCount:    &UdnArgCount{4, -1},
+		},
+		{
+			Name:        "__custom_metric_process_alert_notifications",
+			Title:       "Metric Process Alert Notifications",
+			Group:       "custom",
+			Function:    UDN_Custom_Metric_Process_Alert_Notifications,
+			Writes:      true,
+			Description: "CUSTOM: Processes any open Alert Notifications",
+			ArgCount:    &UdnArgCount{1, -1},
+		},
+		{
+			Name:        "__custom_metric_escalation_policy_oncall",
+			Title:       "Metric Escalation Policy Oncall",
+			Group:       "custom",
+			Function:    UDN_Custom_Metric_Escalation_Policy_Oncall,
+			Description: "CUSTOM: Get the team/oncall members of the Escalation Policy",
+			ArgCount:    &UdnArgCount{2, -1},
+		},
+		{
+			Name:        "__custom_duty_shift_summary",
+			Title:       "Duty Shift Summary",
+			Group:       "custom",
+			Function:    UDN_Custom_Duty_Shift_Summary,
+			Description: "CUSTOM: Get the Duty shift summary over a time range",
+			ArgCount:    &UdnArgCount{4, -1},
+		},
+		{
+			Name:        "__current_duty_responsibility_current_user",
+			Title:       "Duty Responsibility Current User",
+			Group:       "custom",
+			Function:    UDN_Custom_Duty_Responsibility_Current_User,
+			Description: "CUSTOM: Get the current user of a Duty Responsibility",
+			ArgCount:    &UdnArgCount{2, -1},
+		},
+		{
+			Name:        "__customer_duty_roster_user_shift_info",
+			Title:       "Duty Roster User Shift Info",
+			Group:       "custom",
+			Function:    UDN_Custom_Duty_Roster_User_Shift_Info,
+			Description: "CUSTOM: Get the shift info for a user in a Duty Roster",
+			ArgCount:    &UdnArgCount{3, -1},
+		},
+		{
+			Name:        "__custom_weekly_activity",
+			Title:       "Weekly Activity",
+			Group:       "custom",
+			Function:    UDN_Custom_Activity_Daily,
+			Description: "CUSTOM: Weekly activity on a database/table/field",
+		},
+		{
+			Name:        "__custom_date_range_parse",
+			Title:       "Date Range Parse",
+			Group:       "custom",
+			Function:    UDN_Custom_Date_Range_Parse,
+			Description: "CUSTOM: Parse a date range",
+		},
+		{
+			Name:        "__custom_monitor_post_process_change",
+			Title:       "Monitor Post Process Change",
+			Group:       "custom",
+			Function:    UDN_Custom_Monitor_Post_Process_Change,
+			Writes:      true,
+			Description: "CUSTOM: Post change submit, process the data",
+			ArgCount:    &UdnArgCount{6, -1},
+		},
+		//TODO(g):REMOVE: This one is a typo.  Switch to the above __custom_*
+		{
+			Name:         "__customer_monitor_post_process_change",
+			Title:        "Monitor Post Process Change",
+			Group:        "custom",
+			Function:     UDN_Custom_Monitor_Post_Process_Change,
+			Writes:       true,
+			Description:  "CUSTOM: Post change submit, process the data.  This one is a typo, use __custom_monitor_post_process_change.",
+			DeprecatedBy: "__custom_monitor_post_process_change",
+			ArgCount:     &UdnArgCount{6, -1},
+		},
+		{
+			Name:        "__custom_dashboard_item_edit",
+			Title:       "Dashboard Item Edit",
+			Group:       "custom",
+			Function:    UDN_Custom_Dashboard_Item_Edit,
+			Description: "CUSTOM: Edit a dashboard item",
+			ArgCount:    &UdnArgCount{7, -1},
+		},
+		{
+			Name:        "__custom_dataman_create_filter_html",
+			Title:       "Dataman Create Filter HTML",
+			Group:       "custom",
+			Function:    UDN_Custom_Dataman_Create_Filter_Html,
+			Description: "CUSTOM: Create the HTML for a Dataman filter",
+			ArgCount:    &UdnArgCount{4, -1},
+		},
+		{
+			Name:        "__custom_dataman_add_rule",
+			Title:       "Dataman Add Rule",
+			Group:       "custom",
+			Function:    UDN_Custom_Dataman_Add_Rule,
+			Writes:      true,
+			Description: "CUSTOM: Add a rule for Dataman filter",
+			ArgCount:    &UdnArgCount{1, -1},
+		},
+		{
+			Name:        "__custom_login",
+			Title:       "Login",
+			Group:       "custom",
+			Function:    UDN_Custom_Login,
+			Writes:      true,
+			Description: "CUSTOM: Login",
+			ArgCount:    &UdnArgCount{4, -1},
+		},
+		{
+			Name:        "__custom_auth",
+			Title:       "Auth",
+			Group:       "custom",
+			Function:    UDN_Custom_Auth,
+			Description: "CUSTOM: Authenticate",
+			ArgCount:    &UdnArgCount{2, -1},
+		},
+	}
+}
@@ -1,12 +1,14 @@
 package yudien
 
 import (
-	"container/list"
 	"database/sql"
 	"fmt"
 	. "github.com/ghowland/yudien/yudiencore"
 	. "github.com/ghowland/yudien/yudienutil"
+	"regexp"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 
@@ -21,12 +23,25 @@ const (
 	part_map_key  = iota
 )
 
+// UdnPart.ValueFinalType, for item literals that are recognised while parsing.  literal_none means the item is plain text, and Value is used as-is.
+const (
+	literal_none  = iota
+	literal_int   = iota
+	literal_float = iota
+	literal_bool  = iota
+	literal_null  = iota
+)
+
+// Same number format as JSON, so "007" and "1." stay text
+var udn_literal_int_regex = regexp.MustCompile(`^-?(0|[1-9][0-9]*)$`)
+var udn_literal_float_regex = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][-+]?[0-9]+)?$`)
+
 
-func SprintUdnResultList(items list.List) string {
+func SprintUdnResultList(items []*UdnResult) string {
 	output := ""
 
-	for item := items.Front(); item != nil; item = item.Next() {
-		item_str := GetResult(item.Value.(*UdnResult), type_string).(string)
+	for _, item := range items {
+		item_str := GetResult(item, type_string).(string)
 
 		if output != "" {
 			output += " -> "
@@ -42,8 +57,8 @@ func GetUdnResultValue(udn_result *UdnResult) interface{} {
 	result := udn_result.Result
 
 	// Recurse if this is a UdnResult as well, since they can be packed inside each other, this function opens the box and gets the real answer
-	if fmt.Sprintf("%T", result) == "*main.UdnResult" {
-		result = GetUdnResultValue(result.(*UdnResult))
+	if inner_result, ok := result.(*UdnResult); ok {
+		result = GetUdnResultValue(inner_result)
 	}
 
 	return result
@@ -58,65 +73,408 @@ func GetUdnResultString(udn_result *UdnResult) string {
 }
 
 
-// Parse a UDN string and return a hierarchy under UdnPart
-func ParseUdnString(db *sql.DB, udn_schema map[string]interface{}, udn_value_source string) *UdnPart {
+// Token types produced by the UDN lexer
+const (
+	token_end            = iota
+	token_text           = iota // Anything between delimiters: function names, dotted args, map key/values
+	token_string         = iota // Single quoted string, Value is the contents without the quotes
+	token_open_compound  = iota
+	token_close_compound = iota
+	token_open_list      = iota
+	token_close_list     = iota
+	token_open_map       = iota
+	token_close_map      = iota
+)
 
-	// First Stage
-	next_split := _SplitQuotes(db, udn_schema, udn_value_source)
+// Printable names for tokens, used in ParseError messages
+var udn_token_names = map[int]string{
+	token_end:            "end of statement",
+	token_text:           "text",
+	token_string:         "quoted string",
+	token_open_compound:  "'('",
+	token_close_compound: "')'",
+	token_open_list:      "'['",
+	token_close_list:     "']'",
+	token_open_map:       "'{'",
+	token_close_map:      "'}'",
+}
 
-	//UdnLog(udn_schema, "\nSplit: Quotes: AFTER: %v\n\n", next_split)
+// Sigils that are their own tokens, everything else is text or a quoted string
+var udn_token_sigils = map[byte]int{
+	'(': token_open_compound,
+	')': token_close_compound,
+	'[': token_open_list,
+	']': token_close_list,
+	'{': token_open_map,
+	'}': token_close_map,
+}
 
-	next_split = _SplitCompoundStatements(db, udn_schema, next_split)
+// Which closing token matches an opening token, and what part type the group becomes
+var udn_token_closers = map[int]int{
+	token_open_compound: token_close_compound,
+	token_open_list:     token_close_list,
+	token_open_map:      token_close_map,
+}
 
-	//UdnLog(udn_schema, "\nSplit: Compound: AFTER: %v\n\n", next_split)
+var udn_token_part_types = map[int]int{
+	token_open_compound: part_compound,
+	token_open_list:     part_list,
+	token_open_map:      part_map,
+}
 
-	next_split = _SplitStatementLists(db, udn_schema, next_split)
+type UdnToken struct {
+	Type  int
+	Value string
 
-	//UdnLog(udn_schema, "\nSplit: List: AFTER: %v\n\n", next_split)
+	// Byte offset of the first character of this token in the UDN source
+	Offset int
+}
 
-	// Forth Stage
-	next_split = _SplitStatementMaps(db, udn_schema, next_split)
+// Returned when UDN source cannot be parsed.  Offset is in bytes, Line and Column start at 1.
+type ParseError struct {
+	Source string `json:"source"`
 
-	//UdnLog(udn_schema, "\nSplit: Compound: Map: %v\n\n", next_split)
+	Offset int `json:"offset"`
+	Line   int `json:"line"`
+	Column int `json:"column"`
 
-	// Fifth Stage
-	next_split = _SplitStatementMapKeyValues(db, udn_schema, next_split)
+	Expected string `json:"expected"`
+	Found    string `json:"found"`
 
-	//UdnLog(udn_schema, "\nSplit: Compound: Map Key Values: %v\n\n", next_split)
+	// Extra context, such as where an unclosed group was opened
+	Message string `json:"message,omitempty"`
+}
 
-	// Put it into a structure now -- UdnPart
-	//
-	udn_start := CreateUdnPartsFromSplit_Initial(db, udn_schema, next_split)
+func NewParseError(source string, offset int, expected string, found string, message string) *ParseError {
+	line := 1 + strings.Count(source[:offset], "\n")
+	column := 1 + utf8.RuneCountInString(source[strings.LastIndex(source[:offset], "\n")+1:offset])
+
+	return &ParseError{
+		Source:   source,
+		Offset:   offset,
+		Line:     line,
+		Column:   column,
+		Expected: expected,
+		Found:    found,
+		Message:  message,
+	}
+}
+
+func (parse_error *ParseError) Error() string {
+	output := fmt.Sprintf("UDN parse error: line %d, column %d (offset %d): expected %s, found %s", parse_error.Line, parse_error.Column, parse_error.Offset, parse_error.Expected, parse_error.Found)
+
+	if parse_error.Message != "" {
+		output += ": " + parse_error.Message
+	}
+
+	return output
+}
+
+// Split UDN source into tokens.  Quoted strings are complete tokens, so sigils inside them are not special.  Quoted strings take the escapes: \' \\ \n \t \uXXXX, any other backslash is kept as-is.
+func LexUdn(udn_value_source string) ([]UdnToken, error) {
+	tokens := make([]UdnToken, 0)
+
+	text_start := 0
+
+	// Add any text we have collected since the last sigil
+	flush_text := func(position int) {
+		if position > text_start {
+			tokens = append(tokens, UdnToken{Type: token_text, Value: udn_value_source[text_start:position], Offset: text_start})
+		}
+	}
+
+	for position := 0; position < len(udn_value_source); position++ {
+		cur_char := udn_value_source[position]
+
+		if cur_char == '\'' {
+			flush_text(position)
+
+			value, string_end, err := _LexUdnQuotedString(udn_value_source, position)
+			if err != nil {
+				return nil, err
+			}
+
+			tokens = append(tokens, UdnToken{Type: token_string, Value: value, Offset: position})
+
+			position = string_end
+			text_start = position + 1
+		} else if token_type, ok := udn_token_sigils[cur_char]; ok {
+			flush_text(position)
+
+			tokens = append(tokens, UdnToken{Type: token_type, Value: string(cur_char), Offset: position})
+
+			text_start = position + 1
+		}
+	}
+
+	flush_text(len(udn_value_source))
+
+	tokens = append(tokens, UdnToken{Type: token_end, Offset: len(udn_value_source)})
+
+	return tokens, nil
+}
+
+// Read the quoted string starting at the quote at string_start.  Returns the unescaped value, and the offset of the closing quote.
+func _LexUdnQuotedString(udn_value_source string, string_start int) (string, int, error) {
+	value := make([]byte, 0)
+
+	for position := string_start + 1; position < len(udn_value_source); position++ {
+		cur_char := udn_value_source[position]
+
+		if cur_char == '\'' {
+			return string(value), position, nil
+		} else if cur_char != '\\' || position+1 == len(udn_value_source) {
+			value = append(value, cur_char)
+			continue
+		}
+
+		position++
+
+		switch udn_value_source[position] {
+		case '\'', '\\':
+			value = append(value, udn_value_source[position])
+		case 'n':
+			value = append(value, '\n')
+		case 't':
+			value = append(value, '\t')
+		case 'u':
+			if position+5 > len(udn_value_source) {
+				return "", 0, NewParseError(udn_value_source, position-1, "4 hex digits after \\u", udn_token_names[token_end], "")
+			}
+			code, err := strconv.ParseUint(udn_value_source[position+1:position+5], 16, 32)
+			if err != nil {
+				return "", 0, NewParseError(udn_value_source, position-1, "4 hex digits after \\u", fmt.Sprintf("%q", udn_value_source[position+1:position+5]), "")
+			}
+			value = append(value, string(rune(code))...)
+			position += 4
+		default:
+			// Not an escape, so keep the backslash, as UDN written before escapes existed has them (ex: regular expressions)
+			value = append(value, '\\', udn_value_source[position])
+		}
+	}
+
+	return "", 0, NewParseError(udn_value_source, len(udn_value_source), "\"'\"", udn_token_names[token_end], fmt.Sprintf("unclosed quoted string opened at offset %d", string_start))
+}
+
+// Recursive descent parser over the LexUdn tokens
+//
+//	statement := sequence END
+//	sequence  := { TEXT | STRING | group }
+//	group     := '(' sequence ')' | '[' sequence ']' | '{' sequence '}'
+//
+// Parts are built with AddFunction/AddChild as we go, and FinalParseProcessUdnParts then moves function arguments and map keys into place
+type udnParser struct {
+	source string
+	tokens []UdnToken
+
+	// Function pack namespaces of the engine we are parsing for, so "__ops.duty_shift_summary" is one function name
+	namespaces map[string]bool
+
+	position int
+}
 
-	////output := DescribeUdnPart(&udn_start)
-	////UdnLog(udn_schema, "\n===== 0 - Description of UDN Part:\n\n%s\n===== 0 - END\n", output)
+// Parse a UDN string and return a hierarchy under UdnPart
+func ParseUdnString(db *sql.DB, udn_schema map[string]interface{}, udn_value_source string) (*UdnPart, error) {
+	tokens, err := LexUdn(udn_value_source)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := udnParser{source: udn_value_source, tokens: tokens}
+	if engine := GetUdnEngine(udn_schema); engine != nil {
+		parser.namespaces = engine.function_namespaces
+	}
+
+	udn_start := NewUdnPart()
+	udn_start.Depth = 0
+	udn_start.Id = fmt.Sprintf("%p", &udn_start)
+
+	_, err = parser.ParseSequence(&udn_start, token_end, nil)
+	if err != nil {
+		return nil, err
+	}
 
 	// Put it into a structure now -- UdnPart
-	//
 	FinalParseProcessUdnParts(db, udn_schema, &udn_start)
 
 	//output := DescribeUdnPart(&udn_start)
 	//UdnLogLevel(nil, log_trace, "\n===== 1 - Description of UDN Part:\n\n%s\n===== 1 - END\n", output)
 
-	return &udn_start
+	return &udn_start, nil
+}
+
+// Parse tokens into udn_current until we reach the closing token.  The closing token is not consumed, so the caller can check it.  Returns the current part, which moves forward as functions are added.
+func (parser *udnParser) ParseSequence(udn_current *UdnPart, closing int, opening *UdnToken) (*UdnPart, error) {
+	for {
+		token := parser.tokens[parser.position]
+
+		switch token.Type {
+		case token_end:
+			if closing != token_end {
+				return nil, NewParseError(parser.source, token.Offset, udn_token_names[closing], udn_token_names[token_end], fmt.Sprintf("%s opened at offset %d is not closed", udn_token_names[opening.Type], opening.Offset))
+			}
+			return udn_current, nil
+
+		case token_text:
+			udn_current = _AddTextToken(udn_current, token.Value, parser.namespaces)
+
+		case token_string:
+			// Add single quotes using the HTML Double Quote mechanism, so we can still have single quotes
+			value := strings.Replace(token.Value, "&QUOTE;", "'", -1)
+			value = strings.Replace(value, "||QUOTE||", "'", -1)
+
+			udn_current.AddChild(part_string, value)
+
+		case token_open_compound, token_open_list, token_open_map:
+			// Groups are children of the current part.  Once they close, we continue adding to the current part again.
+			udn_group := udn_current.AddChild(udn_token_part_types[token.Type], token.Value)
+
+			parser.position++
+
+			_, err := parser.ParseSequence(udn_group, udn_token_closers[token.Type], &token)
+			if err != nil {
+				return nil, err
+			}
+
+		default:
+			// Closing tokens end our sequence, if they are the one we are waiting for
+			if token.Type == closing {
+				return udn_current, nil
+			}
+
+			if opening == nil {
+				return nil, NewParseError(parser.source, token.Offset, udn_token_names[closing], udn_token_names[token.Type], "closing sigil has no matching opening sigil")
+			}
+
+			return nil, NewParseError(parser.source, token.Offset, udn_token_names[closing], udn_token_names[token.Type], fmt.Sprintf("%s opened at offset %d is closed by the wrong sigil", udn_token_names[opening.Type], opening.Offset))
+		}
+
+		parser.position++
+	}
+}
+
+// Add a text token to the current part.  Text is split on dots (and commas, when it is not a function) into functions and item arguments, except after a function pack namespace in namespaces ("__ops.duty_shift_summary" is one function).  Returns the new current part.
+func _AddTextToken(udn_current *UdnPart, cur_item string, namespaces map[string]bool) *UdnPart {
+	// If this is a Underscore, make a new piece, unless this is the first one
+	if strings.HasPrefix(cur_item, "__") {
+		// Split any dots that may be connected to this still (we dont split on them before this), so we do it here and the part_item test, to complete that
+		dot_split_array := _JoinUdnFunctionNamespaces(strings.Split(cur_item, "."), namespaces)
+
+		// In the beginning, the udn_start (first part) is part_unknown, but we can use that for the first function, so we just set it here, instead of AddFunction()
+		if udn_current.PartType == part_unknown {
+			// Set the first function value and part
+			udn_current.Value = dot_split_array[0]
+			udn_current.PartType = part_function
+		} else {
+			// Else, this is not the first function, so add it to the current function
+			udn_current = udn_current.AddFunction(dot_split_array[0])
+		}
+
+		// Add any of the remaining dot_split_array as children
+		for _, doc_split_child := range dot_split_array[1:] {
+			if doc_split_child != "" {
+				if strings.HasPrefix(doc_split_child, "__") {
+					udn_current = udn_current.AddFunction(doc_split_child)
+				} else {
+					udn_current.AddChild(part_item, doc_split_child)
+				}
+			}
+		}
+	} else if cur_item != "" && cur_item != "." {
+		// If this is not a separator we are going to ignore, add it as Children (splitting on commas)
+		children_array := strings.Split(cur_item, ",")
+
+		// Add basic elements as children
+		for _, comma_child_item := range children_array {
+			// Floats have a "." in them, which would split them into 2 items.  Lists and maps dont take dotted args, so we keep them whole there.
+			if (udn_current.PartType == part_list || udn_current.PartType == part_map) && _IsUdnFloatItem(comma_child_item) {
+				udn_current.AddChild(part_item, comma_child_item)
+				continue
+			}
+
+			dot_children_array := _JoinUdnFunctionNamespaces(strings.Split(comma_child_item, "."), namespaces)
+
+			for _, new_child_item := range dot_children_array {
+				if strings.TrimSpace(new_child_item) != "" {
+					if strings.HasPrefix(new_child_item, "__") {
+						udn_current = udn_current.AddFunction(new_child_item)
+					} else {
+						udn_current.AddChild(part_item, new_child_item)
+					}
+				}
+			}
+		}
+	}
+
+	return udn_current
+}
+
+// Returns true if this is a float with a fraction, or a map "key:float" where the value has a fraction
+func _IsUdnFloatItem(item string) bool {
+	value := item[strings.LastIndexAny(item, ":=")+1:]
+	value = strings.TrimSpace(value)
+
+	return strings.Contains(value, ".") && udn_literal_float_regex.MatchString(value)
+}
+
+// Returns the typed value of an item literal, and its literal_* type.  Items that arent ints, floats, true, false or null are literal_none.
+func ParseUdnLiteral(value string) (interface{}, int) {
+	value = strings.TrimSpace(value)
+
+	switch value {
+	case "true":
+		return true, literal_bool
+	case "false":
+		return false, literal_bool
+	case "null":
+		return nil, literal_null
+	}
+
+	if udn_literal_int_regex.MatchString(value) {
+		if result, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return result, literal_int
+		}
+	}
+
+	if udn_literal_float_regex.MatchString(value) {
+		if result, err := strconv.ParseFloat(value, 64); err == nil {
+			return result, literal_float
+		}
+	}
+
+	return nil, literal_none
+}
+
+// The value a parsed part gives as an argument: the typed literal for items, otherwise the text
+func UdnPartValue(part *UdnPart) interface{} {
+	if part.ValueFinalType != literal_none {
+		return part.ValueFinal
+	}
+
+	return part.Value
 }
 
 // Take the partially created UdnParts, and finalize the parsing, now that it has a hierarchical structure.  Recusive function
 func FinalParseProcessUdnParts(db *sql.DB, udn_schema map[string]interface{}, part *UdnPart) {
 
-	//UdnLogLevel(nil, log_trace, "\n** Final Parse **:  Type: %d   Value: %s   Children: %d  Next: %v\n", part.PartType, part.Value, part.Children.Len(), part.NextUdnPart)
+	//UdnLogLevel(nil, log_trace, "\n** Final Parse **:  Type: %d   Value: %s   Children: %d  Next: %v\n", part.PartType, part.Value, len(part.Children), part.NextUdnPart)
+
+	// Items get their typed literal values, so functions receive ints, floats, bools and nil instead of strings
+	if part.PartType == part_item {
+		part.ValueFinal, part.ValueFinalType = ParseUdnLiteral(part.Value)
+	}
 
 	// If this is a map component, make a new Children list with our Map Keys
 	if part.PartType == part_map {
-		new_children := list.New()
+		new_children := make([]*UdnPart, 0, len(part.Children))
 
 		//fmt.Printf("\n\nMap Part:\n%s\n\n", DescribeUdnPart(part))
 
 		next_child_is_value := false
 		next_child_is_assignment := false
 
-		for child := part.Children.Front(); child != nil; child = child.Next() {
-			cur_child := *child.Value.(*UdnPart)
+		for _, child := range part.Children {
+			cur_child := *child
 
 			// If this child isn't the value of the last Map Key, then we are expecting a new Map Key, possibly a value
 			if next_child_is_assignment == true {
@@ -138,7 +496,7 @@ func FinalParseProcessUdnParts(db *sql.DB, udn_schema map[string]interface{}, pa
 				map_key_part.ParentUdnPart = part
 
 				// Add to the new Children
-				new_children.PushBack(&map_key_part)
+				new_children = append(new_children, &map_key_part)
 
 				if len(map_key_split) == 1 {
 					// We only had the key, so the next child is the assignment
@@ -153,20 +511,20 @@ func FinalParseProcessUdnParts(db *sql.DB, udn_schema map[string]interface{}, pa
 					key_value_part.Depth = map_key_part.Depth + 1
 					key_value_part.ParentUdnPart = &map_key_part
 					key_value_part.Value = map_key_split[1]
-					map_key_part.Children.PushBack(&key_value_part)
+					map_key_part.Children = append(map_key_part.Children, &key_value_part)
 				}
 			} else {
 				// Get the last Map Key in new_children
-				last_map_key := new_children.Back().Value.(*UdnPart)
+				last_map_key := new_children[len(new_children)-1]
 
 				// Add this UdnPart to the Map Key's children
-				last_map_key.Children.PushBack(&cur_child)
+				last_map_key.Children = append(last_map_key.Children, &cur_child)
 
 				// Set this back to false, as we processed this already
 				next_child_is_value = false
 			}
 
-			//new_children.PushBack(&cur_child)
+			//new_children = append(new_children, &cur_child)
 		}
 
 		// Assign the new children list to be our Map's children
@@ -194,61 +552,57 @@ func FinalParseProcessUdnParts(db *sql.DB, udn_schema map[string]interface{}, pa
 			// Once this is true, start adding new functions and arguments into the NextUdnPart list
 			found_new_function := false
 
-			// New functions we will add after removing elements, into the NextUdnPart chain
-			new_function_list := list.New()
-			remove_children := list.New()
+			// New functions we will add into the NextUdnPart chain, and the children that stay on this part
+			new_function_list := make([]*UdnPart, 0)
+			remaining_children := make([]*UdnPart, 0, len(part.Children))
 
-			// Current new function (this one will always be replaced before being used, but nil wouldnt type cast properly)
-			cur_udn_function := UdnPart{}
+			// Current new function, that is collecting the arguments after it
+			var cur_udn_function *UdnPart
 
-			for child := part.Children.Front(); child != nil; child = child.Next() {
-				if strings.HasPrefix(child.Value.(*UdnPart).Value, "__") {
+			for _, child := range part.Children {
+				// Quoted strings are always arguments, so UDN can be passed as a string (ex: __execute.'__input.1')
+				if child.PartType == part_item && strings.HasPrefix(child.Value, "__") {
 					// All children from now on will be either a new NextUdnPart, or will be args to those functions
 					found_new_function = true
 
 					// Create our new function UdnPart here
 					new_udn_function := NewUdnPart()
-					new_udn_function.Value = child.Value.(*UdnPart).Value
+					new_udn_function.Value = child.Value
 					new_udn_function.Depth = part.Depth
 					new_udn_function.PartType = part_function
-					new_udn_function.Children = child.Value.(*UdnPart).Children
+					new_udn_function.Children = child.Children
 
-					new_function_list.PushBack(&new_udn_function)
-					remove_children.PushBack(child)
+					new_function_list = append(new_function_list, &new_udn_function)
 
-					cur_udn_function = new_udn_function
+					cur_udn_function = &new_udn_function
 
 					//UdnLog(udn_schema, "Adding to new_function_list: %s\n", new_udn_function.Value)
 
-				} else if child.Value.(*UdnPart).PartType == part_compound {
+				} else if child.PartType == part_compound {
 					//SKIP: If this is a compount function, we dont need to do anything...
 					//UdnLog(udn_schema, "-=-=-= Found Compound!\n -=-=-=-\n")
+					remaining_children = append(remaining_children, child)
 				} else if found_new_function == true {
 					new_udn := NewUdnPart()
-					new_udn.Value = child.Value.(*UdnPart).Value
-					new_udn.ValueFinal = child.Value.(*UdnPart).ValueFinal
+					new_udn.Value = child.Value
+					new_udn.ValueFinal = child.ValueFinal
+					new_udn.ValueFinalType = child.ValueFinalType
 					new_udn.Depth = cur_udn_function.Depth + 1
-					new_udn.PartType = child.Value.(*UdnPart).PartType
-					new_udn.ParentUdnPart = &cur_udn_function
-					new_udn.Children = child.Value.(*UdnPart).Children
+					new_udn.PartType = child.PartType
+					new_udn.ParentUdnPart = cur_udn_function
+					new_udn.Children = child.Children
 
 					// Else, if we are taking
-					cur_udn_function.Children.PushBack(&new_udn)
-					remove_children.PushBack(child)
+					cur_udn_function.Children = append(cur_udn_function.Children, &new_udn)
 
 					//UdnLog(udn_schema, "  Adding new function Argument/Child: %s\n", new_udn.Value)
+				} else {
+					remaining_children = append(remaining_children, child)
 				}
 			}
 
-			// Remove these children from the current part.Children
-			for child := remove_children.Front(); child != nil; child = child.Next() {
-
-				//UdnLog(udn_schema, "Removing: %v\n", child.Value.(*list.Element).Value)
-
-				_ = part.Children.Remove(child.Value.(*list.Element))
-				//removed := part.Children.Remove(child.Value.(*list.Element))
-				//UdnLog(udn_schema, "  Removed: %v\n", removed)
-			}
+			// Remove the children we moved to the new functions from the current part.Children
+			part.Children = remaining_children
 
 			// Find the last UdnPart, that doesnt have a NextUdnPart, so we can add all the functions onto this
 			last_udn_part := part
@@ -266,26 +620,23 @@ func FinalParseProcessUdnParts(db *sql.DB, udn_schema map[string]interface{}, pa
 			//UdnLog(udn_schema, "Elements in new_function_list: %d\n", new_function_list.Len())
 
 			// Add all the functions to the NextUdnPart, starting from last_udn_part
-			for new_function := new_function_list.Front(); new_function != nil; new_function = new_function.Next() {
-				// Get the UdnPart for the next function
-				add_udn_function := *new_function.Value.(*UdnPart)
-
+			for _, add_udn_function := range new_function_list {
 				// Set at the next item, and connect parrent
-				last_udn_part.NextUdnPart = &add_udn_function
+				last_udn_part.NextUdnPart = add_udn_function
 				add_udn_function.ParentUdnPart = last_udn_part
 
 				//UdnLog(udn_schema, "Added NextUdnFunction: %s\n", add_udn_function.Value)
 
 				// Update our new last UdnPart, which continues the Next trail
-				last_udn_part = &add_udn_function
+				last_udn_part = add_udn_function
 			}
 		}
 
 	}
 
 	// Process all this part's children
-	for child := part.Children.Front(); child != nil; child = child.Next() {
-		FinalParseProcessUdnParts(db, udn_schema, child.Value.(*UdnPart))
+	for _, child := range part.Children {
+		FinalParseProcessUdnParts(db, udn_schema, child)
 	}
 
 	// Process any next parts (more functions)
@@ -294,334 +645,6 @@ func FinalParseProcessUdnParts(db *sql.DB, udn_schema map[string]interface{}, pa
 	}
 }
 
-// Take partially split text, and start putting it into the structure we need
-func CreateUdnPartsFromSplit_Initial(db *sql.DB, udn_schema map[string]interface{}, source_array []string) UdnPart {
-	udn_start := NewUdnPart()
-	udn_current := &udn_start
-
-	// We start at depth zero, and descend with sub-statements, lists, maps, etc
-	udn_current.Depth = 0
-
-	is_open_quote := false
-
-	//UdnLogLevel(nil, log_trace, "Create UDN Parts: Initial: %v\n\n", source_array)
-
-	// Traverse into the data, and start storing everything
-	for _, cur_item := range source_array {
-		//UdnLogLevel(nil, log_trace, "  Create UDN Parts: UDN Current: %-20s    Cur Item: %v\n", udn_current.Value, cur_item)
-
-		// If we are in a string, and we are not about to end it, keep appending to the previous element
-		if is_open_quote && cur_item != "'" {
-			udn_current.Value += cur_item
-		} else {
-			// We are not in a currently open string, or are about to end it, so do normal processing
-
-			// If this is a Underscore, make a new piece, unless this is the first one
-			if strings.HasPrefix(cur_item, "__") {
-
-				// Split any dots that may be connected to this still (we dont split on them before this), so we do it here and the part_item test, to complete that
-				dot_split_array := strings.Split(cur_item, ".")
-
-				// In the beginning, the udn_start (first part) is part_unknown, but we can use that for the first function, so we just set it here, instead of AddFunction()
-				if udn_current.PartType == part_unknown {
-					// Set the first function value and part
-					udn_current.Value = dot_split_array[0]
-					udn_current.PartType = part_function
-					// Manually set this first one, as it isnt done through AddFunction()
-					udn_current.Id = fmt.Sprintf("%p", &udn_current)
-					//UdnLog(udn_schema, "Create UDN: Function Start: %s\n", cur_item)
-				} else {
-					// Else, this is not the first function, so add it to the current function
-					udn_current = udn_current.AddFunction(dot_split_array[0])
-				}
-
-				// Add any of the remaining dot_split_array as children
-				for dot_count, doc_split_child := range dot_split_array {
-					// Skip the 1st element, which is the function name we stored above
-					if dot_count >= 1 {
-						if doc_split_child != "" {
-							if strings.HasPrefix(doc_split_child, "__") {
-								udn_current = udn_current.AddFunction(doc_split_child)
-							} else {
-								udn_current.AddChild(part_item, doc_split_child)
-							}
-						}
-					}
-				}
-
-			} else if cur_item == "'" {
-				// Enable and disable our quoting, it is simple enough we can just start/stop it.  Lists, maps, and subs cant be done this way.
-				if !is_open_quote {
-					is_open_quote = true
-					udn_current = udn_current.AddChild(part_string, "")
-					//UdnLog(udn_schema, "Create UDN: Starting Quoted String\n")
-				} else if is_open_quote {
-					is_open_quote = false
-
-					// Add single quotes using the HTML Double Quote mechanism, so we can still have single quotes
-					udn_current.Value = strings.Replace(udn_current.Value, "&QUOTE;", "'", -1)
-					udn_current.Value = strings.Replace(udn_current.Value, "||QUOTE||", "'", -1)
-
-					// Reset to before we were a in string
-					udn_current = udn_current.ParentUdnPart
-					//UdnLog(udn_schema, "Create UDN: Closing Quoted String\n")
-				}
-			} else if cur_item == "(" {
-				//UdnLog(udn_schema, "Create UDN: Starting Compound\n")
-
-				////TODO(g): Is this the correct way to do this?  Im not sure it is...  Why is it different than other children?  Add as a child, then become the current...
-				//// Get the last child, which we will become a child of (because we are on argument) -- Else, we are already in our udn_current...
-				//if udn_current.Children.Len() > 0 {
-				//	last_udn_current := udn_current.Children.Back().Value.(*UdnPart)
-				//	// Set the last child to be the current item, and we are good!
-				//	udn_current = last_udn_current
-				//}
-
-				// Make this compound current, so we continue to add into it, until it closes
-				udn_current = udn_current.AddChild(part_compound, cur_item)
-
-			} else if cur_item == ")" {
-				//UdnLog(udn_schema, "Create UDN: Closing Compound\n")
-
-				// Walk backwards until we are done
-				done := false
-				for done == false {
-					if udn_current.ParentUdnPart == nil {
-						// If we have no more parents, we are done because there is nothing left to come back from
-						//TODO(g): This could be invalid grammar, need to test for that (extra closing sigils)
-						done = true
-						//UdnLog(udn_schema, "COMPOUND: No more parents, finished\n")
-					} else if udn_current.PartType == part_compound {
-						// Else, if we are already currently on the map, just move off once
-						udn_current = udn_current.ParentUdnPart
-
-						done = true
-						//UdnLog(udn_schema, "COMPOUND: Moved out of the Compound\n")
-					} else {
-						//UdnLog(udn_schema, "COMPOUND: Updating UdnPart to part: %v --> %v\n", udn_current, *udn_current.ParentUdnPart)
-						udn_current = udn_current.ParentUdnPart
-						//UdnLog(udn_schema, "  Walking Up the Compound:  Depth: %d\n", udn_current.Depth)
-					}
-
-				}
-			} else if cur_item == "[" {
-				// Make this list current, so we continue to add into it, until it closes
-				udn_current = udn_current.AddChild(part_list, cur_item)
-
-			} else if cur_item == "]" {
-				//UdnLog(udn_schema, "Create UDN: Closing List\n")
-
-				// Walk backwards until we are done
-				done := false
-				for done == false {
-					if udn_current.ParentUdnPart == nil {
-						// If we have no more parents, we are done because there is nothing left to come back from
-						//TODO(g): This could be invalid grammar, need to test for that (extra closing sigils)
-						done = true
-						//UdnLog(udn_schema, "LIST: No more parents, finished\n")
-					} else if udn_current.PartType == part_list {
-						// Else, if we are already currently on the map, just move off once
-						udn_current = udn_current.ParentUdnPart
-
-						done = true
-						//UdnLog(udn_schema, "LIST: Moved out of the List\n")
-					} else {
-						//UdnLog(udn_schema, "LIST: Updating UdnPart to part: %v --> %v\n", udn_current, *udn_current.ParentUdnPart)
-						udn_current = udn_current.ParentUdnPart
-						//UdnLog(udn_schema, "  Walking Up the List:  Depth: %d\n", udn_current.Depth)
-					}
-
-				}
-			} else if cur_item == "{" {
-				// Make this list current, so we continue to add into it, until it closes
-				udn_current = udn_current.AddChild(part_map, cur_item)
-
-			} else if cur_item == "}" {
-				//UdnLog(udn_schema, "Create UDN: Closing Map\n")
-
-				// Walk backwards until we are done
-				done := false
-				for done == false {
-					if udn_current.ParentUdnPart == nil {
-						// If we have no more parents, we are done because there is nothing left to come back from
-						//TODO(g): This could be invalid grammar, need to test for that (extra closing sigils)
-						done = true
-						UdnLog(udn_schema, "MAP: No more parents, finished\n")
-					} else if udn_current.PartType == part_map {
-						// Else, if we are already currently on the map, just move off once
-						udn_current = udn_current.ParentUdnPart
-
-						done = true
-						//UdnLog(udn_schema, "MAP: Moved out of the Map\n")
-					} else {
-						//UdnLog(udn_schema, "MAP: Updating UdnPart to part: %v --> %v\n", udn_current, *udn_current.ParentUdnPart)
-						udn_current = udn_current.ParentUdnPart
-						//UdnLog(udn_schema, "  Walking Up the Map:  Depth: %d\n", udn_current.Depth)
-					}
-				}
-			} else {
-				// If this is not a separator we are going to ignore, add it as Children (splitting on commas)
-				if cur_item != "" && cur_item != "." {
-					children_array := strings.Split(cur_item, ",")
-
-					// Add basic elements as children
-					for _, comma_child_item := range children_array {
-						dot_children_array := strings.Split(comma_child_item, ".")
-
-						for _, new_child_item := range dot_children_array {
-							if strings.TrimSpace(new_child_item) != "" {
-								//udn_current.AddChild(part_item, new_child_item)
-
-								if strings.HasPrefix(new_child_item, "__") {
-									udn_current = udn_current.AddFunction(new_child_item)
-								} else {
-									udn_current.AddChild(part_item, new_child_item)
-								}
-
-							}
-						}
-					}
-				}
-			}
-		}
-
-	}
-
-	//UdnLogLevel(nil, log_trace, "Finished Create UDN Parts: Initial\n\n")
-
-	return udn_start
-}
-
-func _SplitStringAndKeepSeparator(value string, separator string) []string {
-	split_array := strings.Split(value, separator)
-
-	final_array := make([]string, (len(split_array)*2)-1)
-
-	for pos, item := range split_array {
-		cur_pos := pos * 2
-
-		final_array[cur_pos] = item
-
-		if cur_pos+1 < len(final_array) {
-			final_array[cur_pos+1] = separator
-		}
-	}
-
-	// Fix the stupid trailing empty item, if it exists.  Will just increase with splits.
-	if final_array[len(final_array)-1] == "" {
-		final_array = final_array[0 : len(final_array)-1]
-	}
-
-	//UdnLog(udn_schema, "Split: %s  Sep: %s  Result: %s\n", value, separator, final_array)
-
-	return final_array
-}
-
-func _SplitStringArray(value_array []string, separator string) []string {
-	total_count := 0
-
-	work_list := list.New()
-
-	// Split all the string arrays, keep track of the new total, and put them into the work_list
-	for _, item := range value_array {
-		split_array := _SplitStringAndKeepSeparator(item, separator)
-
-		total_count += len(split_array)
-
-		work_list.PushBack(split_array)
-	}
-
-	// Our final array
-	final_array := make([]string, total_count)
-
-	// Iterate over the work list, and add them to our final array by index
-	append_count := 0
-
-	for item := work_list.Front(); item != nil; item = item.Next() {
-		cur_string_array := item.Value.([]string)
-
-		for _, item_string := range cur_string_array {
-			final_array[append_count] = item_string
-
-			append_count++
-		}
-	}
-
-	return final_array
-}
-
-// FIRST STAGE: Recursive function, tracked by depth int.  Inserts sequentially into next_processing_udn_list (list[string]), each of the compound nested items, starting with the inner-most first, and then working out, so that all compound statements can be sequentially processed, with the inner-most getting processed before their immediate next-outer layer, which is the proper order
-func _SplitQuotes(db *sql.DB, udn_schema map[string]interface{}, udn_value string) []string {
-	//UdnLog(udn_schema, "\nSplit: Quotes: %v\n\n", udn_value)
-
-	split_result := _SplitStringAndKeepSeparator(udn_value, "'")
-
-	return split_result
-}
-
-// SECOND STAGE: Recursive function, tracked by depth int.  Inserts sequentially into next_processing_udn_list (list[string]), each of the compound nested items, starting with the inner-most first, and then working out, so that all compound statements can be sequentially processed, with the inner-most getting processed before their immediate next-outer layer, which is the proper order
-func _SplitCompoundStatements(db *sql.DB, udn_schema map[string]interface{}, source_array []string) []string {
-	//UdnLog(udn_schema, "\nSplit: Compound: %v\n\n", source_array)
-
-	// Split Open Compound
-	split_result := _SplitStringArray(source_array, "(")
-
-	// Split Close Compound
-	split_result = _SplitStringArray(split_result, ")")
-
-	return split_result
-}
-
-// THIRD STAGE: Linear function, iterating over the THIRD STAGE's list[string], list values are collected as argument variables for their respective UDN processing sections
-func _SplitStatementLists(db *sql.DB, udn_schema map[string]interface{}, source_array []string) []string {
-	//UdnLog(udn_schema, "\nSplit: Lists: %v\n\n", source_array)
-
-	// Split Open Compound
-	split_result := _SplitStringArray(source_array, "[")
-
-	// Split Close Compound
-	split_result = _SplitStringArray(split_result, "]")
-
-	return split_result
-}
-
-// FOURTH STAGE: Linear function, iterating over the SECOND STAGE's list[string], map values are collected as argument variables for their respective UDN processing sections
-func _SplitStatementMaps(db *sql.DB, udn_schema map[string]interface{}, source_array []string) []string {
-	//UdnLog(udn_schema, "\nSplit: Maps: %v\n\n", source_array)
-
-	// Split Open Compound
-	split_result := _SplitStringArray(source_array, "{")
-
-	// Split Close Compound
-	split_result = _SplitStringArray(split_result, "}")
-
-	return split_result
-}
-
-// FIFTH STAGE: Linear function, iterating over the THIRD STAGE's list[string], list values are collected as argument variables for their respective UDN processing sections
-func _SplitStatementMapKeyValues(db *sql.DB, udn_schema map[string]interface{}, source_array []string) []string {
-	//UdnLog(udn_schema, "\nSplit: Map Key Values: %v\n\n", source_array)
-
-	return source_array
-}
-
-// SIXTH STAGE: Linear function, iterating over the FIRST STAGE's list[string] sequence of compound-nested-items.  This populates a new list[string] which now includes the split items at each compound-layer, which means we have a full set of UDN statements that will be processed at the end of this function
-func _SplitStatementItems(db *sql.DB, udn_schema map[string]interface{}, source_array []string) []string {
-	//UdnLog(udn_schema, "\nSplit: Items: %v\n\n", source_array)
-
-	// Split Open Compound
-	split_result := _SplitStringArray(source_array, ".")
-
-	return split_result
-}
-
-// SEVENTH STAGE: Linear function, iterating over the THIRD STAGE's list[string], list values are collected as argument variables for their respective UDN processing sections
-func _DepthTagList(db *sql.DB, udn_schema map[string]interface{}, source_array []string) []string {
-	//UdnLog(udn_schema, "\nSplit: Lists: %v\n\n", source_array)
-
-	return source_array
-}
-
 // Need to pass in all the Widget data as well, so we have it as a pool of data to be accessed from UDN
 
 // Cookies, Headers, URI Params, JSON Body Payload, etc, must be passed in also, so we have access to all of it
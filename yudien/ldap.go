@@ -23,11 +23,16 @@ type LdapUser struct {
 }
 
 func LdapLogin(username string, password string) LdapUser {
+	return LdapLoginConfig(Ldap, username, password)
+}
+
+// Login with an engine's LDAP server
+func LdapLoginConfig(ldap_config *LdapConfig, username string, password string) LdapUser {
 	// Set up return value, we can return any time
 	ldap_user := LdapUser{}
 	ldap_user.Username = username
 
-	ldapHost := fmt.Sprintf("%s:%d", Ldap.Host, Ldap.Port)
+	ldapHost := fmt.Sprintf("%s:%d", ldap_config.Host, ldap_config.Port)
 	UdnLogLevel(nil, log_info,"LDAP: %s\n", ldapHost)
 
 	l, err := ldap.Dial("tcp", ldapHost)
@@ -41,8 +46,8 @@ func LdapLogin(username string, password string) LdapUser {
 	UdnLogLevel(nil, log_info, "Dial complete\n")
 
 	sbr := ldap.SimpleBindRequest{
-		Username: Ldap.LoginDN,
-		Password: Ldap.Password,
+		Username: ldap_config.LoginDN,
+		Password: ldap_config.Password,
 	}
 	_, err = l.SimpleBind(&sbr)
 	if err != nil {
@@ -61,7 +66,7 @@ func LdapLogin(username string, password string) LdapUser {
 	//TODO(g): Get these from JSON or something?  Not sure...  Probably JSON.  This is all ghetto, but it keeps things mostly anonymous and flexible
 	attributes := []string{"cn", "gidNumber", "givenName", "homeDirectory", "loginShell", "mail", "sn", "uid", "uidNumber", "userPassword"}
 
-	sr := ldap.NewSearchRequest(Ldap.UserSearch, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false, filter, attributes, nil)
+	sr := ldap.NewSearchRequest(ldap_config.UserSearch, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false, filter, attributes, nil)
 	user_result, err := l.Search(sr)
 	if err != nil {
 		ldap_user.IsAuthenticated = false
@@ -97,7 +102,7 @@ func LdapLogin(username string, password string) LdapUser {
 	//TODO(g): Get these from JSON or something?  Not sure...  Probably JSON.  This is all ghetto, but it keeps things mostly anonymous and flexible
 	attributes = []string{"cn", "gidNumber", "memberUid"}
 
-	sr = ldap.NewSearchRequest(Ldap.GroupSearch, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false, filter, attributes, nil)
+	sr = ldap.NewSearchRequest(ldap_config.GroupSearch, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false, filter, attributes, nil)
 	group_result, err := l.Search(sr)
 	if err != nil {
 		ldap_user.IsAuthenticated = false
@@ -126,7 +131,7 @@ func LdapLogin(username string, password string) LdapUser {
 	UdnLogLevel(nil, log_info,"User: %s  Groups: %v\n", username, user_groups)
 
 	// Testing password
-	err = l.Bind(fmt.Sprintf("uid=%s,%s", username, Ldap.UserSearch), password)
+	err = l.Bind(fmt.Sprintf("uid=%s,%s", username, ldap_config.UserSearch), password)
 	if err != nil {
 		ldap_user.IsAuthenticated = false
 		ldap_user.Error = err.Error()
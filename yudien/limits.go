@@ -0,0 +1,231 @@
+package yudien
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Limits on how much work a request's UDN can do, so a bad stored function cant loop or recurse forever, or hang on a request.  0 is unlimited.
+type UdnExecutionLimits struct {
+	// Wall clock time from the start of the execution.  Outstanding HTTP and SQL calls are cancelled at the deadline.
+	Timeout time.Duration `json:"timeout"`
+
+	// Functions executed, in total, including arguments and everything executed concurrently
+	MaxSteps int64 `json:"max_steps"`
+
+	// Nested calls into other UDN: __call, __function, __execute and __custom_code
+	MaxCallDepth int `json:"max_call_depth"`
+}
+
+// Limits of new engines (Engine.Limits).  Unlimited, except for the call depth, as unbounded recursion will crash the process.
+var DefaultUdnExecutionLimits = UdnExecutionLimits{MaxCallDepth: 200}
+
+const (
+	execution_limit_deadline   = "deadline"
+	execution_limit_cancelled  = "cancelled"
+	execution_limit_steps      = "steps"
+	execution_limit_call_depth = "call_depth"
+)
+
+// The budget of a request's execution, kept in udn_schema["execution_budget"].  Concurrent blocks share it (their udn_schema copies point to the same budget), so the limits are for all the work together.
+// Every top-level ProcessSchemaUDNSet, ProcessUDN and ProcessSingleUDNTarget gets a new budget with the engine's Limits, unless StartUdnExecution started one for it.  Nested executions (ex: __function, __execute, concurrent blocks) use their caller's.
+//NOTE(g): Once a limit is exceeded, the execution is aborted: every function after it fails with the same error, and __try cannot catch it.
+type UdnExecutionBudget struct {
+	Context context.Context
+	Limits  UdnExecutionLimits
+
+	// Zero if there is no Timeout
+	Deadline time.Time
+
+	steps int64
+
+	// Executions using this budget that havent returned, and whether one has started.  A used budget isnt used again once all its executions have returned.
+	executions int32
+	used       int32
+
+	// Made once, for all the Dataman calls of the execution
+	dataman_context      context.Context
+	dataman_cancel       context.CancelFunc
+	dataman_context_once sync.Once
+
+	abort_lock    sync.Mutex
+	abort_limit   string
+	abort_message string
+}
+
+// Start the execution budget for the next top-level execution with udn_schema.  ctx cancels the execution (ex: the client went away), and limits.Timeout starts now.
+func StartUdnExecution(ctx context.Context, udn_schema map[string]interface{}, limits UdnExecutionLimits) *UdnExecutionBudget {
+	budget := &UdnExecutionBudget{Context: ctx, Limits: limits}
+
+	if limits.Timeout > 0 {
+		budget.Deadline = time.Now().Add(limits.Timeout)
+	}
+
+	udn_schema["execution_budget"] = budget
+
+	return budget
+}
+
+// Returns the budget for this execution, starting one with the engine's Limits if there isnt one (ex: ExecuteUdn is called directly).  nil if there is no udn_schema.
+func GetUdnExecutionBudget(udn_schema map[string]interface{}) *UdnExecutionBudget {
+	if udn_schema == nil {
+		return nil
+	}
+
+	budget, ok := udn_schema["execution_budget"].(*UdnExecutionBudget)
+	if !ok {
+		budget = StartUdnExecution(context.Background(), udn_schema, GetUdnEngine(udn_schema).Limits)
+		budget.used = 1
+	}
+
+	return budget
+}
+
+// Start the budget of a top-level execution cancelled with ctx, for the ProcessSchemaUDNSetContext entry points.  Nested executions keep their caller's budget, which is already cancelled with the caller.
+func _StartUdnExecutionContext(ctx context.Context, udn_schema map[string]interface{}) {
+	if budget, ok := udn_schema["execution_budget"].(*UdnExecutionBudget); ok && atomic.LoadInt32(&budget.executions) > 0 {
+		return
+	}
+
+	StartUdnExecution(ctx, udn_schema, GetUdnEngine(udn_schema).Limits)
+}
+
+// Begin an execution with udn_schema.  A top-level execution gets a new budget, unless StartUdnExecution started one for it, so an earlier aborted or timed out execution doesnt fail this one.  Every _BeginUdnExecution must have an _EndUdnExecution.
+func _BeginUdnExecution(udn_schema map[string]interface{}) *UdnExecutionBudget {
+	budget, ok := udn_schema["execution_budget"].(*UdnExecutionBudget)
+	if !ok || (atomic.LoadInt32(&budget.executions) == 0 && atomic.LoadInt32(&budget.used) == 1) {
+		budget = StartUdnExecution(context.Background(), udn_schema, GetUdnEngine(udn_schema).Limits)
+	}
+
+	atomic.AddInt32(&budget.executions, 1)
+	atomic.StoreInt32(&budget.used, 1)
+
+	return budget
+}
+
+func _EndUdnExecution(budget *UdnExecutionBudget) {
+	atomic.AddInt32(&budget.executions, -1)
+}
+
+// Returns a context for HTTP and SQL calls, which is cancelled with the execution, or at its deadline.  Call the cancel function when the call is done.
+func UdnExecutionContext(udn_schema map[string]interface{}) (context.Context, context.CancelFunc) {
+	budget := GetUdnExecutionBudget(udn_schema)
+	if budget == nil {
+		return context.WithCancel(context.Background())
+	}
+
+	if budget.Deadline.IsZero() {
+		return context.WithCancel(budget.Context)
+	}
+
+	return context.WithDeadline(budget.Context, budget.Deadline)
+}
+
+// Returns the context for Dataman calls (the "context" Dataman option), which is cancelled with the execution, or at its deadline.  Unlike UdnExecutionContext there is nothing to cancel after each call, so it is made once, and its deadline releases it.
+func UdnExecutionDatamanContext(udn_schema map[string]interface{}) context.Context {
+	budget := GetUdnExecutionBudget(udn_schema)
+	if budget == nil {
+		return context.Background()
+	}
+
+	budget.dataman_context_once.Do(func() {
+		if budget.Deadline.IsZero() {
+			budget.dataman_context = budget.Context
+		} else {
+			budget.dataman_context, budget.dataman_cancel = context.WithDeadline(budget.Context, budget.Deadline)
+		}
+	})
+
+	return budget.dataman_context
+}
+
+// Returns the limit ("deadline", "cancelled", "steps", "call_depth") that aborted the execution, or "" if it hasnt been aborted
+func UdnExecutionAborted(udn_schema map[string]interface{}) string {
+	budget := GetUdnExecutionBudget(udn_schema)
+	if budget == nil {
+		return ""
+	}
+
+	budget.abort_lock.Lock()
+	defer budget.abort_lock.Unlock()
+
+	return budget.abort_limit
+}
+
+func (budget *UdnExecutionBudget) _AbortMessage() string {
+	budget.abort_lock.Lock()
+	defer budget.abort_lock.Unlock()
+
+	return budget.abort_message
+}
+
+// Abort the execution.  The first limit exceeded is kept, so every function after it fails with the same error.  Returns the error message.
+func (budget *UdnExecutionBudget) _Abort(limit string, message string) string {
+	budget.abort_lock.Lock()
+	defer budget.abort_lock.Unlock()
+
+	if budget.abort_limit == "" {
+		budget.abort_limit = limit
+		budget.abort_message = message
+	}
+
+	return budget.abort_message
+}
+
+// Count a function step, and check the deadline and cancellation.  Returns an error message if the execution is over its budget (or was already aborted), or "".  Called by ExecuteUdnPart for every function.
+func CheckUdnExecutionBudget(udn_schema map[string]interface{}) string {
+	budget := GetUdnExecutionBudget(udn_schema)
+	if budget == nil {
+		return ""
+	}
+
+	steps := atomic.AddInt64(&budget.steps, 1)
+
+	if message := budget._AbortMessage(); message != "" {
+		return message
+	}
+
+	if budget.Limits.MaxSteps > 0 && steps > budget.Limits.MaxSteps {
+		return budget._Abort(execution_limit_steps, fmt.Sprintf("Execution Limit: More than %d steps", budget.Limits.MaxSteps))
+	}
+
+	if !budget.Deadline.IsZero() && time.Now().After(budget.Deadline) {
+		return budget._Abort(execution_limit_deadline, fmt.Sprintf("Execution Limit: Deadline exceeded, after %s", budget.Limits.Timeout))
+	}
+
+	if err := budget.Context.Err(); err != nil {
+		return budget._Abort(execution_limit_cancelled, fmt.Sprintf("Execution Limit: Cancelled: %s", err))
+	}
+
+	return ""
+}
+
+// Start a nested call into other UDN.  Returns an error message if it would be deeper than MaxCallDepth (which aborts the execution), or "".  Every PushUdnCallDepth that succeeds must have a PopUdnCallDepth, when the call returns.
+//NOTE(g): The depth is kept in udn_data["__call_depth"], not the budget, as concurrent blocks and workers each have their own call depth
+func PushUdnCallDepth(udn_schema map[string]interface{}, udn_data map[string]interface{}) string {
+	call_depth, _ := udn_data["__call_depth"].(int)
+	call_depth++
+
+	budget := GetUdnExecutionBudget(udn_schema)
+	if budget != nil && budget.Limits.MaxCallDepth > 0 && call_depth > budget.Limits.MaxCallDepth {
+		return budget._Abort(execution_limit_call_depth, fmt.Sprintf("Execution Limit: Calls nested more than %d deep", budget.Limits.MaxCallDepth))
+	}
+
+	udn_data["__call_depth"] = call_depth
+
+	return ""
+}
+
+func PopUdnCallDepth(udn_data map[string]interface{}) {
+	call_depth, _ := udn_data["__call_depth"].(int)
+
+	// Dont leave it in udn_data when we are back at the top
+	if call_depth <= 1 {
+		delete(udn_data, "__call_depth")
+	} else {
+		udn_data["__call_depth"] = call_depth - 1
+	}
+}
@@ -0,0 +1,168 @@
+package yudien
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/ghowland/yudien/yudiendata"
+)
+
+func TestUdnExecutionLimits(t *testing.T) {
+	udn_data := map[string]interface{}{}
+
+	tests := []struct {
+		limits UdnExecutionLimits
+		udn    []string
+		limit  string
+	}{
+		{UdnExecutionLimits{MaxSteps: 1000}, []string{"__while.'__input.1'.1000000.__input.1.__end_while"}, execution_limit_steps},
+		{UdnExecutionLimits{Timeout: 20 * time.Millisecond}, []string{"__while.'__input.1'.1000000000.__input.1.__end_while"}, execution_limit_deadline},
+		{UdnExecutionLimits{MaxCallDepth: 50}, []string{"__define.forever.__call.forever.__end_define", "__call.forever"}, execution_limit_call_depth},
+		// __try cant catch an aborted execution
+		{UdnExecutionLimits{MaxSteps: 100}, []string{"__try.__while.'__input.1'.1000000.__input.1.__end_while.__catch.__input.caught.__end_try"}, execution_limit_steps},
+	}
+
+	for _, test := range tests {
+		udn_schema := testUdnSchema()
+		StartUdnExecution(context.Background(), udn_schema, test.limits)
+
+		result := ProcessUDN(nil, udn_schema, test.udn, udn_data)
+
+		udn_error := GetUdnError(udn_schema)
+		if result != nil || udn_error == nil || udn_error["limit"] != test.limit {
+			t.Errorf("%v: Expected %s limit error: %v  Error: %v", test.udn, test.limit, result, udn_error)
+			continue
+		}
+
+		// The error names the functions it unwound through, innermost first
+		function_stack := udn_error["function_stack"].([]interface{})
+		if outermost := function_stack[len(function_stack)-1].(map[string]interface{})["function"]; outermost != test.udn[len(test.udn)-1][:len(outermost.(string))] {
+			t.Errorf("%v: Unexpected function stack: %v", test.udn, function_stack)
+		}
+	}
+
+	if _, ok := udn_data["__call_depth"]; ok {
+		t.Errorf("Call depth was left in udn_data: %v", udn_data["__call_depth"])
+	}
+}
+
+func TestUdnExecutionCancel(t *testing.T) {
+	udn_schema := testUdnSchema()
+	udn_data := map[string]interface{}{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	StartUdnExecution(ctx, udn_schema, UdnExecutionLimits{})
+	cancel()
+
+	ProcessSingleUDNTarget(nil, udn_schema, "__input.1", nil, udn_data)
+	if udn_error := GetUdnError(udn_schema); udn_error == nil || udn_error["limit"] != execution_limit_cancelled {
+		t.Errorf("Cancelled execution did not fail: %v", udn_error)
+	}
+
+	// Outstanding HTTP requests are cancelled at the deadline
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		select {
+		case <-request.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	udn_schema = testUdnSchema()
+	StartUdnExecution(context.Background(), udn_schema, UdnExecutionLimits{Timeout: 50 * time.Millisecond})
+
+	start := time.Now()
+	ProcessSingleUDNTarget(nil, udn_schema, "__http_request.GET.'"+server.URL+"'", nil, udn_data)
+
+	if time.Since(start) > 2*time.Second || GetUdnError(udn_schema) == nil {
+		t.Errorf("HTTP request was not cancelled at the deadline: %s  Error: %v", time.Since(start), GetUdnError(udn_schema))
+	}
+}
+
+// A slow datasource: Filter waits for the query's context, like a long SQL query
+type testSlowDatamanStore struct {
+	*DatamanFileStore
+}
+
+func (store testSlowDatamanStore) Filter(collection_name string, filter interface{}, options map[string]interface{}) []map[string]interface{} {
+	ctx, ok := options["context"].(context.Context)
+	if !ok {
+		ctx = context.Background()
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case <-time.After(5 * time.Second):
+		return store.DatamanFileStore.Filter(collection_name, filter, options)
+	}
+}
+
+func TestUdnExecutionCancelDataman(t *testing.T) {
+	file_store, _ := NewDatamanFileStore("")
+	udn_schema := testStandInUdnSchema(testSlowDatamanStore{file_store})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	StartUdnExecution(ctx, udn_schema, UdnExecutionLimits{})
+
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	ProcessSingleUDNTarget(nil, udn_schema, "__data_filter.user.{}.__input.1", nil, map[string]interface{}{})
+
+	if time.Since(start) > 2*time.Second {
+		t.Errorf("Dataman call was not cancelled with the execution: %s", time.Since(start))
+	}
+	if udn_error := GetUdnError(udn_schema); udn_error == nil || udn_error["limit"] != execution_limit_cancelled {
+		t.Errorf("Cancelled execution did not fail: %v", udn_error)
+	}
+}
+
+// Every top-level execution gets its own budget, so an aborted one doesnt fail the next, and nested executions keep their caller's
+func TestUdnExecutionBudgetPerExecution(t *testing.T) {
+	udn_schema := testUdnSchema()
+	udn_data := map[string]interface{}{}
+
+	ProcessUDN(nil, udn_schema, []string{"__define.forever.__call.forever.__end_define", "__call.forever"}, udn_data)
+	if udn_error := GetUdnError(udn_schema); udn_error == nil || udn_error["limit"] != execution_limit_call_depth {
+		t.Fatalf("Expected a call depth limit error: %v", udn_error)
+	}
+
+	if result := ProcessUDN(nil, udn_schema, []string{"__input.1"}, udn_data); result != int64(1) {
+		t.Errorf("Execution after an aborted one failed: %v  Error: %v", result, GetUdnError(udn_schema))
+	}
+	if result := ProcessSingleUDNTarget(nil, udn_schema, "__input.2", nil, udn_data); result != int64(2) {
+		t.Errorf("Execution after an aborted one failed: %v  Error: %v", result, GetUdnError(udn_schema))
+	}
+
+	// The engine's limits, which nested executions cant get a new budget of
+	engine := NewEngine()
+	engine.Limits = UdnExecutionLimits{MaxSteps: 100}
+
+	udn_schema = engine.NewUdnSchema()
+
+	tests := []string{
+		"__while.'__input.1'.1000000.__input.1.__end_while",
+		"__execute.'__while.&QUOTE;__input.1&QUOTE;.1000000.__input.1.__end_while'",
+	}
+	for _, udn_value := range tests {
+		engine.ProcessUDN(nil, udn_schema, []string{udn_value}, udn_data)
+		if udn_error := GetUdnError(udn_schema); udn_error == nil || udn_error["limit"] != execution_limit_steps {
+			t.Errorf("%s: Expected a steps limit error: %v", udn_value, udn_error)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if result := engine.ProcessUDNContext(ctx, nil, udn_schema, []string{"__input.1"}, udn_data); result != nil || UdnExecutionAborted(udn_schema) != execution_limit_cancelled {
+		t.Errorf("Cancelled execution did not fail: %v  Error: %v", result, GetUdnError(udn_schema))
+	}
+	if result := engine.ProcessSchemaUDNSetContext(context.Background(), nil, udn_schema, `[[["__input.1", "__set.temp.x"]]]`, udn_data); UdnErrorPending(udn_schema) {
+		t.Errorf("Execution after a cancelled one failed: %v  Error: %v", result, GetUdnError(udn_schema))
+	}
+}
@@ -0,0 +1,151 @@
+package yudien
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/ghowland/yudien/yudiencore"
+)
+
+type testLogLine struct {
+	level   int
+	message string
+	fields  map[string]interface{}
+}
+
+type testLogger struct {
+	lines []testLogLine
+}
+
+func (logger *testLogger) Log(level int, message string, fields map[string]interface{}) {
+	logger.lines = append(logger.lines, testLogLine{level, message, fields})
+}
+
+func TestUdnLogger(t *testing.T) {
+	logger := &testLogger{}
+
+	caller_logger := DefaultEngine.Logger
+	DefaultEngine.Logger = logger
+	defer func() { DefaultEngine.Logger = caller_logger }()
+
+	udn_schema := testUdnSchema()
+	udn_data := map[string]interface{}{}
+
+	ProcessSchemaUDNSet(nil, udn_schema, `[[["__input.1.__exec_command"]]]`, udn_data)
+
+	// The error is logged at the error level, without the "ERROR: " prefix, tagged with the request and statement
+	var error_line *testLogLine
+	for index := range logger.lines {
+		if logger.lines[index].level == ParseUdnLogLevel("error") {
+			error_line = &logger.lines[index]
+			break
+		}
+	}
+
+	if error_line == nil {
+		t.Fatalf("Error was not logged: %v", logger.lines)
+	}
+	if strings.HasPrefix(error_line.message, "ERROR: ") || error_line.fields["request_id"] == nil || error_line.fields["source"] != "__input.1.__exec_command" {
+		t.Errorf("Unexpected error log line: %+v", error_line)
+	}
+
+	// The request id is only set while executing
+	if udn_schema["request_id"] != nil {
+		t.Errorf("Request id was left in udn_schema: %v", udn_schema["request_id"])
+	}
+}
+
+func TestJsonLinesLogger(t *testing.T) {
+	log_dir, err := ioutil.TempDir("", "udn_log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(log_dir)
+
+	log_path := filepath.Join(log_dir, "udn.log")
+
+	logger, err := NewJsonLinesLogger(log_path, 200, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for count := 0; count < 10; count++ {
+		logger.Log(ParseUdnLogLevel("info"), "Message\n", map[string]interface{}{"request_id": "abc", "count": count})
+	}
+	logger.Close()
+
+	// Rotated by size, keeping 2 old files
+	files, _ := filepath.Glob(log_path + "*")
+	if len(files) != 3 {
+		t.Errorf("Expected the log and 2 rotated files, got: %v", files)
+	}
+
+	file, err := os.Open(log_path + ".1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := map[string]interface{}{}
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("Log line is not JSON: %s  %s", scanner.Text(), err)
+		}
+
+		if line["level"] != "info" || line["message"] != "Message" || line["request_id"] != "abc" || line["time"] == nil {
+			t.Errorf("Unexpected log line: %s", scanner.Text())
+		}
+	}
+}
+
+func TestJsonLinesLoggerRotateFailure(t *testing.T) {
+	log_dir, err := ioutil.TempDir("", "udn_log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(log_dir)
+
+	log_path := filepath.Join(log_dir, "udn.log")
+
+	// The log cant be moved onto a directory, so every rotation fails
+	os.Mkdir(log_path+".1", 0755)
+
+	logger, err := NewJsonLinesLogger(log_path, 200, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	for count := 0; count < 10; count++ {
+		logger.Log(ParseUdnLogLevel("info"), "Message", nil)
+	}
+
+	// No lines were lost, they are still in the log
+	if lines := testCountLines(t, log_path); lines != 10 {
+		t.Errorf("Expected 10 lines in the log after failed rotations, got %d", lines)
+	}
+
+	// The next line rotates, once it can
+	os.Remove(log_path + ".1")
+	logger.Log(ParseUdnLogLevel("info"), "Message", nil)
+
+	if lines, old_lines := testCountLines(t, log_path), testCountLines(t, log_path+".1"); lines != 1 || old_lines != 10 {
+		t.Errorf("Unexpected lines after rotating: %d  Old: %d", lines, old_lines)
+	}
+}
+
+func testCountLines(t *testing.T, path string) int {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return bytes.Count(data, []byte("\n"))
+}
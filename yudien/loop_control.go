@@ -0,0 +1,86 @@
+package yudien
+
+import (
+	"database/sql"
+	. "github.com/ghowland/yudien/yudiencore"
+)
+
+// __break and __continue unwind to the nearest __iterate or __while, the same way an error unwinds to a __try.  The pending loop control is kept in udn_schema["loop_control"] while it unwinds, and every block executor stops when one is pending.
+//NOTE(g): udn_schema["loop_depth"] is how many loops we are executing inside of, so a __break outside of a loop is an error, instead of silently stopping everything after it.  Functions (__call, __function) start at 0, they cannot break their caller's loop.
+
+const (
+	loop_control_break    = "break"
+	loop_control_continue = "continue"
+)
+
+// Returns true if a __break or __continue is unwinding to its loop, and no more of the loop body should be executed
+func UdnLoopControlPending(udn_schema map[string]interface{}) bool {
+	return udn_schema != nil && udn_schema["loop_control"] != nil
+}
+
+// Clear the pending loop control, and return it ("break", "continue", or "" if there wasnt one).  Loops do this after their body stops.
+func ClearUdnLoopControl(udn_schema map[string]interface{}) string {
+	if !UdnLoopControlPending(udn_schema) {
+		return ""
+	}
+
+	loop_control := udn_schema["loop_control"].(string)
+	delete(udn_schema, "loop_control")
+
+	return loop_control
+}
+
+// Returns how many loops we are executing inside of
+func _GetUdnLoopDepth(udn_schema map[string]interface{}) int {
+	if udn_schema == nil {
+		return 0
+	}
+
+	loop_depth, _ := udn_schema["loop_depth"].(int)
+	return loop_depth
+}
+
+// Set the loop depth, and return the previous one.  Functions set 0 when they start, and put back their caller's depth when they return.
+func _SwapUdnLoopDepth(udn_schema map[string]interface{}, loop_depth int) int {
+	previous_loop_depth := _GetUdnLoopDepth(udn_schema)
+
+	if udn_schema != nil {
+		udn_schema["loop_depth"] = loop_depth
+	}
+
+	return previous_loop_depth
+}
+
+// Start a loop, so __break and __continue inside it have somewhere to go.  Every PushUdnLoop must have a PopUdnLoop, when the loop finishes.
+func PushUdnLoop(udn_schema map[string]interface{}) {
+	_SwapUdnLoopDepth(udn_schema, _GetUdnLoopDepth(udn_schema)+1)
+}
+
+func PopUdnLoop(udn_schema map[string]interface{}) {
+	_SwapUdnLoopDepth(udn_schema, _GetUdnLoopDepth(udn_schema)-1)
+}
+
+func _SetUdnLoopControl(udn_schema map[string]interface{}, loop_control string, input interface{}) UdnResult {
+	if _GetUdnLoopDepth(udn_schema) == 0 {
+		return UdnResultError("__%s is not inside an __iterate or __while loop", loop_control)
+	}
+
+	UdnLogLevel(udn_schema, log_trace, "Loop Control: %s  Loop Depth: %d\n", loop_control, _GetUdnLoopDepth(udn_schema))
+
+	udn_schema["loop_control"] = loop_control
+
+	result := UdnResult{}
+	result.Result = input
+
+	return result
+}
+
+func UDN_Break(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart, args []interface{}, input interface{}, udn_data map[string]interface{}) UdnResult {
+	// Stops the nearest __iterate or __while loop.  The loop we are in is not added to the loop's result list, earlier loops are.
+	return _SetUdnLoopControl(udn_schema, loop_control_break, input)
+}
+
+func UDN_Continue(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart, args []interface{}, input interface{}, udn_data map[string]interface{}) UdnResult {
+	// Skips the rest of this loop of the nearest __iterate or __while, and starts the next one.  The loop we are in is not added to the loop's result list, so __continue filters __iterate's items.
+	return _SetUdnLoopControl(udn_schema, loop_control_continue, input)
+}
@@ -0,0 +1,55 @@
+package yudien
+
+import (
+	"testing"
+
+	. "github.com/ghowland/yudien/yudienutil"
+)
+
+func TestUdnBreakContinue(t *testing.T) {
+	udn_schema := testUdnSchema()
+	udn_data := map[string]interface{}{}
+
+	tests := []struct {
+		udn    string
+		result string
+	}{
+		// From inside nested __if/__else blocks, only the current loop is left out of the results
+		{"__input.[1,2,3,4,5].__iterate.__if.(__compare_equal.(__input).1).__input.one.__else.__if.(__compare_equal.(__input).4).__break.__end_if.__if.(__compare_equal.(__input).2).__continue.__end_if.__end_if.__end_iterate", `["one",3]`},
+		// A nested loop only breaks itself
+		{"__input.[1,2].__iterate.__input.[1,2,3].__iterate.__if.(__compare_equal.(__input).2).__break.__end_if.__end_iterate.__end_iterate", `[[1],[1]]`},
+		{"__input.0.__set_temp.count.__while.'__compare_not_equal.(__get_temp.count).10'.100.__math.add.(__get_temp.count).1.__set_temp.count.__if.(__compare_equal.(__input).3).__break.__end_if.__end_while", `[1,2]`},
+	}
+
+	for _, test := range tests {
+		result := ProcessSingleUDNTarget(nil, udn_schema, test.udn, nil, udn_data)
+
+		if JsonDumpData(result) != test.result {
+			t.Errorf("%s: got %s, want %s  Error: %v", test.udn, JsonDumpData(result), test.result, GetUdnError(udn_schema))
+		}
+		if UdnLoopControlPending(udn_schema) || _GetUdnLoopDepth(udn_schema) != 0 {
+			t.Errorf("%s: loop control was left behind: %v", test.udn, udn_schema["loop_control"])
+		}
+	}
+}
+
+func TestUdnBreakOutsideLoop(t *testing.T) {
+	udn_schema := testUdnSchema()
+	udn_data := map[string]interface{}{}
+
+	if result := ProcessSingleUDNTarget(nil, udn_schema, "__input.1.__break.__input.2", nil, udn_data); result != nil || GetUdnError(udn_schema) == nil {
+		t.Errorf("__break outside a loop did not fail: %v", result)
+	}
+
+	// A called function cannot break the loop it was called from
+	udn_list := []string{
+		"__define.stop.__break.__end_define",
+		"__input.[1,2].__iterate.__call.stop.__end_iterate",
+	}
+	if result := ProcessUDN(nil, udn_schema, udn_list, udn_data); result != nil || GetUdnError(udn_schema) == nil {
+		t.Errorf("__break in a called function did not fail: %v", result)
+	}
+	if _GetUdnLoopDepth(udn_schema) != 0 {
+		t.Errorf("Loop depth was left behind: %d", _GetUdnLoopDepth(udn_schema))
+	}
+}
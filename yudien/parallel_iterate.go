@@ -0,0 +1,178 @@
+package yudien
+
+import (
+	"database/sql"
+	. "github.com/ghowland/yudien/yudiencore"
+	. "github.com/ghowland/yudien/yudienutil"
+	"strconv"
+	"sync"
+)
+
+func UDN_ParallelIterate(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart, args []interface{}, input interface{}, udn_data map[string]interface{}) UdnResult {
+	// Like __iterate, but the block is executed for up to arg_0 items at once, each on its own goroutine.  Each worker has its own copy of udn_data and udn_schema (like concurrent execution group blocks), which are merged back when all the items are done.
+	// Results are in input order.  An error only stops its item: the item's result is nil, and the error is added to udn_data["iterate_errors"] with its "index", instead of unwinding.
+	if udn_start.BlockEnd == nil {
+		return UdnResultError("__parallel_iterate has no matching __end_parallel_iterate")
+	}
+
+	if len(args) == 0 {
+		return UdnResultError("__parallel_iterate needs a worker count")
+	}
+
+	// Checked here, GetResult panics on a worker count that isnt an integer
+	worker_count_value, err := strconv.ParseInt(GetResult(args[0], type_string).(string), 10, 64)
+	if err != nil {
+		return UdnResultError("Parallel Iterate: Worker count is not an integer: %v", args[0])
+	}
+
+	worker_count := int(worker_count_value)
+	if worker_count < 1 {
+		return UdnResultError("Parallel Iterate: Worker count must be at least 1: %d", worker_count)
+	}
+
+	input_array := GetResult(input, type_array).([]interface{})
+
+	if worker_count > len(input_array) {
+		worker_count = len(input_array)
+	}
+
+	// Debug logging is only readable in execution order, so we only use 1 worker while debugging
+	if worker_count > 1 && _UdnDebugLogging(udn_schema) {
+		worker_count = 1
+	}
+
+	UdnLogLevel(udn_schema, log_trace, "Parallel Iterate: [%s]  Workers: %d  Input: %s\n\n", udn_start.Id, worker_count, SnippetData(input_array, 240))
+
+	// Indexed by item, so workers never write the same element
+	item_results := make([]interface{}, len(input_array))
+	item_included := make([]bool, len(input_array))
+	item_errors := make([]map[string]interface{}, len(input_array))
+
+	// Copies are made before starting any goroutines, as nothing may read udn_data while it is being written.  udn_data isnt changed until the join, so it is what we find each worker's changes against.
+	worker_datas, err := _CopyUdnDataForBlocks(udn_data, worker_count)
+	if err != nil {
+		return UdnResultError("Parallel Iterate: Cant copy udn_data for the workers: %s", err)
+	}
+
+	worker_schemas := make([]map[string]interface{}, worker_count)
+	for worker := 0; worker < worker_count; worker++ {
+		worker_schemas[worker] = _CopyUdnSchemaForBlock(udn_schema)
+
+		// __break and __continue in our block come to us
+		PushUdnLoop(worker_schemas[worker])
+	}
+
+	// The first item that did a __break.  Items after it are not started, and their results are dropped if they were already running.
+	var break_lock sync.Mutex
+	break_index := len(input_array)
+
+	// Items are handed out in order, so when an item does a __break, every item before it has been started
+	item_channel := make(chan int)
+
+	var wait_group sync.WaitGroup
+
+	for worker := 0; worker < worker_count; worker++ {
+		wait_group.Add(1)
+
+		go func(worker_schema map[string]interface{}, worker_data map[string]interface{}) {
+			defer wait_group.Done()
+
+			for item_index := range item_channel {
+				// Set the iterate index, so we can track it.  This is the worker's own udn_data, so it is only our item's index.
+				worker_data["_iterate_index"] = item_index
+
+				// Each loop has its own variables (__let/__var)
+				PushUdnScope(worker_data)
+				item_result := _ExecuteUdnBlock(db, worker_schema, udn_start, udn_start.BlockEnd, input_array[item_index], worker_data)
+				PopUdnScope(worker_data)
+
+				loop_control := ClearUdnLoopControl(worker_schema)
+
+				if UdnErrorPending(worker_schema) {
+					item_errors[item_index] = ClearUdnError(worker_schema)
+					item_included[item_index] = true
+
+					UdnLogLevel(worker_schema, log_debug, "Parallel Iterate: [%s]  Item %d Error: %s: %s\n", udn_start.Id, item_index, item_errors[item_index]["function"], item_errors[item_index]["message"])
+				} else if loop_control == loop_control_break {
+					break_lock.Lock()
+					if item_index < break_index {
+						break_index = item_index
+					}
+					break_lock.Unlock()
+				} else if loop_control == "" {
+					item_results[item_index] = item_result
+					item_included[item_index] = true
+				}
+			}
+		}(worker_schemas[worker], worker_datas[worker])
+	}
+
+	for item_index := range input_array {
+		break_lock.Lock()
+		stop := item_index > break_index
+		break_lock.Unlock()
+
+		if stop {
+			break
+		}
+
+		item_channel <- item_index
+	}
+
+	close(item_channel)
+
+	wait_group.Wait()
+
+	// Find all the workers' changes before merging any of them, as merging changes udn_data
+	worker_changes := make([]map[string]*_UdnDataChange, worker_count)
+	for worker := 0; worker < worker_count; worker++ {
+		worker_changes[worker] = _GetUdnDataChanges(udn_data, worker_datas[worker])
+	}
+
+	iterate_index, had_iterate_index := udn_data["_iterate_index"]
+
+	// Join, in worker order
+	for worker := 0; worker < worker_count; worker++ {
+		_MergeUdnSchemaFromBlock(udn_schema, worker_schemas[worker])
+		_ApplyUdnDataChanges(udn_data, worker_changes[worker])
+	}
+
+	// Each worker had its own _iterate_index, none of them are ours
+	if had_iterate_index {
+		udn_data["_iterate_index"] = iterate_index
+	} else {
+		delete(udn_data, "_iterate_index")
+	}
+
+	// An aborted execution (over its execution limits) isnt an item's error, it stops everything
+	for _, item_error := range item_errors {
+		if item_error != nil && item_error["limit"] != nil {
+			udn_schema["udn_error"] = item_error
+			return UdnResult{}
+		}
+	}
+
+	result_list := make([]interface{}, 0)
+	iterate_errors := make([]interface{}, 0)
+
+	for item_index := 0; item_index < break_index; item_index++ {
+		if item_included[item_index] {
+			result_list = AppendArray(result_list, item_results[item_index])
+		}
+
+		if item_errors[item_index] != nil {
+			item_errors[item_index]["index"] = item_index
+			iterate_errors = append(iterate_errors, item_errors[item_index])
+		}
+	}
+
+	udn_data["iterate_errors"] = iterate_errors
+
+	UdnLogLevel(udn_schema, log_trace, "\n====== Parallel Iterate Finished: [%s]  Results: %d  Errors: %d\n\n", udn_start.Id, len(result_list), len(iterate_errors))
+
+	result := UdnResult{}
+	result.Result = result_list
+	result.NextUdnPart = udn_start.BlockEnd
+
+	return result
+}
@@ -0,0 +1,89 @@
+package yudien
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/ghowland/yudien/yudienutil"
+)
+
+func TestUdnParallelIterate(t *testing.T) {
+	udn_schema := testUdnSchema()
+	udn_data := map[string]interface{}{}
+
+	tests := []struct {
+		udn    string
+		result string
+	}{
+		{"__input.[1,2,3,4,5,6,7,8].__parallel_iterate.3.__math.multiply.(__input).10.__end_parallel_iterate", `[10,20,30,40,50,60,70,80]`},
+		{"__input.[1,2,3].__parallel_iterate.8.__input.(__get._iterate_index).__end_parallel_iterate", `[0,1,2]`},
+		{"__input.[1,2,3,4].__parallel_iterate.2.__if.(__compare_equal.(__input).2).__continue.__end_if.__end_parallel_iterate", `[1,3,4]`},
+		{"__input.[1,2,3,4,5,6].__parallel_iterate.2.__if.(__compare_equal.(__input).3).__break.__end_if.__end_parallel_iterate", `[1,2]`},
+		{"__input.[].__parallel_iterate.2.__input.x.__end_parallel_iterate", `[]`},
+	}
+
+	for _, test := range tests {
+		result := ProcessSingleUDNTarget(nil, udn_schema, test.udn, nil, udn_data)
+
+		if JsonDumpData(result) != test.result {
+			t.Errorf("%s: got %s, want %s  Error: %v", test.udn, JsonDumpData(result), test.result, GetUdnError(udn_schema))
+		}
+	}
+
+	if _, ok := udn_data["_iterate_index"]; ok {
+		t.Errorf("Worker _iterate_index leaked: %v", udn_data["_iterate_index"])
+	}
+}
+
+func TestUdnParallelIterateErrors(t *testing.T) {
+	udn_schema := testUdnSchema()
+	udn_data := map[string]interface{}{}
+
+	// Errors stay with their item, the other items finish, and what each item set is merged back
+	result := ProcessSingleUDNTarget(nil, udn_schema, `__input.['{"a": 1}','bad','{"b": 2}','bad'].__parallel_iterate.2.__json_decode.__set.decoded.(__get._iterate_index).__end_parallel_iterate`, nil, udn_data)
+
+	if GetUdnError(udn_schema) != nil {
+		t.Fatalf("Item errors unwound: %v", GetUdnError(udn_schema))
+	}
+	if JsonDumpData(result) != `[{"a":1},null,{"b":2},null]` {
+		t.Errorf("Unexpected result: %s", JsonDumpData(result))
+	}
+	if JsonDumpData(udn_data["decoded"]) != `{"0":{"a":1},"2":{"b":2}}` {
+		t.Errorf("Unexpected merged data: %s", JsonDumpData(udn_data["decoded"]))
+	}
+
+	iterate_errors := udn_data["iterate_errors"].([]interface{})
+	if len(iterate_errors) != 2 || iterate_errors[0].(map[string]interface{})["index"] != 1 || iterate_errors[1].(map[string]interface{})["function"] != "__json_decode" {
+		t.Errorf("Unexpected iterate_errors: %v", iterate_errors)
+	}
+}
+
+func TestUdnParallelIterateUncopyable(t *testing.T) {
+	udn_schema := testUdnSchema()
+	udn_data := map[string]interface{}{"uncopyable": testUncopyable{}}
+
+	ProcessSingleUDNTarget(nil, udn_schema, "__input.[1,2].__parallel_iterate.2.__input.x.__end_parallel_iterate", nil, udn_data)
+
+	if udn_error := GetUdnError(udn_schema); udn_error == nil || !strings.Contains(udn_error["message"].(string), "cant copy testUncopyable") {
+		t.Errorf("Copy error was not returned: %v", udn_error)
+	}
+}
+
+func TestUdnParallelIterateWorkerCount(t *testing.T) {
+	tests := map[string]string{
+		"__input.[1,2].__parallel_iterate.__input.x.__end_parallel_iterate":     "__parallel_iterate needs a worker count",
+		"__input.[1,2].__parallel_iterate.0.__input.x.__end_parallel_iterate":   "Worker count must be at least 1: 0",
+		"__input.[1,2].__parallel_iterate.-2.__input.x.__end_parallel_iterate":  "Worker count must be at least 1: -2",
+		"__input.[1,2].__parallel_iterate.two.__input.x.__end_parallel_iterate": "Worker count is not an integer: two",
+	}
+
+	for udn_value, expected := range tests {
+		udn_schema := testUdnSchema()
+
+		ProcessSingleUDNTarget(nil, udn_schema, udn_value, nil, map[string]interface{}{})
+
+		if udn_error := GetUdnError(udn_schema); udn_error == nil || !strings.Contains(udn_error["message"].(string), expected) || strings.Contains(udn_error["message"].(string), "Panic") {
+			t.Errorf("%s: Expected error %q, got %v", udn_value, expected, udn_error)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package yudien
+
+import (
+	"container/list"
+	"database/sql"
+	. "github.com/ghowland/yudien/yudiencore"
+	"sync"
+)
+
+// Default number of parsed UDN statements we keep around.  Widget and stored function UDN is the same on every request, so this only needs to cover the working set of statements.
+const udn_parse_cache_default_size = 4096
+
+// Cache of parsed UdnPart trees, keyed by the UDN source string.  Parsed trees are immutable once FinalParseProcessUdnParts is done with them, so the same tree is shared between all executions (and goroutines).
+//NOTE(g): Nothing may write into a cached UdnPart during execution.  Execution state belongs in UdnResult, args, or udn_data, never on the tree.
+type UdnParseCache struct {
+	lock sync.Mutex
+
+	// Maximum number of entries, 0 disables caching
+	max_size int
+
+	// Map of UDN source to its element in the LRU order list.  Front of the list is the most recently used.
+	items map[string]*list.Element
+	order *list.List
+
+	hits   int64
+	misses int64
+}
+
+// Single cached entry, so we can find the key again when evicting from the back of the LRU list
+type udnParseCacheItem struct {
+	udn_value string
+	udn_part  *UdnPart
+}
+
+// Counters and size of the parse cache, for monitoring whether it is big enough
+type UdnParseCacheStats struct {
+	Hits    int64 `json:"hits"`
+	Misses  int64 `json:"misses"`
+	Size    int   `json:"size"`
+	MaxSize int   `json:"max_size"`
+}
+
+// Parse cache of DefaultEngine.  Other engines have their own, Engine.ParseCache.
+var UdnParsedCache = NewUdnParseCache(udn_parse_cache_default_size)
+
+func NewUdnParseCache(max_size int) *UdnParseCache {
+	return &UdnParseCache{
+		max_size: max_size,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Returns the cached UdnPart for this UDN source, or nil if we havent parsed it yet
+func (cache *UdnParseCache) Get(udn_value string) *UdnPart {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	element, ok := cache.items[udn_value]
+	if !ok {
+		cache.misses++
+		return nil
+	}
+
+	cache.hits++
+	cache.order.MoveToFront(element)
+
+	return element.Value.(*udnParseCacheItem).udn_part
+}
+
+// Store a parsed UdnPart for this UDN source, evicting the least recently used entries if we are over our size
+func (cache *UdnParseCache) Put(udn_value string, udn_part *UdnPart) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	if cache.max_size <= 0 {
+		return
+	}
+
+	// If another request parsed the same string at the same time, keep the first one, they are identical
+	if element, ok := cache.items[udn_value]; ok {
+		cache.order.MoveToFront(element)
+		return
+	}
+
+	cache.items[udn_value] = cache.order.PushFront(&udnParseCacheItem{udn_value: udn_value, udn_part: udn_part})
+
+	for cache.order.Len() > cache.max_size {
+		oldest := cache.order.Back()
+		cache.order.Remove(oldest)
+		delete(cache.items, oldest.Value.(*udnParseCacheItem).udn_value)
+	}
+}
+
+// Change the maximum size of the cache.  Setting 0 disables the cache, and drops everything in it.
+func (cache *UdnParseCache) SetMaxSize(max_size int) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	cache.max_size = max_size
+
+	for cache.order.Len() > 0 && cache.order.Len() > max_size {
+		oldest := cache.order.Back()
+		cache.order.Remove(oldest)
+		delete(cache.items, oldest.Value.(*udnParseCacheItem).udn_value)
+	}
+}
+
+// Drop all entries and reset the counters
+func (cache *UdnParseCache) Clear() {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	cache.items = make(map[string]*list.Element)
+	cache.order = list.New()
+	cache.hits = 0
+	cache.misses = 0
+}
+
+func (cache *UdnParseCache) Stats() UdnParseCacheStats {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	return UdnParseCacheStats{
+		Hits:    cache.hits,
+		Misses:  cache.misses,
+		Size:    cache.order.Len(),
+		MaxSize: cache.max_size,
+	}
+}
+
+// Parse a UDN string, using the parse cache of the udn_schema's engine.  The returned UdnPart is shared, and must be treated as read-only.  Statements that fail to parse are not cached.
+func ParseUdnStringCached(db *sql.DB, udn_schema map[string]interface{}, udn_value_source string) (*UdnPart, error) {
+	parse_cache := GetUdnEngine(udn_schema).ParseCache
+
+	udn_part := parse_cache.Get(udn_value_source)
+
+	if udn_part == nil {
+		var err error
+		udn_part, err = ParseUdnString(db, udn_schema, udn_value_source)
+		if err != nil {
+			return nil, err
+		}
+
+		parse_cache.Put(udn_value_source, udn_part)
+	}
+
+	return udn_part, nil
+}
@@ -0,0 +1,45 @@
+package yudien
+
+import (
+	"testing"
+)
+
+func TestUdnParseCache(t *testing.T) {
+	cache := NewUdnParseCache(2)
+
+	if cache.Get("__input.1") != nil {
+		t.Fatalf("Empty cache returned a UdnPart")
+	}
+
+	part_a, _ := ParseUdnString(nil, nil, "__input.1")
+	part_b, _ := ParseUdnString(nil, nil, "__input.2")
+	part_c, _ := ParseUdnString(nil, nil, "__input.3")
+
+	cache.Put("__input.1", part_a)
+	cache.Put("__input.2", part_b)
+
+	// Touch the first entry, so the second is the least recently used
+	if cache.Get("__input.1") != part_a {
+		t.Fatalf("Cache did not return the stored UdnPart")
+	}
+
+	cache.Put("__input.3", part_c)
+
+	if cache.Get("__input.2") != nil {
+		t.Fatalf("Least recently used entry was not evicted")
+	}
+	if cache.Get("__input.1") != part_a || cache.Get("__input.3") != part_c {
+		t.Fatalf("Recently used entries were evicted")
+	}
+
+	stats := cache.Stats()
+	if stats.Hits != 3 || stats.Misses != 2 || stats.Size != 2 {
+		t.Fatalf("Unexpected cache stats: %+v", stats)
+	}
+
+	cache.SetMaxSize(0)
+	cache.Put("__input.1", part_a)
+	if cache.Stats().Size != 0 {
+		t.Fatalf("Disabled cache stored an entry")
+	}
+}
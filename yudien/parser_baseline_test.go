@@ -0,0 +1,119 @@
+package yudien
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	. "github.com/ghowland/yudien/yudiencore"
+)
+
+// Trees the split passes (_SplitQuotes, _SplitCompoundStatements, ...) built before the lexer and parser replaced them, from baseline commit 0166aa8.  Statements are the udn_test_cases statements, and syntax both parsers accept.
+var parser_baseline_trees = "data/parser_baseline_trees.json"
+
+// Statements we parse differently than the split passes on purpose, with the tree we build now
+var parser_baseline_changes = map[string]string{
+	// Quoted strings are arguments, the split passes made them functions chained after the last function
+	"__execute.'__input.Testing123'.__set.temp.x": "Function: __execute\n  String: __input.Testing123\n> Function: __set\n  Item: temp\n  Item: x",
+	"__input.'__not_a_function'":                  "Function: __input\n  String: __not_a_function",
+}
+
+type parserBaselineTree struct {
+	Statement string `json:"statement"`
+	Tree      string `json:"tree"`
+}
+
+func loadParserBaselineTrees(t *testing.T) []parserBaselineTree {
+	data, err := ioutil.ReadFile(parser_baseline_trees)
+	if err != nil {
+		t.Fatalf("Unable to read %s: %v", parser_baseline_trees, err)
+	}
+
+	trees := []parserBaselineTree{}
+	if err := json.Unmarshal(data, &trees); err != nil {
+		t.Fatalf("Unable to load %s: %v", parser_baseline_trees, err)
+	}
+
+	return trees
+}
+
+// The baseline statements that still parse, which are all but the unclosed quotes
+func parserBaselineStatements(t *testing.T) []string {
+	statements := []string{}
+	for _, baseline := range loadParserBaselineTrees(t) {
+		if strings.Count(baseline.Statement, "'")%2 == 0 {
+			statements = append(statements, baseline.Statement)
+		}
+	}
+
+	return statements
+}
+
+// The part types and values of a tree, one part per line.  Arguments are indented under their function, and "> " is the next function in the chain.
+func describeUdnPartTree(part *UdnPart, indent string, prefix string) string {
+	output := indent + prefix + PartTypeName[part.PartType] + ": " + part.Value + "\n"
+
+	for _, child := range part.Children {
+		output += describeUdnPartTree(child, indent+"  ", "")
+	}
+
+	if part.NextUdnPart != nil {
+		output += describeUdnPartTree(part.NextUdnPart, indent, "> ")
+	}
+
+	return output
+}
+
+func TestParseUdnStringMatchesBaseline(t *testing.T) {
+	trees := loadParserBaselineTrees(t)
+
+	for _, baseline := range trees {
+		// Unclosed quotes are ParseErrors now, see TestParseUdnStringBaselineUnclosedQuote
+		if strings.Count(baseline.Statement, "'")%2 == 1 {
+			continue
+		}
+
+		udn_part, err := ParseUdnString(nil, nil, baseline.Statement)
+		if err != nil {
+			t.Errorf("Parse of %q failed: %s", baseline.Statement, err)
+			continue
+		}
+
+		expected := baseline.Tree
+		if changed, ok := parser_baseline_changes[baseline.Statement]; ok {
+			if changed == baseline.Tree {
+				t.Errorf("Change of %q is the same as the baseline tree", baseline.Statement)
+			}
+			expected = changed
+		}
+
+		if parsed := strings.TrimSuffix(describeUdnPartTree(udn_part, "", ""), "\n"); parsed != expected {
+			t.Errorf("Parse of %q is different from the baseline:\n%s\nBaseline:\n%s", baseline.Statement, parsed, expected)
+		}
+	}
+}
+
+// The split passes ended an unclosed quoted string at the end of the statement, the http_request fixtures relied on that before their quotes were closed.  This is a ParseError now.
+func TestParseUdnStringBaselineUnclosedQuote(t *testing.T) {
+	baseline_trees := map[string]string{}
+	for _, baseline := range loadParserBaselineTrees(t) {
+		baseline_trees[baseline.Statement] = baseline.Tree
+	}
+
+	statements := []string{
+		"__http_request.'POST'.'http://eventsum.infra.prod.wish.com",
+		"__http_request.'GET'.'http://eventsum.infra.prod.wish.com/health",
+	}
+
+	for _, statement := range statements {
+		if _, err := ParseUdnString(nil, nil, statement); err == nil || !strings.Contains(err.Error(), "unclosed quoted string") {
+			t.Errorf("Parse of %q: Expected an unclosed quoted string error, got: %v", statement, err)
+		}
+
+		// The old fixture text and the closed quote were the same for the split passes
+		if baseline_trees[statement] == "" || baseline_trees[statement] != baseline_trees[statement+"'"] {
+			t.Errorf("Baseline trees of %q changed when closing the quote:\n%s\n%s", statement, baseline_trees[statement], baseline_trees[statement+"'"])
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package yudien
+
+import (
+	"testing"
+)
+
+func TestParseUdnStringErrors(t *testing.T) {
+	cases := []struct {
+		udn    string
+		line   int
+		column int
+		found  string
+	}{
+		{"__input.'unclosed", 1, 18, "end of statement"},
+		{"__input.(__get.temp", 1, 20, "end of statement"},
+		{"__input.[1, 2}", 1, 14, "'}'"},
+		{"__input.1)", 1, 10, "')'"},
+		{"__input.{a=1}\n__output.]", 2, 10, "']'"},
+	}
+
+	for _, test_case := range cases {
+		udn_part, err := ParseUdnString(nil, nil, test_case.udn)
+		if err == nil || udn_part != nil {
+			t.Errorf("Parse of %q did not fail", test_case.udn)
+			continue
+		}
+
+		parse_error, ok := err.(*ParseError)
+		if !ok {
+			t.Errorf("Parse of %q returned %T, expected *ParseError", test_case.udn, err)
+			continue
+		}
+
+		if parse_error.Line != test_case.line || parse_error.Column != test_case.column || parse_error.Found != test_case.found {
+			t.Errorf("Parse of %q: %s", test_case.udn, parse_error)
+		}
+	}
+}
+
+func TestParseUdnStringNested(t *testing.T) {
+	udn_part, err := ParseUdnString(nil, nil, "__input.{a=[1,'(]}'],b=(__get.x)}")
+	if err != nil {
+		t.Fatalf("Parse failed: %s", err)
+	}
+
+	if udn_part.PartType != part_function || udn_part.Value != "__input" || len(udn_part.Children) != 1 {
+		t.Fatalf("Unexpected parse: %s", DescribeUdnPart(udn_part))
+	}
+}
+
+func TestParseUdnStringEscapes(t *testing.T) {
+	cases := map[string]string{
+		`__input.'it\'s'`:       "it's",
+		`__input.'a\\b'`:        `a\b`,
+		`__input.'one\ntwo\tx'`: "one\ntwo\tx",
+		`__input.'été'`:         "été",
+		`__input.'\d+'`:         `\d+`,
+		`__input.'it&QUOTE;s'`:  "it's",
+	}
+
+	for udn, expected := range cases {
+		udn_part, err := ParseUdnString(nil, nil, udn)
+		if err != nil {
+			t.Errorf("Parse of %q failed: %s", udn, err)
+			continue
+		}
+
+		if udn_part.Children[0].Value != expected {
+			t.Errorf("Parse of %q: got %q, expected %q", udn, udn_part.Children[0].Value, expected)
+		}
+	}
+
+	if _, err := ParseUdnString(nil, nil, `__input.'\u00zz'`); err == nil {
+		t.Errorf("Bad \\u escape did not fail")
+	}
+}
+
+func TestParseUdnStringLiterals(t *testing.T) {
+	udn_part, err := ParseUdnString(nil, nil, "__input.[1,-2.5,3e2,true,false,null,007,'1',x,{a:1.5}]")
+	if err != nil {
+		t.Fatalf("Parse failed: %s", err)
+	}
+
+	expected := []interface{}{int64(1), float64(-2.5), float64(300), true, false, nil, "007", "1", "x"}
+
+	items := udn_part.Children[0].Children
+	if len(items) != len(expected)+1 {
+		t.Fatalf("Unexpected parse: %s", DescribeUdnPart(udn_part))
+	}
+
+	for index, value := range expected {
+		if UdnPartValue(items[index]) != value {
+			t.Errorf("Item %d: got %v (%T), expected %v (%T)", index, UdnPartValue(items[index]), UdnPartValue(items[index]), value, value)
+		}
+	}
+
+	map_value := items[len(expected)].Children[0].Children[0]
+	if UdnPartValue(map_value) != float64(1.5) {
+		t.Errorf("Map value: got %v (%T)", UdnPartValue(map_value), UdnPartValue(map_value))
+	}
+}
+
+// Literals are typed values as arguments, but keys with their text in functions that take keys
+func TestUdnLiteralKeys(t *testing.T) {
+	udn_schema := testUdnSchema()
+	udn_data := map[string]interface{}{"x": map[string]interface{}{"null": "a", "true": "b", "1": "c", "": "empty"}}
+
+	tests := []struct {
+		udn    string
+		result interface{}
+	}{
+		{"__get.x.null", "a"},
+		{"__get.x.true", "b"},
+		{"__get.x.1", "c"},
+		{"__get.x.__input_get.null", "a"},
+		{"__get.x.__input_get.true", "b"},
+		{"__get.x.__input_get.1", "c"},
+		{"__input.5.__set.y.false.__get.y.false", int64(5)},
+		{"__input.true", true},
+		{"__input.null", nil},
+	}
+
+	for _, test := range tests {
+		if result := ProcessSingleUDNTarget(nil, udn_schema, test.udn, nil, udn_data); result != test.result {
+			t.Errorf("%s: Expected %v (%T), got %v (%T)  Error: %v", test.udn, test.result, test.result, result, result, GetUdnError(udn_schema))
+		}
+	}
+
+	if _, ok := udn_data["y"].(map[string]interface{})["false"]; !ok {
+		t.Errorf("__set did not use the key \"false\": %v", udn_data["y"])
+	}
+}
+
+func TestParseUdnStringQuotedFunction(t *testing.T) {
+	// A quoted string is an argument, even if it starts with "__", so UDN can be passed to __execute.  The split passes made it a function, chained after the last one:
+	//   __execute -> __set.temp.x -> __input.Testing123
+	udn_part, err := ParseUdnString(nil, nil, "__execute.'__input.Testing123'.__set.temp.x")
+	if err != nil {
+		t.Fatalf("Parse failed: %s", err)
+	}
+
+	if len(udn_part.Children) != 1 || udn_part.Children[0].PartType != part_string || udn_part.Children[0].Value != "__input.Testing123" {
+		t.Fatalf("Quoted string is not an argument: %s", DescribeUdnPart(udn_part))
+	}
+	if udn_part.NextUdnPart == nil || udn_part.NextUdnPart.Value != "__set" || udn_part.NextUdnPart.NextUdnPart != nil {
+		t.Fatalf("Unexpected functions: %s", DescribeUdnPart(udn_part))
+	}
+}
@@ -0,0 +1,186 @@
+package yudien
+
+import (
+	"fmt"
+	neturl "net/url"
+	"path"
+	"strings"
+)
+
+// What a request's UDN is allowed to do, so UDN edited by less trusted users (ex: widgets) cant shell out, reach any URL, or change any table.  Empty lists allow everything.  Patterns are path.Match patterns, ex: "__string_*", "*.example.com".
+// NOTE(g): Function pack functions (including the custom functions) call Dataman and other services directly, and the "special" functions can run shell commands (__exec_command), which isnt checked against UrlHosts, Databases, Collections or ReadOnly.  When any of those are set, these functions can only be executed if Functions lists them (Groups isnt enough).
+type UdnPolicy struct {
+	// Functions that can be executed, by name or group (UdnFunctionGroups).  A function is allowed if either list allows it.
+	Functions []string `json:"functions"`
+	Groups    []string `json:"groups"`
+
+	// Hosts that __http_request can reach, including redirects
+	UrlHosts []string `json:"url_hosts"`
+
+	// Datasources (DatabaseConfig.Name, as in the "db" option) and collections the Dataman functions can use.  Collections match the collection name, or "database.collection".
+	Databases   []string `json:"databases"`
+	Collections []string `json:"collections"`
+
+	// No functions that change data outside the execution (UdnFunctionSignature.Writes), and __http_request can only GET
+	ReadOnly bool `json:"read_only"`
+}
+
+// Restrict all the execution with udn_schema, including nested calls and concurrent blocks, to policy.  nil removes the policy.
+func SetUdnPolicy(udn_schema map[string]interface{}, policy *UdnPolicy) {
+	if policy == nil {
+		delete(udn_schema, "policy")
+	} else {
+		udn_schema["policy"] = policy
+	}
+}
+
+// Returns the policy of this execution, or nil if it isnt restricted
+func GetUdnPolicy(udn_schema map[string]interface{}) *UdnPolicy {
+	policy, _ := udn_schema["policy"].(*UdnPolicy)
+	return policy
+}
+
+// Returns an error message if the policy doesnt allow executing this function, or "".  Called by ExecuteUdnPart for every function.
+func CheckUdnPolicyFunction(udn_schema map[string]interface{}, function_name string) string {
+	policy := GetUdnPolicy(udn_schema)
+	if policy == nil {
+		return ""
+	}
+
+	signature := GetUdnEngine(udn_schema).FunctionSignatures[function_name]
+
+	if len(policy.Functions) > 0 || len(policy.Groups) > 0 {
+		allowed := _UdnPolicyMatch(policy.Functions, function_name)
+		if !allowed && signature != nil {
+			allowed = _UdnPolicyMatch(policy.Groups, signature.Group)
+		}
+
+		if !allowed {
+			return fmt.Sprintf("Policy: Function is not allowed: %s", function_name)
+		}
+	}
+
+	if policy.ReadOnly && signature != nil && signature.Writes {
+		return fmt.Sprintf("Policy: Function is not allowed in read-only mode: %s", function_name)
+	}
+
+	if policy._RestrictsServices() && !_UdnPolicyMatch(policy.Functions, function_name) {
+		if _, is_pack := GetUdnEngine(udn_schema).function_packs[function_name]; is_pack {
+			return fmt.Sprintf("Policy: Function pack function is not listed in the policy's functions: %s", function_name)
+		}
+
+		if signature != nil && signature.Group == "special" {
+			return fmt.Sprintf("Policy: Special function is not listed in the policy's functions: %s", function_name)
+		}
+	}
+
+	return ""
+}
+
+// Returns an error message if the policy doesnt allow an HTTP request with method to url, or "".  Checked again for every redirect.
+func CheckUdnPolicyUrl(udn_schema map[string]interface{}, method string, url string) string {
+	policy := GetUdnPolicy(udn_schema)
+	if policy == nil {
+		return ""
+	}
+
+	if policy.ReadOnly && method != "GET" {
+		return fmt.Sprintf("Policy: HTTP %s is not allowed in read-only mode: %s", method, url)
+	}
+
+	if len(policy.UrlHosts) > 0 {
+		parsed_url, err := neturl.Parse(url)
+		if err != nil {
+			return fmt.Sprintf("Policy: Invalid URL: %s: %s", url, err)
+		}
+
+		if !_UdnPolicyMatch(policy.UrlHosts, strings.ToLower(parsed_url.Hostname())) {
+			return fmt.Sprintf("Policy: URL host is not allowed: %s", url)
+		}
+	}
+
+	return ""
+}
+
+// Returns an error message if the policy doesnt allow using the collection in the datasource the Dataman options select, or "".  The Dataman functions check this before using Dataman.
+func CheckUdnPolicyCollection(udn_schema map[string]interface{}, options map[string]interface{}, collection_name string) string {
+	policy := GetUdnPolicy(udn_schema)
+	if policy == nil {
+		return ""
+	}
+
+	return policy._CheckCollection(_UdnPolicyDatabase(udn_schema, options), collection_name)
+}
+
+// Same as CheckUdnPolicyCollection, for record labels ("database.collection.id") and field labels ("database.collection.id.field")
+func CheckUdnPolicyLabel(udn_schema map[string]interface{}, label string) string {
+	policy := GetUdnPolicy(udn_schema)
+	if policy == nil {
+		return ""
+	}
+
+	label_parts := strings.SplitN(label, ".", 3)
+	if len(label_parts) < 2 {
+		return fmt.Sprintf("Policy: Invalid record label: %s", label)
+	}
+
+	// The database Dataman will use, which is the default datasource for an unknown name
+	return policy._CheckCollection(_UdnPolicyDatabase(udn_schema, map[string]interface{}{"db": label_parts[0]}), label_parts[1])
+}
+
+// Returns an error message if the policy restricts databases or collections, or "".  For raw SQL (__query), whose tables cant be checked.
+func CheckUdnPolicySql(udn_schema map[string]interface{}) string {
+	policy := GetUdnPolicy(udn_schema)
+	if policy == nil {
+		return ""
+	}
+
+	if len(policy.Databases) > 0 || len(policy.Collections) > 0 {
+		return "Policy: SQL queries are not allowed when databases or collections are restricted"
+	}
+
+	return ""
+}
+
+// Whether the policy restricts anything function pack and special functions could do without being checked
+func (policy *UdnPolicy) _RestrictsServices() bool {
+	return len(policy.UrlHosts) > 0 || len(policy.Databases) > 0 || len(policy.Collections) > 0 || policy.ReadOnly
+}
+
+func (policy *UdnPolicy) _CheckCollection(database string, collection_name string) string {
+	if len(policy.Databases) > 0 && !_UdnPolicyMatch(policy.Databases, database) {
+		return fmt.Sprintf("Policy: Database is not allowed: %s", database)
+	}
+
+	if len(policy.Collections) > 0 && !_UdnPolicyMatch(policy.Collections, collection_name) && !_UdnPolicyMatch(policy.Collections, database+"."+collection_name) {
+		return fmt.Sprintf("Policy: Collection is not allowed: %s.%s", database, collection_name)
+	}
+
+	return ""
+}
+
+// The datasource name Dataman will use for options, like GetDatasourceInstance selects it.  The default datasource is its own name, when it is configured.
+func _UdnPolicyDatabase(udn_schema map[string]interface{}, options map[string]interface{}) string {
+	datasources := GetUdnEngine(udn_schema).Datasources
+
+	database, _ := options["db"].(string)
+	if database != "" && (datasources.StandIn != nil || datasources.Instance[database] != nil) {
+		return database
+	}
+
+	if database_config, ok := datasources.DatabaseConfig["_default"]; ok && database_config.Name != "" {
+		return database_config.Name
+	}
+
+	return "_default"
+}
+
+func _UdnPolicyMatch(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, value); matched {
+			return true
+		}
+	}
+
+	return false
+}
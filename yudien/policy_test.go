@@ -0,0 +1,227 @@
+package yudien
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/ghowland/yudien/yudiendata"
+)
+
+func TestUdnPolicyFunctions(t *testing.T) {
+	udn_data := map[string]interface{}{}
+
+	policy := &UdnPolicy{Functions: []string{"__input", "__iterate", "__end_iterate"}, Groups: []string{"text"}}
+
+	tests := []struct {
+		udn   string
+		error string
+	}{
+		{"__input.abc.__upper.(__input)", ""},
+		{"__input.[1,2].__iterate.__upper.x.__end_iterate", ""},
+		{"__input.ls.__exec_command", "Policy: Function is not allowed: __exec_command"},
+		// Functions in arguments and loops are checked too
+		{"__input.(__input.ls.__exec_command)", "Policy: Function is not allowed: __exec_command"},
+		{"__input.[1,2].__iterate.__get_temp.x.__end_iterate", "Policy: Function is not allowed: __get_temp"},
+	}
+
+	for _, test := range tests {
+		udn_schema := testUdnSchema()
+		SetUdnPolicy(udn_schema, policy)
+
+		ProcessSingleUDNTarget(nil, udn_schema, test.udn, nil, udn_data)
+
+		message := ""
+		if udn_error := GetUdnError(udn_schema); udn_error != nil {
+			message = udn_error["message"].(string)
+		}
+		if !strings.Contains(message, test.error) || (test.error == "" && message != "") {
+			t.Errorf("%s: Expected error %q, got %q", test.udn, test.error, message)
+		}
+	}
+
+	// Without a policy, everything is allowed
+	udn_schema := testUdnSchema()
+	SetUdnPolicy(udn_schema, policy)
+	SetUdnPolicy(udn_schema, nil)
+	if result := ProcessSingleUDNTarget(nil, udn_schema, "__input.a.__set_temp.x.__get_temp.x", nil, udn_data); result != "a" {
+		t.Errorf("Unexpected result without a policy: %v  Error: %v", result, GetUdnError(udn_schema))
+	}
+}
+
+func TestUdnPolicyData(t *testing.T) {
+	store, _ := NewDatamanFileStore("")
+	store.Collections["user"] = []map[string]interface{}{{"_id": int64(1), "name": "a"}}
+	store.Collections["secret"] = []map[string]interface{}{{"_id": int64(1), "name": "b"}}
+
+	udn_data := map[string]interface{}{"submit": map[string]interface{}{"opsdb.secret.1.name": "x"}}
+
+	tests := []struct {
+		policy *UdnPolicy
+		udn    string
+		error  string
+	}{
+		{&UdnPolicy{Collections: []string{"user"}}, "__data_get.user.1", ""},
+		{&UdnPolicy{Collections: []string{"user"}}, "__data_get.secret.1", "Policy: Collection is not allowed: _default.secret"},
+		{&UdnPolicy{Collections: []string{"opsdb.user"}}, "__data_filter.user.{}.{db=opsdb}", ""},
+		{&UdnPolicy{Collections: []string{"opsdb.user"}}, "__data_filter.user.{}", "Policy: Collection is not allowed: _default.user"},
+		{&UdnPolicy{Databases: []string{"opsdb"}}, "__data_get.user.1.{db=opsdb}", ""},
+		{&UdnPolicy{Databases: []string{"opsdb"}}, "__data_get.user.1.{db=other}", "Policy: Database is not allowed: other"},
+		{&UdnPolicy{Collections: []string{"user"}}, "__data_tombstone.'opsdb.secret.1'", "Policy: Collection is not allowed: opsdb.secret"},
+		{&UdnPolicy{Collections: []string{"user"}}, "__change_submit.(__get.submit)", "Policy: Collection is not allowed: opsdb.secret"},
+		{&UdnPolicy{Collections: []string{"user"}}, "__query.1", "Policy: SQL queries are not allowed"},
+		{&UdnPolicy{ReadOnly: true}, "__data_get.secret.1", ""},
+		{&UdnPolicy{ReadOnly: true}, "__data_delete.user.1", "Policy: Function is not allowed in read-only mode: __data_delete"},
+		{&UdnPolicy{Collections: []string{"user"}}, "__login.admin.secret", "Policy: Collection is not allowed: _default.web_user_session"},
+		{&UdnPolicy{Functions: []string{"__ddd_render"}, Collections: []string{"ddd"}}, "__ddd_render.'0'.0.0.0.1.x.{}.0", "Policy: Collection is not allowed: _default.temp"},
+		{&UdnPolicy{ReadOnly: true}, "__ddd_render.'0'.0.0.0.1.x.{}.0", "Policy: Function is not allowed in read-only mode: __ddd_render"},
+	}
+
+	for _, test := range tests {
+		udn_schema := testStandInUdnSchema(store)
+		SetUdnPolicy(udn_schema, test.policy)
+
+		ProcessSingleUDNTarget(nil, udn_schema, test.udn, nil, udn_data)
+
+		message := ""
+		if udn_error := GetUdnError(udn_schema); udn_error != nil {
+			message = udn_error["message"].(string)
+		}
+		if !strings.Contains(message, test.error) || (test.error == "" && message != "") {
+			t.Errorf("%+v: %s: Expected error %q, got %q", test.policy, test.udn, test.error, message)
+		}
+	}
+
+	// Nothing was deleted
+	if len(store.Collections["user"]) != 1 || len(store.Collections["secret"]) != 1 {
+		t.Errorf("Records were changed: %v", store.Collections)
+	}
+}
+
+func TestUdnPolicyUrl(t *testing.T) {
+	udn_schema := testUdnSchema()
+	SetUdnPolicy(udn_schema, &UdnPolicy{UrlHosts: []string{"*.example.com", "127.0.0.1"}, ReadOnly: true})
+
+	tests := map[string]string{
+		"GET https://api.example.com/x":      "",
+		"GET https://API.Example.com:8443":   "",
+		"GET https://example.com/x":          "Policy: URL host is not allowed",
+		"GET http://evil.com/?a.example.com": "Policy: URL host is not allowed",
+		"POST https://api.example.com/x":     "Policy: HTTP POST is not allowed in read-only mode",
+	}
+	for request, expected := range tests {
+		parts := strings.SplitN(request, " ", 2)
+		if message := CheckUdnPolicyUrl(udn_schema, parts[0], parts[1]); !strings.Contains(message, expected) || (expected == "" && message != "") {
+			t.Errorf("%s: Expected %q, got %q", request, expected, message)
+		}
+	}
+
+	// Redirects to another host are stopped
+	target := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Write([]byte(`{"ok": true}`))
+	}))
+	defer target.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		http.Redirect(writer, request, strings.Replace(target.URL, "127.0.0.1", "localhost", 1), http.StatusFound)
+	}))
+	defer server.Close()
+
+	ProcessSingleUDNTarget(nil, udn_schema, "__http_request.GET.'"+target.URL+"'", nil, map[string]interface{}{})
+	if udn_error := GetUdnError(udn_schema); udn_error != nil {
+		t.Errorf("Allowed request failed: %v", udn_error["message"])
+	}
+
+	ProcessSingleUDNTarget(nil, udn_schema, "__http_request.GET.'"+server.URL+"'", nil, map[string]interface{}{})
+	if udn_error := GetUdnError(udn_schema); udn_error == nil || !strings.Contains(udn_error["message"].(string), "Policy: URL host is not allowed") {
+		t.Errorf("Redirect to another host was not stopped: %v", udn_error)
+	}
+}
+
+func TestUdnPolicyDeleteFilter(t *testing.T) {
+	store, _ := NewDatamanFileStore("")
+	store.Collections["user"] = []map[string]interface{}{{"_id": int64(1), "name": "a"}, {"_id": int64(2), "name": "b"}}
+
+	deletes := &testPolicyDeleteStore{DatamanFileStore: store}
+
+	// The default database isnt allowed, so the records must be deleted from the one that was checked
+	udn_schema := testStandInUdnSchema(deletes)
+	SetUdnPolicy(udn_schema, &UdnPolicy{Databases: []string{"opsdb"}})
+
+	ProcessSingleUDNTarget(nil, udn_schema, "__data_delete_filter.user.{name=a}.{db=opsdb}", nil, map[string]interface{}{})
+
+	if udn_error := GetUdnError(udn_schema); udn_error != nil {
+		t.Errorf("Unexpected error: %v", udn_error["message"])
+	}
+	if len(deletes.databases) != 1 || deletes.databases[0] != "opsdb" {
+		t.Errorf("Records were deleted from other databases: %v", deletes.databases)
+	}
+	if len(store.Collections["user"]) != 1 {
+		t.Errorf("Record was not deleted: %v", store.Collections["user"])
+	}
+}
+
+// Records the database of every Delete
+type testPolicyDeleteStore struct {
+	*DatamanFileStore
+
+	databases []interface{}
+}
+
+func (store *testPolicyDeleteStore) Delete(collection_name string, record_id int64, options map[string]interface{}) map[string]interface{} {
+	store.databases = append(store.databases, options["db"])
+	return store.DatamanFileStore.Delete(collection_name, record_id, options)
+}
+
+func TestUdnPolicyLabel(t *testing.T) {
+	udn_schema := testUdnSchema()
+	SetUdnPolicy(udn_schema, &UdnPolicy{Databases: []string{"unknown"}})
+
+	// Dataman uses the default datasource for a database it doesnt have, so that is what is checked
+	if message := CheckUdnPolicyLabel(udn_schema, "unknown.user.1"); !strings.Contains(message, "Policy: Database is not allowed") || strings.Contains(message, "unknown") {
+		t.Errorf("Unknown label database was not checked as the default: %q", message)
+	}
+}
+
+func TestUdnPolicyFunctionPack(t *testing.T) {
+	engine := NewEngine()
+	if err := engine.RegisterFunctionPack(&testFunctionPack{name: "ops", functions: []*UdnFunctionSignature{testPackFunction("__ops_status")}}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		policy *UdnPolicy
+		udn    string
+		error  string
+	}{
+		{&UdnPolicy{Functions: []string{"__ops_*"}}, "__ops_status", ""},
+		// Pack functions can do anything, so they must be listed by name once data, URLs or writes are restricted
+		{&UdnPolicy{Collections: []string{"user"}}, "__ops_status", "Policy: Function pack function is not listed in the policy's functions: __ops_status"},
+		{&UdnPolicy{UrlHosts: []string{"*.example.com"}}, "__ops_status", "Policy: Function pack function is not listed"},
+		{&UdnPolicy{ReadOnly: true}, "__ops_status", "Policy: Function pack function is not listed"},
+		{&UdnPolicy{Groups: []string{"custom"}, Collections: []string{"user"}}, "__custom_login.a.b", "Policy: Function pack function is not listed in the policy's functions: __custom_login"},
+		{&UdnPolicy{Functions: []string{"__ops_status"}, Collections: []string{"user"}}, "__ops_status", ""},
+		// Core functions are checked by the policy themselves
+		{&UdnPolicy{Collections: []string{"user"}}, "__input.1", ""},
+		// Except the shell, which can reach any URL or database
+		{&UdnPolicy{UrlHosts: []string{"*.example.com"}, Databases: []string{"opsdb"}}, "__exec_command.echo.hi_from_shell", "Policy: Special function is not listed in the policy's functions: __exec_command"},
+		{&UdnPolicy{Groups: []string{"special"}, Collections: []string{"user"}}, "__exec_command.echo.hi_from_shell", "Policy: Special function is not listed in the policy's functions: __exec_command"},
+		{&UdnPolicy{Functions: []string{"__exec_command"}, UrlHosts: []string{"*.example.com"}}, "__exec_command.echo.hi_from_shell", ""},
+	}
+
+	for _, test := range tests {
+		udn_schema := engine.NewUdnSchema()
+		SetUdnPolicy(udn_schema, test.policy)
+
+		engine.ProcessSingleUDNTarget(nil, udn_schema, test.udn, nil, map[string]interface{}{})
+
+		message := ""
+		if udn_error := GetUdnError(udn_schema); udn_error != nil {
+			message = udn_error["message"].(string)
+		}
+		if !strings.Contains(message, test.error) || (test.error == "" && message != "") {
+			t.Errorf("%+v: %s: Expected error %q, got %q", test.policy, test.udn, test.error, message)
+		}
+	}
+}
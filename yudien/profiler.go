@@ -0,0 +1,263 @@
+package yudien
+
+import (
+	"fmt"
+	. "github.com/ghowland/yudien/yudiencore"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+)
+
+// A profiler records how long every function takes, by function name, and by the stack of functions it was executed in (folded stacks, for flame graphs).  Stored functions (__function) and defined functions (__call) are profiled by their own names, ex: "__function:user_list".
+// Attach one to an execution with SetUdnProfiler, or set Engine.Profiler to profile every execution of an engine into one profiler.  It only takes a lock once per function, so it can be left on in staging.
+// NOTE(g): Total time of recursive functions counts the nested calls again, like most profilers.  Self time never double counts.
+type UdnProfiler struct {
+	Started time.Time
+
+	lock      sync.Mutex
+	functions map[string]*UdnProfileStat
+	folded    map[string]time.Duration
+}
+
+type UdnProfileStat struct {
+	Name  string
+	Calls int64
+
+	Total time.Duration // Including the functions it executed
+	Self  time.Duration // Not including the functions it executed
+	Args  time.Duration // Processing its arguments, before it was executed.  Included in Total.
+}
+
+// A function we are executing
+type _UdnProfileFrame struct {
+	name     string
+	start    time.Time
+	args     time.Duration
+	children int64 // time.Duration of the functions it executed.  Concurrent blocks add theirs from their goroutines, so it is atomic.
+}
+
+// The functions an execution is in, outermost first.  Concurrent blocks get their own stack, which starts with the folded stack of the function that started them.
+type _UdnProfileStack struct {
+	prefix string
+	parent *_UdnProfileFrame
+	frames []*_UdnProfileFrame
+}
+
+func NewUdnProfiler() *UdnProfiler {
+	return &UdnProfiler{Started: time.Now(), functions: make(map[string]*UdnProfileStat), folded: make(map[string]time.Duration)}
+}
+
+func SetUdnProfiler(udn_schema map[string]interface{}, profiler *UdnProfiler) {
+	if profiler == nil {
+		delete(udn_schema, "profiler")
+	} else {
+		udn_schema["profiler"] = profiler
+	}
+	delete(udn_schema, "profile_stack")
+}
+
+// Returns the profiler of this execution, or its engine's Profiler, or nil if it isnt being profiled
+func GetUdnProfiler(udn_schema map[string]interface{}) *UdnProfiler {
+	if profiler, ok := udn_schema["profiler"].(*UdnProfiler); ok {
+		return profiler
+	}
+
+	return GetUdnEngine(udn_schema).Profiler
+}
+
+// Start profiling a function.  Returns the frame to pass to _EndFunction, when it has finished.
+func (profiler *UdnProfiler) _BeginFunction(udn_schema map[string]interface{}, udn_start *UdnPart) *_UdnProfileFrame {
+	stack, ok := udn_schema["profile_stack"].(*_UdnProfileStack)
+	if !ok {
+		stack = &_UdnProfileStack{}
+		udn_schema["profile_stack"] = stack
+	}
+
+	frame := &_UdnProfileFrame{name: udn_start.Value, start: time.Now()}
+	stack.frames = append(stack.frames, frame)
+
+	return frame
+}
+
+// Our arguments are processed, and we know our name, if we are a stored or defined function
+func (frame *_UdnProfileFrame) _ArgsDone(args []interface{}) {
+	frame.args = time.Since(frame.start)
+
+	if (frame.name == "__function" || frame.name == "__call") && len(args) > 0 {
+		if function_name, ok := args[0].(string); ok {
+			frame.name += ":" + function_name
+		}
+	}
+}
+
+func (profiler *UdnProfiler) _EndFunction(udn_schema map[string]interface{}, frame *_UdnProfileFrame) {
+	total := time.Since(frame.start)
+
+	stack, _ := udn_schema["profile_stack"].(*_UdnProfileStack)
+	if stack == nil {
+		return
+	}
+
+	// Frames above ours never ended (they panicked), so they are dropped with ours
+	index := len(stack.frames) - 1
+	for index >= 0 && stack.frames[index] != frame {
+		index--
+	}
+	if index < 0 {
+		return
+	}
+
+	folded_stack := stack._Folded(index)
+	stack.frames = stack.frames[:index]
+
+	if index > 0 {
+		atomic.AddInt64(&stack.frames[index-1].children, int64(total))
+	} else if stack.parent != nil {
+		atomic.AddInt64(&stack.parent.children, int64(total))
+	}
+
+	// Blocks that ran at the same time can add up to more than we took, then we were only waiting for them
+	self := total - time.Duration(atomic.LoadInt64(&frame.children))
+	if self < 0 {
+		self = 0
+	}
+
+	profiler.lock.Lock()
+	defer profiler.lock.Unlock()
+
+	stat, ok := profiler.functions[frame.name]
+	if !ok {
+		stat = &UdnProfileStat{Name: frame.name}
+		profiler.functions[frame.name] = stat
+	}
+
+	stat.Calls++
+	stat.Total += total
+	stat.Self += self
+	stat.Args += frame.args
+
+	profiler.folded[folded_stack] += self
+}
+
+// The folded stack of the frame at index: "outer;inner;function"
+func (stack *_UdnProfileStack) _Folded(index int) string {
+	names := make([]string, 0, index+2)
+	if stack.prefix != "" {
+		names = append(names, stack.prefix)
+	}
+	for _, frame := range stack.frames[:index+1] {
+		names = append(names, frame.name)
+	}
+
+	return strings.Join(names, ";")
+}
+
+// Concurrent blocks run on their own goroutines, so they need their own stack
+func _CopyUdnProfileStackForBlock(udn_schema map[string]interface{}, block_schema map[string]interface{}) {
+	stack, ok := udn_schema["profile_stack"].(*_UdnProfileStack)
+	if !ok {
+		return
+	}
+
+	block_stack := &_UdnProfileStack{prefix: stack.prefix, parent: stack.parent}
+	if len(stack.frames) > 0 {
+		block_stack.prefix = stack._Folded(len(stack.frames) - 1)
+		block_stack.parent = stack.frames[len(stack.frames)-1]
+	}
+
+	block_schema["profile_stack"] = block_stack
+}
+
+// Returns the stats of every function, slowest (by self time) first.  Returns them all if count is 0.
+func (profiler *UdnProfiler) Top(count int) []UdnProfileStat {
+	profiler.lock.Lock()
+	stats := make([]UdnProfileStat, 0, len(profiler.functions))
+	for _, stat := range profiler.functions {
+		stats = append(stats, *stat)
+	}
+	profiler.lock.Unlock()
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Self != stats[j].Self {
+			return stats[i].Self > stats[j].Self
+		}
+		return stats[i].Name < stats[j].Name
+	})
+
+	if count > 0 && len(stats) > count {
+		stats = stats[:count]
+	}
+
+	return stats
+}
+
+// Write the top count functions as a table
+func (profiler *UdnProfiler) WriteTop(writer io.Writer, count int) error {
+	table := tabwriter.NewWriter(writer, 0, 4, 2, ' ', tabwriter.AlignRight)
+
+	fmt.Fprintf(table, "Calls\tSelf\tSelf %%\tTotal\tArgs\tSelf/Call\t  Function\n")
+
+	stats := profiler.Top(0)
+
+	all_self := time.Duration(0)
+	for _, stat := range stats {
+		all_self += stat.Self
+	}
+
+	for index, stat := range stats {
+		if count > 0 && index >= count {
+			break
+		}
+
+		percent := 0.0
+		if all_self > 0 {
+			percent = float64(stat.Self) * 100 / float64(all_self)
+		}
+
+		fmt.Fprintf(table, "%d\t%s\t%.1f%%\t%s\t%s\t%s\t  %s\n", stat.Calls, _ProfileDuration(stat.Self), percent, _ProfileDuration(stat.Total), _ProfileDuration(stat.Args), _ProfileDuration(stat.Self/time.Duration(stat.Calls)), stat.Name)
+	}
+
+	return table.Flush()
+}
+
+// Write the folded stacks, one per line, with their self time in microseconds: "__iterate;__input 25".  This is the input format of flamegraph.pl, speedscope and most flame graph tools.
+func (profiler *UdnProfiler) WriteFolded(writer io.Writer) error {
+	profiler.lock.Lock()
+	folded_stacks := make([]string, 0, len(profiler.folded))
+	for folded_stack := range profiler.folded {
+		folded_stacks = append(folded_stacks, folded_stack)
+	}
+	sort.Strings(folded_stacks)
+
+	lines := make([]string, 0, len(folded_stacks))
+	for _, folded_stack := range folded_stacks {
+		lines = append(lines, fmt.Sprintf("%s %d\n", folded_stack, profiler.folded[folded_stack].Microseconds()))
+	}
+	profiler.lock.Unlock()
+
+	for _, line := range lines {
+		if _, err := io.WriteString(writer, line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Forget everything profiled so far
+func (profiler *UdnProfiler) Reset() {
+	profiler.lock.Lock()
+	defer profiler.lock.Unlock()
+
+	profiler.Started = time.Now()
+	profiler.functions = make(map[string]*UdnProfileStat)
+	profiler.folded = make(map[string]time.Duration)
+}
+
+func _ProfileDuration(duration time.Duration) string {
+	return duration.Round(time.Microsecond).String()
+}
@@ -0,0 +1,81 @@
+package yudien
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestUdnProfiler(t *testing.T) {
+	udn_schema := testUdnSchema()
+	udn_data := map[string]interface{}{}
+
+	profiler := NewUdnProfiler()
+	SetUdnProfiler(udn_schema, profiler)
+
+	ProcessUDN(nil, udn_schema, []string{
+		"__define.double.x.__math.multiply.(__get_temp.x).2.__end_define",
+		"__input.[1,2,3].__iterate.__call.double.(__get_temp.x).__end_iterate",
+	}, udn_data)
+
+	calls := map[string]int64{}
+	for _, stat := range profiler.Top(0) {
+		calls[stat.Name] = stat.Calls
+
+		if stat.Self > stat.Total || stat.Args > stat.Total {
+			t.Errorf("Self and Args time cant be more than Total: %+v", stat)
+		}
+	}
+
+	// Defined functions are profiled by their own name
+	expected := map[string]int64{"__define": 1, "__input": 1, "__iterate": 1, "__call:double": 3, "__math": 3, "__get_temp": 6}
+	for name, count := range expected {
+		if calls[name] != count {
+			t.Errorf("%s: Expected %d calls, got %d  %v", name, count, calls[name], calls)
+		}
+	}
+
+	if len(profiler.Top(2)) != 2 {
+		t.Errorf("Top did not limit the functions: %v", profiler.Top(2))
+	}
+
+	folded := &bytes.Buffer{}
+	if err := profiler.WriteFolded(folded); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(folded.String(), "__iterate;__call:double;__math ") || !strings.Contains(folded.String(), "__iterate;__call:double;__math;__get_temp ") {
+		t.Errorf("Unexpected folded stacks:\n%s", folded)
+	}
+
+	table := &bytes.Buffer{}
+	profiler.WriteTop(table, 3)
+	if lines := strings.Split(strings.TrimSpace(table.String()), "\n"); len(lines) != 4 || !strings.Contains(lines[0], "Self") {
+		t.Errorf("Unexpected top table:\n%s", table)
+	}
+
+	// Executions without a profiler arent profiled
+	SetUdnProfiler(udn_schema, nil)
+	profiler.Reset()
+	ProcessSingleUDNTarget(nil, udn_schema, "__input.1", nil, udn_data)
+	if len(profiler.Top(0)) != 0 {
+		t.Errorf("Execution was profiled without a profiler: %v", profiler.Top(0))
+	}
+}
+
+func TestUdnProfilerConcurrentBlocks(t *testing.T) {
+	udn_schema := testUdnSchema()
+	udn_data := map[string]interface{}{}
+
+	profiler := NewUdnProfiler()
+	SetUdnProfiler(udn_schema, profiler)
+
+	ProcessSingleUDNTarget(nil, udn_schema, "__input.[1,2,3,4].__parallel_iterate.2.__input.(__get_temp.x).__end_parallel_iterate", nil, udn_data)
+
+	folded := &bytes.Buffer{}
+	profiler.WriteFolded(folded)
+
+	// Each worker has its own stack, under the function that started it
+	if !strings.Contains(folded.String(), "__parallel_iterate;__input;__get_temp ") {
+		t.Errorf("Unexpected folded stacks:\n%s", folded)
+	}
+}
@@ -0,0 +1,190 @@
+package yudien
+
+import (
+	"database/sql"
+	. "github.com/ghowland/yudien/yudiencore"
+	. "github.com/ghowland/yudien/yudienutil"
+)
+
+// Variables (__let/__var) live in scopes, which are kept in the current function stack frame, so a stored function or __call never sees its caller's variables.  Each __iterate and __while loop, and each __if block, runs in a new scope that is removed when it finishes, so variables set in a loop body dont leak into the next loop, or after the block.
+
+// Returns the current function stack frame.  ProcessUDN and ProcessSingleUDNTarget add a frame for statements executed without one, so this only happens when a function is called directly from Go, which gets a temporary frame that isnt kept.
+func _GetUdnFunctionStackFrame(udn_data map[string]interface{}) map[string]interface{} {
+	if !_HasUdnFunctionStackFrame(udn_data) {
+		return map[string]interface{}{"uuid": ""}
+	}
+
+	function_stack := udn_data["__function_stack"].([]map[string]interface{})
+
+	return function_stack[len(function_stack)-1]
+}
+
+// Adds a function stack frame if there isnt one, for statements executed without ProcessSchemaUDNSet (ex: ProcessSingleUDNTarget from Go).  Returns true if a frame was added, which the caller pops when the statement finishes.
+func _PushUdnFunctionStackIfNone(udn_data map[string]interface{}) bool {
+	if udn_data == nil || _HasUdnFunctionStackFrame(udn_data) {
+		return false
+	}
+
+	PushUdnFunctionStack(udn_data)
+
+	return true
+}
+
+// Pop the frame _PushUdnFunctionStackIfNone added, and the empty stack and temp data it leaves, so udn_data is left like our caller gave it to us
+func _PopUdnFunctionStackAdded(udn_data map[string]interface{}) {
+	PopUdnFunctionStack(udn_data)
+
+	if _GetUdnFunctionStackDepth(udn_data) == 0 {
+		delete(udn_data, "__function_stack")
+	}
+	if temp_udn_data, ok := udn_data["__temp"].(map[string]interface{}); ok && len(temp_udn_data) == 0 {
+		delete(udn_data, "__temp")
+	}
+}
+
+// Returns the temp data (__get_temp/__set_temp) for the current function stack frame.  Without a frame, it is temporary.
+func _GetUdnTempData(udn_data map[string]interface{}) map[string]interface{} {
+	if !_HasUdnFunctionStackFrame(udn_data) {
+		return make(map[string]interface{})
+	}
+
+	function_uuid := _GetUdnFunctionStackFrame(udn_data)["uuid"].(string)
+
+	// Ensure temp exists
+	if udn_data["__temp"] == nil {
+		udn_data["__temp"] = make(map[string]interface{})
+	}
+
+	// Ensure this Function Temp exists
+	if udn_data["__temp"].(map[string]interface{})[function_uuid] == nil {
+		udn_data["__temp"].(map[string]interface{})[function_uuid] = make(map[string]interface{})
+	}
+
+	return udn_data["__temp"].(map[string]interface{})[function_uuid].(map[string]interface{})
+}
+
+// Returns the variable scopes of the current function stack frame, outermost first.  The first scope lasts as long as the frame.
+func _GetUdnScopes(udn_data map[string]interface{}) []map[string]interface{} {
+	function_stack_frame := _GetUdnFunctionStackFrame(udn_data)
+
+	scopes, ok := function_stack_frame["scopes"].([]map[string]interface{})
+	if !ok {
+		scopes = []map[string]interface{}{make(map[string]interface{})}
+		function_stack_frame["scopes"] = scopes
+	}
+
+	return scopes
+}
+
+// Returns true if there is a function stack frame to keep scopes in.  Blocks dont add a frame on their own, so executing a block without one doesnt leave a __function_stack behind in udn_data.
+func _HasUdnFunctionStackFrame(udn_data map[string]interface{}) bool {
+	return _GetUdnFunctionStackDepth(udn_data) > 0
+}
+
+// Returns how many frames are in udn_data["__function_stack"]
+func _GetUdnFunctionStackDepth(udn_data map[string]interface{}) int {
+	function_stack, _ := udn_data["__function_stack"].([]map[string]interface{})
+	return len(function_stack)
+}
+
+// Returns how many scopes the current function stack frame has, without adding any
+func _GetUdnScopeDepth(udn_data map[string]interface{}) int {
+	if !_HasUdnFunctionStackFrame(udn_data) {
+		return 0
+	}
+
+	scopes, _ := _GetUdnFunctionStackFrame(udn_data)["scopes"].([]map[string]interface{})
+
+	return len(scopes)
+}
+
+// Remove the scopes of blocks that didnt finish (a recovered panic), back to scope_depth.  The frame's scope is kept.
+func _RestoreUdnScopeDepth(udn_data map[string]interface{}, scope_depth int) {
+	for current_depth := _GetUdnScopeDepth(udn_data); current_depth > scope_depth && current_depth > 1; current_depth-- {
+		PopUdnScope(udn_data)
+	}
+}
+
+// Start a new variable scope, for a block.  Every PushUdnScope must have a PopUdnScope, when the block finishes.
+func PushUdnScope(udn_data map[string]interface{}) {
+	if !_HasUdnFunctionStackFrame(udn_data) {
+		return
+	}
+
+	scopes := _GetUdnScopes(udn_data)
+
+	_GetUdnFunctionStackFrame(udn_data)["scopes"] = append(scopes, make(map[string]interface{}))
+}
+
+// Remove the innermost variable scope, and its variables
+func PopUdnScope(udn_data map[string]interface{}) {
+	if !_HasUdnFunctionStackFrame(udn_data) {
+		return
+	}
+
+	scopes := _GetUdnScopes(udn_data)
+
+	// The frame's scope is removed with the frame
+	if len(scopes) > 1 {
+		_GetUdnFunctionStackFrame(udn_data)["scopes"] = scopes[:len(scopes)-1]
+	}
+}
+
+func UDN_Let(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart, args []interface{}, input interface{}, udn_data map[string]interface{}) UdnResult {
+	// Sets the input into a variable in the current scope, like __set.  The variable is removed when the block it was set in finishes.
+	scopes := _GetUdnScopes(udn_data)
+
+	UdnLogLevel(udn_schema, log_trace, "Let: %v  Scope: %d  Input: %s\n", SnippetData(args, 80), len(scopes)-1, SnippetData(input, 40))
+
+	result := UdnResult{}
+	result.Result = MapSet(args, input, scopes[len(scopes)-1])
+
+	return result
+}
+
+func UDN_Var(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart, args []interface{}, input interface{}, udn_data map[string]interface{}) UdnResult {
+	// Gets a variable, like __get.  The innermost scope the variable was set in is used, so blocks can read their outer block's variables.  Unset variables are nil.
+	scopes := _GetUdnScopes(udn_data)
+
+	UdnLogLevel(udn_schema, log_trace, "Var: %v\n", SnippetData(args, 80))
+
+	result := UdnResult{}
+
+	if len(args) == 0 {
+		return result
+	}
+
+	variable_name := GetResult(args[0], type_string).(string)
+
+	for index := len(scopes) - 1; index >= 0; index-- {
+		if _, ok := scopes[index][variable_name]; ok {
+			result.Result = MapGet(args, scopes[index])
+			break
+		}
+	}
+
+	return result
+}
+
+func UDN_ClearTemp(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart, args []interface{}, input interface{}, udn_data map[string]interface{}) UdnResult {
+	// Clears the current function's temp data.  If args are given, each one is a key to remove, and the rest of the temp data is kept.
+	UdnLogLevel(udn_schema, log_trace, "Clear Temp: %v\n", SnippetData(args, 80))
+
+	temp_udn_data := _GetUdnTempData(udn_data)
+
+	if len(args) == 0 {
+		for key := range temp_udn_data {
+			delete(temp_udn_data, key)
+		}
+	} else {
+		for _, arg := range args {
+			delete(temp_udn_data, GetResult(arg, type_string).(string))
+		}
+	}
+
+	// Input passes through
+	result := UdnResult{}
+	result.Result = input
+
+	return result
+}
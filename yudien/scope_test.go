@@ -0,0 +1,102 @@
+package yudien
+
+import (
+	"database/sql"
+	"testing"
+
+	. "github.com/ghowland/yudien/yudiencore"
+	. "github.com/ghowland/yudien/yudienutil"
+)
+
+func TestUdnScopes(t *testing.T) {
+	udn_schema := testUdnSchema()
+	udn_data := map[string]interface{}{}
+
+	// Each loop gets its own variables, the outer variable is readable, and nothing is left after the loop
+	udn_list := []string{
+		"__input.10.__let.base",
+		"__input.[1,2,3].__iterate.__let.item.__if.(__compare_equal.(__var.item).2).__input.skipped.__let.inner.__end_if.__math.add.(__var.item).(__var.base).__end_iterate.__set.result",
+		"__input.[(__var.item),(__var.inner),(__var.base)]",
+	}
+
+	result := ProcessUDN(nil, udn_schema, udn_list, udn_data)
+
+	if JsonDumpData(udn_data["result"]) != "[11,12,13]" {
+		t.Fatalf("Unexpected loop results: %v  Error: %v", udn_data["result"], GetUdnError(udn_schema))
+	}
+	if JsonDumpData(result) != "[null,null,10]" {
+		t.Errorf("Variables leaked out of their blocks: %v", result)
+	}
+
+	// A called function does not see its caller's variables
+	udn_list = []string{
+		"__define.peek.__var.base.__end_define",
+		"__input.10.__let.base.__call.peek",
+	}
+	if result := ProcessUDN(nil, udn_schema, udn_list, udn_data); result != nil {
+		t.Errorf("Called function saw the caller's variable: %v", result)
+	}
+}
+
+func TestUdnTempClear(t *testing.T) {
+	udn_schema := testUdnSchema()
+	udn_data := map[string]interface{}{}
+
+	result := ProcessUDN(nil, udn_schema, []string{"__input.1.__set_temp.a.__input.2.__set_temp.b.__temp_clear.a.__input.[(__get_temp.a),(__get_temp.b)]"}, udn_data)
+	if JsonDumpData(result) != `[null,2]` {
+		t.Fatalf("Unexpected temp data after clearing a key: %v", result)
+	}
+
+	result = ProcessUDN(nil, udn_schema, []string{"__input.1.__set_temp.a.__temp_clear.__input.[(__get_temp.a),(__get_temp.b)]"}, udn_data)
+	if JsonDumpData(result) != `[null,null]` {
+		t.Fatalf("Unexpected temp data after clearing: %v", result)
+	}
+}
+
+func TestUdnScopeFrames(t *testing.T) {
+	udn_schema := testUdnSchema()
+	udn_data := map[string]interface{}{}
+
+	// Statements executed without a frame get one that is removed when they finish
+	if result := ProcessSingleUDNTarget(nil, udn_schema, "__input.1.__let.x.__set_temp.y.__get_temp.y", nil, udn_data); result != int64(1) {
+		t.Fatalf("Unexpected result: %v  Error: %v", result, GetUdnError(udn_schema))
+	}
+	if udn_data["__function_stack"] != nil || udn_data["__temp"] != nil {
+		t.Errorf("Frame was left in udn_data: %v", udn_data)
+	}
+
+	// Functions called directly dont add a frame either
+	UDN_SetTemp(nil, udn_schema, nil, []interface{}{"y"}, 1, udn_data)
+	if udn_data["__function_stack"] != nil || udn_data["__temp"] != nil {
+		t.Errorf("Frame was left in udn_data by a direct call: %v", udn_data)
+	}
+}
+
+func TestUdnScopePanic(t *testing.T) {
+	engine := NewEngine()
+	engine.RegisterUdnFunction(&UdnFunctionSignature{
+		Name: "__test_scope_panic",
+		Function: func(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart, args []interface{}, input interface{}, udn_data map[string]interface{}) UdnResult {
+			PushUdnScope(udn_data)
+			UDN_Let(db, udn_schema, udn_start, []interface{}{"leaked"}, input, udn_data)
+			panic("block did not finish")
+		},
+	})
+
+	udn_schema := engine.NewUdnSchema()
+	udn_data := map[string]interface{}{}
+	PushUdnFunctionStack(udn_data)
+
+	engine.ProcessSingleUDNTarget(nil, udn_schema, "__input.1.__let.x.__test_scope_panic", nil, udn_data)
+	if GetUdnError(udn_schema) == nil {
+		t.Fatalf("Panic was not an error")
+	}
+
+	// The panicking block's scope was removed, the frame's scope is kept
+	if depth := _GetUdnScopeDepth(udn_data); depth != 1 {
+		t.Errorf("Unexpected scope depth after a panic: %d", depth)
+	}
+	if result := engine.ProcessSingleUDNTarget(nil, udn_schema, "__input.[(__var.x),(__var.leaked)]", nil, udn_data); JsonDumpData(result) != "[1,null]" {
+		t.Errorf("Unexpected variables after a panic: %v", result)
+	}
+}
@@ -0,0 +1,158 @@
+package yudien
+
+import (
+	"database/sql"
+	. "github.com/ghowland/yudien/yudiencore"
+	. "github.com/ghowland/yudien/yudienutil"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// Describes a UDN function, so it can be registered, documented and checked.  RegisterUdnFunction puts the Function into UdnFunctions, and the signatures generate docs/yudien_functions.md (cmd/udn-docs), answer __help and drive Validate.
+type UdnFunctionSignature struct {
+	Name  string `json:"name"`
+	Title string `json:"title,omitempty"`
+	Group string `json:"group,omitempty"` // UdnFunctionGroups name, which is the section it is documented in
+
+	Description string `json:"description,omitempty"` // Markdown
+
+	Input      string            `json:"input,omitempty"`
+	Args       []UdnArgSignature `json:"args"`
+	Output     string            `json:"output,omitempty"`
+	SideEffect string            `json:"side_effect,omitempty"`
+
+	// Changes data outside the execution: databases, files, processes, emails.  Not allowed by a read-only UdnPolicy.
+	Writes bool `json:"writes,omitempty"`
+
+	// The args are the keys of a location in data (ex: __get), so literals in them are kept as their text: __get.x.null gets the key "null", and __input_get.true the key "true"
+	KeyArgs bool `json:"key_args,omitempty"`
+
+	Examples []UdnFunctionExample `json:"examples,omitempty"`
+	Related  []string             `json:"related,omitempty"`
+
+	// Block functions name the __end_* function that closes them, and the __end_* function names the block it closes
+	BlockEnd   string `json:"block_end,omitempty"`
+	BlockBegin string `json:"block_begin,omitempty"`
+
+	// Old names that still work, are set to the name that should be used instead
+	DeprecatedBy string `json:"deprecated_by,omitempty"`
+
+	// Checked by Validate, for functions that index their args without checking them, so too few args is a panic at request time.  nil is not checked.
+	ArgCount *UdnArgCount `json:"arg_count,omitempty"`
+
+	// nil for the block end functions, which ExecuteUdnPart handles itself
+	Function UdnFunc `json:"-"`
+}
+
+type UdnArgSignature struct {
+	Name        string `json:"name"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+	Optional    bool   `json:"optional,omitempty"`
+	Variadic    bool   `json:"variadic,omitempty"` // Repeats for any number of args
+}
+
+type UdnFunctionExample struct {
+	Description string `json:"description,omitempty"`
+	Udn         string `json:"udn"`
+	Result      string `json:"result,omitempty"`
+}
+
+// Number of arguments a function accepts.  Max of -1 means any number of arguments.
+type UdnArgCount struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// A section of the function docs
+type UdnFunctionGroup struct {
+	Name  string
+	Title string
+}
+
+var UdnFunctionGroups = []UdnFunctionGroup{
+	{"data_access", "Data Access"},
+	{"database", "Database"},
+	{"looping", "Conditions and Looping"},
+	{"execution", "Execution Control"},
+	{"text", "Text"},
+	{"map", "Maps"},
+	{"array", "Array"},
+	{"time", "Time"},
+	{"math", "Math"},
+	{"rendering", "Rendering"},
+	{"networking", "Networking"},
+	{"user", "User"},
+	{"special", "Special"},
+	{"debugging", "Debugging"},
+	{"comments", "Comments"},
+	{"custom", "Custom"},
+}
+
+var UdnFunctionSignatures = map[string]*UdnFunctionSignature{}
+
+// Names of the registered functions, in the order they were registered, so the docs keep their order
+var UdnFunctionNames = []string{}
+
+// Register a UDN function with the default engine, so it can be called by name.  Registering a name again replaces the earlier function.
+func RegisterUdnFunction(signature *UdnFunctionSignature) {
+	DefaultEngine.RegisterUdnFunction(signature)
+
+	UdnFunctionNames = DefaultEngine.FunctionNames
+}
+
+// Name of the Go function that implements this, or "nil" for block end functions
+func (signature *UdnFunctionSignature) GoName() string {
+	if signature.Function == nil {
+		return "nil"
+	}
+
+	name := runtime.FuncForPC(reflect.ValueOf(signature.Function).Pointer()).Name()
+
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+// Returns the signature of arg_0 (or input) as a map, or the sorted list of function names if no function was given
+func UDN_Help(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart, args []interface{}, input interface{}, udn_data map[string]interface{}) UdnResult {
+	function_name := ""
+	if len(args) > 0 {
+		function_name = GetResult(args[0], type_string).(string)
+	} else if input != nil {
+		function_name = GetResult(input, type_string).(string)
+	}
+
+	UdnLogLevel(udn_schema, log_trace, "Help: %s\n", function_name)
+
+	result := UdnResult{}
+
+	if function_name == "" {
+		names := append([]string{}, GetUdnEngine(udn_schema).FunctionNames...)
+		sort.Strings(names)
+
+		name_array := make([]interface{}, 0, len(names))
+		for _, name := range names {
+			name_array = append(name_array, name)
+		}
+		result.Result = name_array
+
+		return result
+	}
+
+	signature, ok := GetUdnEngine(udn_schema).FunctionSignatures[function_name]
+	if !ok {
+		return UdnResultError("Help: Unknown function: %s", function_name)
+	}
+
+	// Return plain data, so it can be used with __get/__iterate like any other result
+	signature_map, err := JsonLoadMap(JsonDumpData(signature))
+	if err != nil {
+		return UdnResultError("Help: %s: %s", function_name, err)
+	}
+	signature_map["go"] = signature.GoName()
+
+	result.Result = signature_map
+
+	return result
+}
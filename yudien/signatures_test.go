@@ -0,0 +1,77 @@
+package yudien
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+func TestUdnFunctionSignatures(t *testing.T) {
+	groups := map[string]bool{}
+	for _, group := range UdnFunctionGroups {
+		groups[group.Name] = true
+	}
+
+	registered := func(name string) bool {
+		_, ok := UdnFunctionSignatures[name]
+		return ok
+	}
+
+	for _, name := range UdnFunctionNames {
+		signature := UdnFunctionSignatures[name]
+
+		if !groups[signature.Group] {
+			t.Errorf("%s: unknown group: %q", name, signature.Group)
+		}
+		for _, related := range signature.Related {
+			if !registered(related) {
+				t.Errorf("%s: related function is not registered: %s", name, related)
+			}
+		}
+		for _, other := range []string{signature.BlockEnd, signature.BlockBegin, signature.DeprecatedBy} {
+			if other != "" && !registered(other) {
+				t.Errorf("%s: %s is not registered", name, other)
+			}
+		}
+		if signature.BlockEnd != "" && UdnFunctionSignatures[signature.BlockEnd].BlockBegin != name {
+			t.Errorf("%s: %s does not name it as its BlockBegin", name, signature.BlockEnd)
+		}
+	}
+}
+
+func TestUdnFunctionDocs(t *testing.T) {
+	docs, err := ioutil.ReadFile("../docs/yudien_functions.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(docs) != FormatUdnFunctionDocs() {
+		t.Errorf("docs/yudien_functions.md is out of date with the function signatures, run: go run ./cmd/udn-docs")
+	}
+}
+
+func TestUdnHelp(t *testing.T) {
+	udn_schema := testUdnSchema()
+	udn_data := map[string]interface{}{}
+
+	result := ProcessSingleUDNTarget(nil, udn_schema, "__help.'__group_by'", nil, udn_data)
+
+	signature, ok := result.(map[string]interface{})
+	if !ok || signature["name"] != "__group_by" || signature["go"] != "UDN_GroupBy" {
+		t.Fatalf("Unexpected signature: %v", result)
+	}
+
+	arg_0 := signature["args"].([]interface{})[0].(map[string]interface{})
+	if arg_0["name"] != "method" || arg_0["type"] != "string" {
+		t.Errorf("Unexpected arg 0: %v", arg_0)
+	}
+
+	names := ProcessSingleUDNTarget(nil, udn_schema, "__help", nil, udn_data).([]interface{})
+	if len(names) != len(UdnFunctionNames) || names[0].(string) > names[1].(string) {
+		t.Errorf("Unexpected function names: %v", names)
+	}
+
+	ProcessSingleUDNTarget(nil, udn_schema, "__help.not_a_function", nil, udn_data)
+	if GetUdnError(udn_schema) == nil {
+		t.Errorf("Unknown function did not return an error")
+	}
+}
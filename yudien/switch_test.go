@@ -0,0 +1,41 @@
+package yudien
+
+import (
+	"testing"
+
+	. "github.com/ghowland/yudien/yudienutil"
+)
+
+func TestUdnSwitch(t *testing.T) {
+	udn_schema := testUdnSchema()
+	udn_data := map[string]interface{}{}
+
+	status_switch := "__switch.__case.ok.__input.green.__case.warning.degraded.__input.yellow.__default.__input.red.__end_switch"
+
+	tests := []struct {
+		udn    string
+		result string
+	}{
+		{"__input.ok." + status_switch, `"green"`},
+		{"__input.degraded." + status_switch, `"yellow"`},
+		{"__input.down." + status_switch, `"red"`},
+		// Without a __default, the input passes through
+		{"__input.down.__switch.__case.ok.__input.green.__end_switch", `"down"`},
+		// Compared like __compare_equal, so ints match their strings
+		{"__input.2.__switch.__case.'1'.__input.one.__case.'2'.__input.two.__end_switch", `"two"`},
+		// Nested blocks in an arm have their own __case and __end_if, and a __break in an arm goes to the loop
+		{"__input.[1,2,3].__iterate.__switch.__case.1.__switch.__case.5.__input.five.__default.__input.inner.__end_switch.__case.2.__if.1.__input.two.__end_if.__case.3.__break.__end_switch.__end_iterate", `["inner","two"]`},
+	}
+
+	for _, test := range tests {
+		result := ProcessSingleUDNTarget(nil, udn_schema, test.udn, nil, udn_data)
+
+		if JsonDumpData(result) != test.result {
+			t.Errorf("%s: got %s, want %s  Error: %v", test.udn, JsonDumpData(result), test.result, GetUdnError(udn_schema))
+		}
+	}
+
+	if result := ProcessSingleUDNTarget(nil, udn_schema, "__input.1.__switch.__default.__default.__end_switch", nil, udn_data); result != nil || GetUdnError(udn_schema) == nil {
+		t.Errorf("Two __default arms did not fail: %v", result)
+	}
+}
@@ -0,0 +1,427 @@
+package yudien
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	. "github.com/ghowland/yudien/yudiencore"
+	. "github.com/ghowland/yudien/yudienutil"
+	"github.com/segmentio/ksuid"
+	"html/template"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// An execution trace records every UDN statement and function executed as nested spans, with their args, input and output (as snippets), and how long they took.  Start one for a request with StartUdnTrace, and write it with WriteUdnTrace (JSON lines, and an offline HTML viewer) when the request is done.
+//NOTE(g): While tracing, concurrent blocks and __parallel_iterate run one at a time, like they do for debug logging, so spans nest in execution order
+
+// How much of the args, input and output is kept in a span
+const udn_trace_snippet_size = 300
+
+// HTML debug logs kept outside of LoggingConfig.TracePath
+const udn_debug_html_keep = 20
+
+type UdnTraceSpan struct {
+	Id       int    `json:"id"`
+	ParentId int    `json:"parent_id"` // 0 for statements at the top of the trace
+	Depth    int    `json:"depth"`
+	Kind     string `json:"kind"` // "statement" or "function"
+	Name     string `json:"name"` // The UDN statement, or the function name
+	PartId   string `json:"part_id,omitempty"`
+
+	Args   string `json:"args,omitempty"`
+	Input  string `json:"input,omitempty"`
+	Output string `json:"output,omitempty"`
+	Error  string `json:"error,omitempty"`
+
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"duration_ns"`
+
+	Children []*UdnTraceSpan `json:"-"`
+}
+
+type UdnTrace struct {
+	Id      string
+	Started time.Time
+
+	// Top level spans, in execution order
+	Spans []*UdnTraceSpan
+
+	lock    sync.Mutex
+	next_id int
+
+	// Spans that havent ended yet, innermost last
+	open []*UdnTraceSpan
+}
+
+// Start tracing the execution that udn_schema is for
+func StartUdnTrace(udn_schema map[string]interface{}) *UdnTrace {
+	trace := &UdnTrace{Id: ksuid.New().String(), Started: time.Now()}
+
+	udn_schema["trace"] = trace
+
+	return trace
+}
+
+// Returns the trace of this execution, or nil if it isnt being traced
+func GetUdnTrace(udn_schema map[string]interface{}) *UdnTrace {
+	if udn_schema == nil {
+		return nil
+	}
+
+	trace, _ := udn_schema["trace"].(*UdnTrace)
+	return trace
+}
+
+// Start a span, nested in the innermost span that hasnt ended.  Returns nil if there is no trace, which all the span functions accept.
+func (trace *UdnTrace) BeginSpan(kind string, name string, part_id string, input interface{}) *UdnTraceSpan {
+	if trace == nil {
+		return nil
+	}
+
+	trace.lock.Lock()
+	defer trace.lock.Unlock()
+
+	trace.next_id++
+
+	span := &UdnTraceSpan{Id: trace.next_id, Kind: kind, Name: name, PartId: part_id, Start: time.Now()}
+
+	if input != nil {
+		span.Input = SnippetData(input, udn_trace_snippet_size)
+	}
+
+	if len(trace.open) > 0 {
+		parent := trace.open[len(trace.open)-1]
+
+		span.ParentId = parent.Id
+		span.Depth = parent.Depth + 1
+		parent.Children = append(parent.Children, span)
+	} else {
+		trace.Spans = append(trace.Spans, span)
+	}
+
+	trace.open = append(trace.open, span)
+
+	return span
+}
+
+// End a span, with its output or error
+func (trace *UdnTrace) EndSpan(span *UdnTraceSpan, output interface{}, error_message string) {
+	if trace == nil || span == nil {
+		return
+	}
+
+	trace.lock.Lock()
+	defer trace.lock.Unlock()
+
+	span.Duration = time.Since(span.Start)
+	span.Error = error_message
+	if output != nil {
+		span.Output = SnippetData(output, udn_trace_snippet_size)
+	}
+
+	// Spans nested in this one that never ended (their function panicked) end with it
+	for index := len(trace.open) - 1; index >= 0; index-- {
+		if trace.open[index] == span {
+			for _, unfinished := range trace.open[index+1:] {
+				unfinished.Duration = time.Since(unfinished.Start)
+				if unfinished.Error == "" {
+					unfinished.Error = "Did not finish"
+				}
+			}
+
+			trace.open = trace.open[:index]
+			break
+		}
+	}
+}
+
+func (span *UdnTraceSpan) SetArgs(args []interface{}) {
+	if span != nil {
+		span.Args = SnippetData(args, udn_trace_snippet_size)
+	}
+}
+
+// Execute a UDN statement as a span of the execution trace, if there is one
+func _ExecuteUdnStatement(db *sql.DB, udn_schema map[string]interface{}, statement string, udn_start *UdnPart, input interface{}, udn_data map[string]interface{}) interface{} {
+	trace := GetUdnTrace(udn_schema)
+	if trace == nil {
+		return ExecuteUdn(db, udn_schema, udn_start, input, udn_data)
+	}
+
+	span := trace.BeginSpan("statement", statement, "", input)
+
+	result := ExecuteUdn(db, udn_schema, udn_start, input, udn_data)
+
+	trace.EndSpan(span, result, _UdnTraceError(udn_schema, ""))
+
+	return result
+}
+
+// The error a span ended with: the error unwinding through it, or the error its function returned
+func _UdnTraceError(udn_schema map[string]interface{}, error_message string) string {
+	if udn_error := GetUdnError(udn_schema); udn_error != nil {
+		return fmt.Sprintf("%v", udn_error["message"])
+	}
+
+	return error_message
+}
+
+// Returns all the spans, parents before their children, in execution order
+func (trace *UdnTrace) AllSpans() []*UdnTraceSpan {
+	trace.lock.Lock()
+	defer trace.lock.Unlock()
+
+	spans := make([]*UdnTraceSpan, 0)
+
+	var add_spans func(span_list []*UdnTraceSpan)
+	add_spans = func(span_list []*UdnTraceSpan) {
+		for _, span := range span_list {
+			spans = append(spans, span)
+			add_spans(span.Children)
+		}
+	}
+	add_spans(trace.Spans)
+
+	return spans
+}
+
+// Write the trace as JSON lines: one span per line, parents before their children
+func (trace *UdnTrace) WriteJsonLines(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+
+	for _, span := range trace.AllSpans() {
+		if err := encoder.Encode(span); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Write a self-contained HTML page that renders the trace, with no network access.  The page can also open any trace's JSON lines file.
+func (trace *UdnTrace) WriteHtml(writer io.Writer) error {
+	json_lines := &strings.Builder{}
+	if err := trace.WriteJsonLines(json_lines); err != nil {
+		return err
+	}
+
+	return udn_trace_html_template.Execute(writer, map[string]interface{}{"Id": trace.Id, "JsonLines": json_lines.String()})
+}
+
+// Write the trace into directory, as udn_trace_<id>.jsonl and udn_trace_<id>.html.  The ids are unique, and sort by time, so traces of concurrent requests never overwrite each other.  Returns the JSON lines path.
+func WriteUdnTrace(trace *UdnTrace, directory string) (string, error) {
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return "", err
+	}
+
+	base_path := filepath.Join(directory, "udn_trace_"+trace.Id)
+
+	for _, extension := range []string{".jsonl", ".html"} {
+		file, err := os.Create(base_path + extension)
+		if err != nil {
+			return "", err
+		}
+
+		buffered := bufio.NewWriter(file)
+		if extension == ".jsonl" {
+			err = trace.WriteJsonLines(buffered)
+		} else {
+			err = trace.WriteHtml(buffered)
+		}
+		if err == nil {
+			err = buffered.Flush()
+		}
+		file.Close()
+
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return base_path + ".jsonl", nil
+}
+
+// Remove old traces from directory: all but the newest keep traces (0 keeps all of them), and any older than max_age (0 keeps them forever).  HTML debug logs (UdnDebugWriteHtml) in directory are kept the same way, each counts as a trace.
+func PruneUdnTraces(directory string, keep int, max_age time.Duration) error {
+	return _PruneUdnTraceFiles(directory, true, keep, max_age)
+}
+
+// PruneUdnTraces, without the traces if include_traces is false, so only HTML debug logs are counted and removed
+func _PruneUdnTraceFiles(directory string, include_traces bool, keep int, max_age time.Duration) error {
+	files, err := ioutil.ReadDir(directory)
+	if err != nil {
+		return err
+	}
+
+	// Both files of a trace have the same base name, "udn_trace_" and its id
+	base_names := make([]string, 0)
+	base_times := make(map[string]time.Time)
+
+	for _, file := range files {
+		name := file.Name()
+		is_trace := include_traces && strings.HasPrefix(name, "udn_trace_")
+		is_debug_log := strings.HasPrefix(name, "udn_debug_log_") && strings.HasSuffix(name, ".html")
+		if !is_trace && !is_debug_log {
+			continue
+		}
+
+		base_name := strings.TrimSuffix(strings.TrimSuffix(name, ".jsonl"), ".html")
+		if _, ok := base_times[base_name]; !ok {
+			base_names = append(base_names, base_name)
+		}
+		if file.ModTime().After(base_times[base_name]) {
+			base_times[base_name] = file.ModTime()
+		}
+	}
+
+	// Newest first.  Trace ids sort by time, for traces written in the same instant.
+	sort.Slice(base_names, func(i, j int) bool {
+		if !base_times[base_names[i]].Equal(base_times[base_names[j]]) {
+			return base_times[base_names[i]].After(base_times[base_names[j]])
+		}
+		return base_names[i] > base_names[j]
+	})
+
+	for index, base_name := range base_names {
+		if (keep > 0 && index >= keep) || (max_age > 0 && time.Since(base_times[base_name]) > max_age) {
+			for _, extension := range []string{".jsonl", ".html"} {
+				err := os.Remove(filepath.Join(directory, base_name+extension))
+				if err != nil && !os.IsNotExist(err) {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Remove old HTML debug logs after UdnDebugWriteHtml writes one.  In LoggingConfig.TracePath they are kept like traces, anywhere else (the temp directory) only the newest udn_debug_html_keep are kept.
+func (engine *Engine) PruneDebugHtml(directory string) {
+	var err error
+
+	if log_config := engine.LogConfig; log_config != nil && log_config.TracePath != "" && filepath.Clean(log_config.TracePath) == filepath.Clean(directory) {
+		max_age, _ := time.ParseDuration(log_config.TraceMaxAge)
+		err = PruneUdnTraces(directory, log_config.TraceKeep, max_age)
+	} else {
+		err = _PruneUdnTraceFiles(directory, false, udn_debug_html_keep, 0)
+	}
+
+	if err != nil {
+		engine._Log(log_error, "Prune UDN Debug HTML: %s\n", err)
+	}
+}
+
+// Write the execution's trace into the configured LoggingConfig.TracePath, and remove old traces by its retention settings.  Returns the JSON lines path, or "" if there is no trace, or no TracePath.
+func FinishUdnTrace(udn_schema map[string]interface{}) string {
+	log_config := GetUdnEngine(udn_schema).LogConfig
+
+	trace := GetUdnTrace(udn_schema)
+	if trace == nil || log_config == nil || log_config.TracePath == "" {
+		return ""
+	}
+
+	trace_path, err := WriteUdnTrace(trace, log_config.TracePath)
+	if err != nil {
+		UdnLogLevel(udn_schema, log_error, "Write UDN Trace: %s\n", err)
+		return ""
+	}
+
+	max_age, err := time.ParseDuration(log_config.TraceMaxAge)
+	if err != nil && log_config.TraceMaxAge != "" {
+		UdnLogLevel(udn_schema, log_error, "UDN Trace: Max Age: %s\n", err)
+	}
+
+	if err := PruneUdnTraces(log_config.TracePath, log_config.TraceKeep, max_age); err != nil {
+		UdnLogLevel(udn_schema, log_error, "Prune UDN Traces: %s\n", err)
+	}
+
+	return trace_path
+}
+
+var udn_trace_html_template = template.Must(template.New("udn_trace").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>UDN Trace {{.Id}}</title>
+<style>
+	body { font-family: monospace; font-size: 13px; }
+	details { margin-left: 16px; }
+	summary { cursor: pointer; white-space: nowrap; }
+	.statement > summary { font-weight: bold; }
+	.error { color: #b00; }
+	.duration { color: #777; }
+	.field { margin-left: 32px; white-space: pre-wrap; color: #333; }
+</style>
+</head>
+<body>
+<h3>UDN Trace {{.Id}}</h3>
+<p>Open another trace: <input type="file" id="trace_file" accept=".jsonl"></p>
+<div id="trace"></div>
+<script>
+// The JSON lines of this trace
+var trace_json_lines = {{.JsonLines}};
+
+function RenderTrace(json_lines) {
+	var root = document.getElementById('trace');
+	root.textContent = '';
+
+	var elements = {0: root};
+
+	json_lines.split('\n').forEach(function (line) {
+		if (line.trim() == '') {
+			return;
+		}
+
+		var span = JSON.parse(line);
+
+		var details = document.createElement('details');
+		details.className = span.kind;
+
+		var summary = document.createElement('summary');
+		summary.textContent = span.name + (span.part_id ? ' [' + span.part_id + ']' : '') + '  ';
+
+		var duration = document.createElement('span');
+		duration.className = 'duration';
+		duration.textContent = (span.duration_ns / 1000000).toFixed(3) + ' ms';
+		summary.appendChild(duration);
+
+		if (span.error) {
+			summary.classList.add('error');
+		}
+		details.appendChild(summary);
+
+		['args', 'input', 'output', 'error'].forEach(function (field) {
+			if (span[field]) {
+				var value = document.createElement('div');
+				value.className = 'field' + (field == 'error' ? ' error' : '');
+				value.textContent = field + ': ' + span[field];
+				details.appendChild(value);
+			}
+		});
+
+		(elements[span.parent_id] || root).appendChild(details);
+		elements[span.id] = details;
+	});
+}
+
+RenderTrace(trace_json_lines);
+
+document.getElementById('trace_file').addEventListener('change', function (event) {
+	var reader = new FileReader();
+	reader.onload = function () { RenderTrace(reader.result); };
+	reader.readAsText(event.target.files[0]);
+});
+</script>
+</body>
+</html>
+`))
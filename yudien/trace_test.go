@@ -0,0 +1,157 @@
+package yudien
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/ghowland/yudien/yudiencore"
+)
+
+func TestUdnTrace(t *testing.T) {
+	udn_schema := testUdnSchema()
+	udn_data := map[string]interface{}{}
+
+	trace := StartUdnTrace(udn_schema)
+
+	ProcessUDN(nil, udn_schema, []string{"__input.[1,2].__iterate.__input.x.__end_iterate.__set.temp.done", "__get.temp.missing.__exec_command"}, udn_data)
+
+	spans := make([]string, 0)
+	for _, span := range trace.AllSpans() {
+		spans = append(spans, fmt.Sprintf("%d:%s", span.Depth, span.Name))
+	}
+
+	expected := "0:__input.[1,2].__iterate.__input.x.__end_iterate.__set.temp.done 1:__input 1:__iterate 2:__input 2:__input 1:__set 0:__get.temp.missing.__exec_command 1:__get 1:__exec_command"
+	if strings.Join(spans, " ") != expected {
+		t.Errorf("Unexpected spans:\n%s\nExpected:\n%s", strings.Join(spans, " "), expected)
+	}
+
+	iterate := trace.Spans[0].Children[1]
+	if iterate.Args == "" || !strings.HasPrefix(iterate.Input, "[1 2]") || !strings.HasPrefix(iterate.Output, "[x x]") || iterate.Error != "" {
+		t.Errorf("Unexpected __iterate span: %+v", iterate)
+	}
+
+	// The panic is an error of its span, and the statement it unwound through
+	if trace.Spans[1].Children[1].Error == "" || trace.Spans[1].Error == "" {
+		t.Errorf("Panic was not recorded in the trace: %+v  %+v", trace.Spans[1], trace.Spans[1].Children[1])
+	}
+
+	// One span per line, that the viewer can nest by parent_id
+	json_lines := &bytes.Buffer{}
+	if err := trace.WriteJsonLines(json_lines); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(json_lines.String()), "\n")
+	if len(lines) != len(spans) {
+		t.Fatalf("Expected %d JSON lines, got %d", len(spans), len(lines))
+	}
+
+	span := UdnTraceSpan{}
+	if err := json.Unmarshal([]byte(lines[2]), &span); err != nil || span.Name != "__iterate" || span.ParentId != 1 {
+		t.Errorf("Unexpected JSON line: %s  %v", lines[2], err)
+	}
+
+	// The viewer works offline
+	html := &bytes.Buffer{}
+	if err := trace.WriteHtml(html); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(html.String(), "http") || !strings.Contains(html.String(), "__iterate") {
+		t.Errorf("HTML viewer is not self-contained, or is missing the trace:\n%s", html)
+	}
+}
+
+func TestUdnTraceFiles(t *testing.T) {
+	trace_dir, err := ioutil.TempDir("", "udn_trace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(trace_dir)
+
+	// Every trace gets its own files
+	for count := 0; count < 3; count++ {
+		udn_schema := testUdnSchema()
+		StartUdnTrace(udn_schema)
+		ProcessSingleUDNTarget(nil, udn_schema, "__input.1", nil, map[string]interface{}{})
+
+		if _, err := WriteUdnTrace(GetUdnTrace(udn_schema), trace_dir); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, _ := filepath.Glob(filepath.Join(trace_dir, "udn_trace_*"))
+	if len(files) != 6 {
+		t.Fatalf("Expected 6 trace files, got: %v", files)
+	}
+
+	if err := PruneUdnTraces(trace_dir, 2, 0); err != nil {
+		t.Fatal(err)
+	}
+	if files, _ := filepath.Glob(filepath.Join(trace_dir, "udn_trace_*.jsonl")); len(files) != 2 {
+		t.Errorf("Expected 2 traces to be kept, got: %v", files)
+	}
+
+	old_time := time.Now().Add(-2 * time.Hour)
+	files, _ = filepath.Glob(filepath.Join(trace_dir, "udn_trace_*"))
+	for _, file := range files {
+		os.Chtimes(file, old_time, old_time)
+	}
+
+	if err := PruneUdnTraces(trace_dir, 0, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if files, _ := filepath.Glob(filepath.Join(trace_dir, "udn_trace_*")); len(files) != 0 {
+		t.Errorf("Expected old traces to be removed, got: %v", files)
+	}
+}
+
+func TestUdnDebugWriteHtml(t *testing.T) {
+	trace_dir, err := ioutil.TempDir("", "udn_trace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(trace_dir)
+
+	// Debug logs are written into the engine's trace directory, and kept like traces
+	engine := NewEngine()
+	engine.LogConfig = &LoggingConfig{TracePath: trace_dir, TraceKeep: 2}
+
+	ioutil.WriteFile(filepath.Join(trace_dir, "other.html"), []byte{}, 0644)
+
+	for count := 0; count < 4; count++ {
+		if output_path := UdnDebugWriteHtml(engine.NewUdnSchema()); filepath.Dir(output_path) != trace_dir {
+			t.Fatalf("Debug log was not written into the trace directory: %s", output_path)
+		}
+	}
+
+	if files, _ := filepath.Glob(filepath.Join(trace_dir, "*.html")); len(files) != 3 {
+		t.Errorf("Expected 2 debug logs and other.html to be kept, got: %v", files)
+	}
+
+	// Anywhere else, only the debug logs are pruned
+	for count := 0; count < udn_debug_html_keep+2; count++ {
+		ioutil.TempFile(trace_dir, "udn_debug_log_*.html")
+	}
+	engine.LogConfig = &LoggingConfig{}
+	engine.PruneDebugHtml(trace_dir)
+
+	if files, _ := filepath.Glob(filepath.Join(trace_dir, "udn_debug_log_*.html")); len(files) != udn_debug_html_keep {
+		t.Errorf("Expected %d debug logs to be kept, got %d", udn_debug_html_keep, len(files))
+	}
+	if _, err := os.Stat(filepath.Join(trace_dir, "other.html")); err != nil {
+		t.Errorf("Expected other.html to be kept: %v", err)
+	}
+}
+
+func TestHtmlClean(t *testing.T) {
+	if html := HtmlClean("<a & b>"); html != "&lt;a&nbsp;&amp;&nbsp;b&gt;" {
+		t.Errorf("Unexpected HtmlClean: %s", html)
+	}
+}
@@ -0,0 +1,165 @@
+package yudien
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	. "github.com/ghowland/yudien/yudiencore"
+	. "github.com/ghowland/yudien/yudienutil"
+	"strings"
+)
+
+const (
+	validation_error   = "error"
+	validation_warning = "warning"
+)
+
+// A single problem found by Validate.  Errors will fail or panic at request time, warnings will work but should be fixed.
+type UdnValidationIssue struct {
+	Severity string `json:"severity"`
+
+	// The UDN statement the issue was found in, and the function in it (if any)
+	Statement string `json:"statement"`
+	Function  string `json:"function,omitempty"`
+
+	Message string `json:"message"`
+
+	// Set when the statement could not be parsed at all
+	ParseError *ParseError `json:"parse_error,omitempty"`
+}
+
+func (issue UdnValidationIssue) String() string {
+	if issue.Function != "" {
+		return fmt.Sprintf("%s: %s: %s", issue.Severity, issue.Function, issue.Message)
+	}
+	return fmt.Sprintf("%s: %s", issue.Severity, issue.Message)
+}
+
+// Check a UDN statement against the default engine's function signatures, without executing it.  Returns all the issues found, or an empty list if the statement is OK.
+func Validate(udn_value string) []UdnValidationIssue {
+	return DefaultEngine.Validate(udn_value)
+}
+
+// Check all the statements in a UDN execution group JSON against the default engine's function signatures
+func ValidateSchemaUDNSet(udn_data_json string) []UdnValidationIssue {
+	return DefaultEngine.ValidateSchemaUDNSet(udn_data_json)
+}
+
+// Check a UDN statement against this engine's function signatures (including its function packs), without executing it
+func (engine *Engine) Validate(udn_value string) []UdnValidationIssue {
+	issues := make([]UdnValidationIssue, 0)
+
+	udn_start, err := ParseUdnString(nil, engine.NewUdnSchema(), udn_value)
+	if err != nil {
+		issue := UdnValidationIssue{Severity: validation_error, Statement: udn_value, Message: err.Error()}
+		if parse_error, ok := err.(*ParseError); ok {
+			issue.ParseError = parse_error
+		}
+		return append(issues, issue)
+	}
+
+	return engine._ValidateUdnPart(udn_value, udn_start, issues)
+}
+
+// Check all the statements in a UDN execution group JSON, as stored in udn_stored_function.udn_data_json and widget data.  A plain UDN string is validated as a single statement, like __execute does.
+func (engine *Engine) ValidateSchemaUDNSet(udn_data_json string) []UdnValidationIssue {
+	udn_execution_group := UdnExecutionGroup{}
+
+	err := json.Unmarshal([]byte(udn_data_json), &udn_execution_group.Blocks)
+	if err != nil {
+		return engine.Validate(udn_data_json)
+	}
+
+	issues := make([]UdnValidationIssue, 0)
+
+	for _, udn_group := range udn_execution_group.Blocks {
+		for _, udn_group_block := range udn_group {
+			for _, udn_value := range udn_group_block {
+				issues = append(issues, engine.Validate(udn_value)...)
+			}
+		}
+	}
+
+	return issues
+}
+
+// Walk this part, its arguments and the rest of its function chain, collecting issues
+func (engine *Engine) _ValidateUdnPart(udn_value string, udn_part *UdnPart, issues []UdnValidationIssue) []UdnValidationIssue {
+	for udn_current := udn_part; udn_current != nil; udn_current = udn_current.NextUdnPart {
+		if udn_current.PartType == part_function {
+			issues = engine._ValidateUdnFunction(udn_value, udn_current, issues)
+		}
+
+		for _, child := range udn_current.Children {
+			issues = engine._ValidateUdnPart(udn_value, child, issues)
+		}
+	}
+
+	return issues
+}
+
+func (engine *Engine) _ValidateUdnFunction(udn_value string, udn_function *UdnPart, issues []UdnValidationIssue) []UdnValidationIssue {
+	add_issue := func(severity string, format string, args ...interface{}) {
+		issues = append(issues, UdnValidationIssue{Severity: severity, Statement: udn_value, Function: udn_function.Value, Message: fmt.Sprintf(format, args...)})
+	}
+
+	signature, ok := engine.FunctionSignatures[udn_function.Value]
+	if !ok {
+		add_issue(validation_error, "unknown function")
+		return issues
+	}
+
+	if signature.DeprecatedBy != "" {
+		add_issue(validation_warning, "deprecated, use %s", signature.DeprecatedBy)
+	}
+
+	// Block pairing was done while parsing (AddFunction), so anything left without a partner is unmatched
+	if signature.BlockEnd != "" && udn_function.BlockEnd == nil {
+		add_issue(validation_error, "block is not closed with %s", signature.BlockEnd)
+	}
+	if strings.HasPrefix(udn_function.Value, "__end_") && udn_function.BlockBegin == nil {
+		add_issue(validation_error, "no matching __%s to close", strings.TrimPrefix(udn_function.Value, "__end_"))
+	}
+
+	if arg_count := signature.ArgCount; arg_count != nil {
+		count := len(udn_function.Children)
+
+		if count < arg_count.Min {
+			add_issue(validation_error, "takes at least %d arguments, found %d", arg_count.Min, count)
+		} else if arg_count.Max != -1 && count > arg_count.Max {
+			add_issue(validation_error, "takes at most %d arguments, found %d", arg_count.Max, count)
+		}
+	}
+
+	return issues
+}
+
+func UDN_Validate(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart, args []interface{}, input interface{}, udn_data map[string]interface{}) UdnResult {
+	// Validate the input, or arg_0 if we have one, like __execute
+	udn_target := GetResult(input, type_string).(string)
+	if len(args) > 0 {
+		udn_target = GetResult(args[0], type_string).(string)
+	}
+
+	UdnLogLevel(udn_schema, log_trace, "Validate: %s\n", udn_target)
+
+	// Against the functions of the engine we are executing with
+	issues := GetUdnEngine(udn_schema).ValidateSchemaUDNSet(udn_target)
+
+	// Return plain data, so it can be used with __get/__iterate like any other result
+	issue_array := make([]interface{}, 0)
+	for _, issue := range issues {
+		issue_map := map[string]interface{}{
+			"severity":  issue.Severity,
+			"statement": issue.Statement,
+			"function":  issue.Function,
+			"message":   issue.Message,
+		}
+		issue_array = append(issue_array, issue_map)
+	}
+
+	result := UdnResult{}
+	result.Result = issue_array
+
+	return result
+}
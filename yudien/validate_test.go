@@ -0,0 +1,68 @@
+package yudien
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		udn      string
+		severity string
+		function string
+	}{
+		{"__input.1.__set.temp.a", "", ""},
+		{"__if.(__compare_equal.Tom.Jerry).__input.1.__else.__input.0.__end_if", "", ""},
+		{"__input.1.__not_a_function", validation_error, "__not_a_function"},
+		{"__if.1.__input.2", validation_error, "__if"},
+		{"__input.[1,2].__iterate.__input.1", validation_error, "__iterate"},
+		{"__input.1.__end_while", validation_error, "__end_while"},
+		{"__while.(__get.temp.x).__end_while", validation_error, "__while"},
+		{"__compare_equal.1.2.3", validation_error, "__compare_equal"},
+		{"__customer_monitor_post_process_change.db.table.ts_db.conn.1.ts", validation_warning, "__customer_monitor_post_process_change"},
+		{"__input.(__get.temp", validation_error, ""},
+	}
+
+	for _, test_case := range cases {
+		issues := Validate(test_case.udn)
+
+		if test_case.severity == "" {
+			if len(issues) != 0 {
+				t.Errorf("Validate %q: expected no issues, got %v", test_case.udn, issues)
+			}
+			continue
+		}
+
+		if len(issues) != 1 || issues[0].Severity != test_case.severity || issues[0].Function != test_case.function {
+			t.Errorf("Validate %q: expected one %s for %q, got %v", test_case.udn, test_case.severity, test_case.function, issues)
+		}
+	}
+}
+
+func TestValidateSchemaUDNSet(t *testing.T) {
+	issues := ValidateSchemaUDNSet(`[[["__input.1", "__set.temp.a"], ["__get.temp.a.__bogus"]]]`)
+
+	if len(issues) != 1 || issues[0].Statement != "__get.temp.a.__bogus" {
+		t.Fatalf("Unexpected issues: %v", issues)
+	}
+}
+
+// Functions are checked against the engine's signatures, so its function packs are known
+func TestValidateEngine(t *testing.T) {
+	engine := NewEngine()
+	if err := engine.RegisterFunctionPack(&testFunctionPack{name: "ops", functions: []*UdnFunctionSignature{testPackFunction("__ops.duty_shift_summary")}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if issues := engine.Validate("__ops.duty_shift_summary.week"); len(issues) != 0 {
+		t.Errorf("Engine: Unexpected issues: %v", issues)
+	}
+	if issues := Validate("__ops.duty_shift_summary.week"); len(issues) != 1 {
+		t.Errorf("Default engine: Expected an unknown function: %v", issues)
+	}
+
+	udn_schema := engine.NewUdnSchema()
+	if result := engine.ProcessSingleUDNTarget(nil, udn_schema, "__validate.'__ops.duty_shift_summary.week.__bogus'", nil, map[string]interface{}{}); fmt.Sprint(result) != "[map[function:__bogus message:unknown function severity:error statement:__ops.duty_shift_summary.week.__bogus]]" {
+		t.Errorf("__validate: Unexpected issues: %v  Error: %v", result, GetUdnError(udn_schema))
+	}
+}
@@ -1,6 +1,7 @@
 package yudien
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -29,10 +30,10 @@ func DescribeUdnPart(part *UdnPart) string {
 		output += fmt.Sprintf("%sBlock:  Begin: %s   End: %s\n", depth_margin, part.BlockBegin.Id, part.BlockEnd.Id)
 	}
 
-	if part.Children.Len() > 0 {
-		output += fmt.Sprintf("%sArgs: %d\n", depth_margin, part.Children.Len())
-		for child := part.Children.Front(); child != nil; child = child.Next() {
-			output += DescribeUdnPart(child.Value.(*UdnPart))
+	if len(part.Children) > 0 {
+		output += fmt.Sprintf("%sArgs: %d\n", depth_margin, len(part.Children))
+		for _, child := range part.Children {
+			output += DescribeUdnPart(child)
 		}
 	}
 
@@ -44,7 +45,7 @@ func DescribeUdnPart(part *UdnPart) string {
 	return output
 }
 
-// Execution group allows for Blocks to be run concurrently.  A Group has Concurrent Blocks, which has UDN pairs of strings, so 3 levels of arrays for grouping
+// Execution group allows for Blocks to be run concurrently.  A Group has Concurrent Blocks, which has UDN pairs of strings, so 3 levels of arrays for grouping.  Groups run in order, the Blocks of a Group run concurrently (see ProcessUdnConcurrentBlocks).
 type UdnExecutionGroup struct {
 	Blocks [][][]string
 }
@@ -69,6 +70,15 @@ var UDNLogConfig *LoggingConfig
 type LoggingConfig struct {
 	OutputPath  string `json:"output_path"`
 	Level string `json:"level"`
+
+	// When OutputPath is set, logs are written there as JSON lines, and the file is rotated when it is over MaxSizeMb, keeping MaxBackups old files
+	MaxSizeMb int `json:"max_size_mb"`
+	MaxBackups int `json:"max_backups"`
+
+	// Execution traces (StartUdnTrace) are written here by FinishUdnTrace.  Only the newest TraceKeep traces are kept (0 keeps all), and none older than TraceMaxAge (a duration, ex: "72h").
+	TracePath string `json:"trace_path"`
+	TraceKeep int `json:"trace_keep"`
+	TraceMaxAge string `json:"trace_max_age"`
 }
 
 type WebsiteConfig struct {
@@ -121,268 +131,37 @@ type AuthenticationConfig struct {
 }
 
 
+// Configure the default engine
 func Configure(default_database *DatabaseConfig, databases map[string]DatabaseConfig, logging *LoggingConfig, authentication *AuthenticationConfig) {
-	UdnLogLevel(nil, log_info,"Configuring Yudien\n")
-
-	Ldap = &authentication.LdapConfig
-	DevelopmentUsers = authentication.DevelopmentUsers
-
-	UDNLogConfig = logging
-
-	Debug_Udn_Log_Level = ParseUdnLogLevel(UDNLogConfig.Level) // see yudiencore/core.go func UdnLogLevels
-
-	DefaultDatabase = default_database
-
-	UdnLogLevel(nil, log_info,"\n\nConfig: Logging: %v\n\n", logging)
-	//UdnLogLevel(nil, log_info,"\n\nConfig: Authentication: %v\n\n", authentication)
-
-	InitDataman(*DefaultDatabase, databases)
+	DefaultEngine.Configure(default_database, databases, logging, authentication)
+
+	// The package globals are copies of the default engine's settings
+	Debug_Udn_Log_Level = DefaultEngine.LogLevel
+	UdnLogger = DefaultEngine.Logger
+	UDNLogConfig = DefaultEngine.LogConfig
+	Ldap = DefaultEngine.Ldap
+	DevelopmentUsers = DefaultEngine.DevelopmentUsers
+	DefaultDatabase = DefaultEngine.DefaultDatabase
+	DefaultDatabaseTarget = DefaultEngine.Datasources.DefaultTarget
 }
 
 func InitUdn() {
 	Debug_Udn_Api = false // Legacy Logging
 	Debug_Udn = false // Legacy Logging - see yudiencore/core.go func UdnLog
 
-	UdnFunctions = map[string]UdnFunc{
-		"__comment":      UDN_Comment,
-		"__query":        UDN_QueryById,
-		"__debug_output": UDN_DebugOutput,
-		"__if":           UDN_IfCondition,
-		"__end_if":       nil,
-		"__else":         UDN_ElseCondition,
-		"__end_else":     nil,
-		"__else_if":      UDN_ElseIfCondition,
-		"__end_else_if":  nil,
-		"__not":          UDN_Not,
-		"__not_nil":      UDN_NotNil,
-		"__is_nil":       UDN_IsNil,
-		"__iterate":      UDN_Iterate,
-		"__end_iterate":  nil,
-		"__while":        UDN_While,	 // While takes a condition (arg_0) and a max (arg_1:int) number of iterations, so it cannot run forever
-		"__end_while":  nil,
-		"__nil":          UDN_Nil,		// Returns nil
-		"__get":          UDN_Get,
-		"__set":          UDN_Set,
-		"__get_index": 	  UDN_GetIndex, // Get data using input rather than args (otherwise same as __get)
-		"__set_index": 	  UDN_SetIndex, // Set data like __set but does not the result is passed to output and not stored
-		"__get_first":    UDN_GetFirst, // Takes N strings, which are dotted for udn_data accessing.  The first value that isnt nil is returned.  nil is returned if they all are
-		"__get_temp":     UDN_GetTemp,  // Function stack based temp storage
-		"__get_temp_key": UDN_GetTempKey, // Get the uuid of the current stack frame for temp variables
-		"__set_temp":     UDN_SetTemp,  // Function stack based temp storage
-		//"__temp_clear":          UDN_ClearTemp,
-		//"__watch": UDN_WatchSyncronization,
-		//"___watch_timeout": UDN_WatchTimeout,				//TODO(g): Should this just be an arg to __watch?  I think so...  Like if/else, watch can control the flow...
-		//"__end_watch": nil,
-		"__test_return":    UDN_TestReturn, // Return some data as a result
-		"__test":           UDN_Test,
-		"__test_different": UDN_TestDifferent,
-		// Migrating from old functions
-		//TODO(g): These need to be reviewed, as they are not necessarily the best way to do this, this is just the easiest/fastest way to do this
-		"__widget": UDN_Widget,
-		// New functions for rendering web pages (finally!)
-		//"__template": UDN_StringTemplate,					// Does a __get from the args...
-		"__template":       UDN_StringTemplateFromValue,      // Does a __get from the args...
-		"__template_wrap":  UDN_StringTemplateMultiWrap,      // Takes N-2 tuple args, after 0th arg, which is the wrap_key, (also supports a single arg templating, like __template, but not the main purpose).  For each N-Tuple, the new map data gets "value" set by the previous output of the last template, creating a rolling "wrap" function.
-		"__template_map":   UDN_MapTemplate,                  //TODO(g): Like format, for templating.  Takes 3*N args: (key,text,map), any number of times.  Performs template and assigns key into the input map
-		"__format":         UDN_MapStringFormat,              //TODO(g): Updates a map with keys and string formats.  Uses the map to format the strings.  Takes N args, doing each arg in sequence, for order control
-		"__template_short": UDN_StringTemplateFromValueShort, // Like __template, but uses {{{fieldname}}} instead of {{index .Max "fieldname"}}, using strings.Replace instead of text/template
-
-		"__true":          UDN_True,
-		"__false":          UDN_False,
-
-		"__length":          UDN_Length,
-
-		//TODO(g): DEPRICATE.  Longer name, same function.
-		"__template_string": UDN_StringTemplateFromValue, // Templates the string passed in as arg_0
-
-		"__string_append": UDN_StringAppend,
-		"__string_clear":  UDN_StringClear, // Initialize a string to empty string, so we can append to it again
-		"__string_replace":  UDN_StringReplace, // Initialize a string to empty string, so we can append to it again
-		"__concat":        UDN_StringConcat,
-		"__markdown_format": UDN_StringMarkdownFormat, // Format a string as HTML from markdown
-
-		"__string_ends_with": UDN_StringEndsWith, // Returns boolean, if matches end of string
-		"__string_begins_with": UDN_StringEndsWith, // Returns boolean, if matches beginning of string
-
-		"__input":         UDN_Input,          //TODO(g): This takes any input as the first arg, and then passes it along, so we can type in new input to go down the pipeline...
-		"__input_get":     UDN_InputGet,       // Gets information from the input, accessing it like __get
-		"__function":      UDN_StoredFunction, //TODO(g): This uses the udn_stored_function.name as the first argument, and then uses the current input to pass to the function, returning the final result of the function.		Uses the web_site.udn_stored_function_domain_id to determine the stored function
-		"__execute":       UDN_Execute,        // Can take single string or the tripple array of UDN statements
-
-		"__html_encode":     UDN_HtmlEncode, // Encode HTML symbols so they are not taken as literal HTML
-
-		"__array_append":    UDN_ArrayAppend, // Appends the input into the specified target location (args)
-		"__array_append_array":    UDN_ArrayAppendArray, // Appends an array (input) into the specified location, like __array_append
-		"__array_slice": 	 UDN_ArraySlice, // Slices an input array based on the start and end index
-		"__array_divide":    UDN_ArrayDivide,   // Breaks an array up into a set of arrays, based on a divisor.  Ex: divide=4, a 14 item array will be 4 arrays, of 4/4/4/2 items each.
-		"__array_remove":    UDN_ArrayRemove, // Removes the first instance of an element in an array.  Recquires exact match
-		"__array_index":     UDN_ArrayIndex, // Gets the index of the first instance of an element in an array.  Requires exact match
-		"__array_contains":  UDN_ArrayContains, // Returns boolean, if the specific array contains all the of input.  Input can be individual elemnent or an arry (converts to an array).
-		"__array_contains_any":  UDN_ArrayContainsAny, // Returns boolean, if the specific array contains all the of input.  Input can be individual elemnent or an arry (converts to an array).
-		"__array_map_update": UDN_ArrayMapUpdate, // Takes an array of maps, and overwrites all keys with the specified map
-		"__array_map_remap": UDN_ArrayMapRemap, // Takes an array of maps, and makes a new array of maps, based on the arg[0] (map) mapping (key_new=key_old)
-		"__array_map_to_map":  UDN_ArrayMapToMap, // Map an array of maps into a single map, using one of the keys
-		"__array_map_to_series":  UDN_ArrayMapToSeries, // Map an array of maps into a single array, from one of the key.  Like a time series or other list of values.
-		"__array_map_template":  UDN_ArrayMapTemplate, // Update all map's key's values with a template statement from each map's key/values
-		"__array_map_key_set":  UDN_ArrayMapKeySet, // Update all map's with specified keys/values
-		"__array_map_find":  UDN_ArrayMapFind, // Finds a single map element, and returns the result in a map of {key=key,value=value}
-		"__array_map_find_update":  UDN_ArrayMapFindUpdate, //TODO(g): Remove once we transition everything to FilterUpdate, was incorrectly named as the first of it's kind. -- --  Finds all map elements that match the find map, and updates them with a passed in map, so we can find the map in-place of matching records.  Returns the array of maps
-		"__array_map_filter_update":  UDN_ArrayMapFilterUpdate, //TODO(g): Update to FilterUpdate and replace code that uses it.  Unless worth having 2 functions.  Want to keep the name spaces and argument order/types similar accross all of them to avoid the PHP curse.  -- -- Finds all map elements that match the find map, and updates them with a passed in map, so we can find the map in-place of matching records.  Returns the array of maps
-		"__array_map_filter_in":  UDN_ArrayMapFilterIn, // Returns all maps in an array, which keys were in the set of the array elements
-		"__array_map_filter_contains":  UDN_ArrayMapFilterContains, // Returns all maps in an array, which keys values contains any of the possible array elements.  This works on arrays (, maps (match key/value), or single items (match)    //TODO(g): Add the non-array checks, only implemented array so far.
-		"__array_map_filter_array_contains":  UDN_ArrayMapFilterArrayContains, // Returns all maps in an array, compares two arrays, and returns an array of all the matches between the 2 list.  Default is "any".  Options will be expanded to get more functionality when needed (need use case).
-
-		"__array_string_join":  UDN_ArrayStringJoin, // Join an array of strings into a string (with separator)
-
-		"__map_key_delete": UDN_MapKeyDelete, // Each argument is a key to remove
-		"__map_key_set":    UDN_MapKeySet,    // Sets N keys, like __format, but with no formatting
-		"__map_copy":       UDN_MapCopy,      // Make a copy of the current map, in a new map
-		"__map_update":     UDN_MapUpdate,    // Input map has fields updated with arg0 map
-		"__map_template_key":     UDN_MapTemplateKey,    // When we want to re-key a map, such as prefixing a UUID in front of the keys for replacement in an HTML document
-		"__map_filter_array_contains":     UDN_MapFilterArrayContains,    // Filters elements in a map, if one of their keys contains at array we are comparing for containing values of another array
-		"__map_filter_key":     UDN_MapFilterKey,    // Filters elements in a map based on their keys.  If their keys appear in a list, they are in the resulting map.  Otherwise they are filtered out.
-
-		"__render_data": UDN_RenderDataWidgetInstance, // Renders a Data Widget Instance:  arg0 = web_data_widget_instance.id, arg1 = widget_instance map update
-
-		"__json_decode": UDN_JsonDecode, // Decode JSON
-		"__json_encode": UDN_JsonEncode, // Encode JSON
-		"__json_encode_data": UDN_JsonEncodeData, // Encode JSON - Format as data.  No indenting, etc.
-
-		"__base64_decode": UDN_Base64Decode, // Decode base64
-		"__base64_encode": UDN_Base64Encode, // Encode base64
-
-
-		//TODO(g): Make these the new defaults, which use CM
-		"__change_get":    UDN_DataGet,    // Dataman Get
-		"__change_set":    UDN_DataSet,    // Dataman Set
-		"__change_submit":    UDN_ChangeDataSubmit,    // This accepts dotted notation and figures out what records/fields are being effected.  Example:  {"opsdb.schema_table_field.1050.name":"_id"}
-		"__change_filter": UDN_DataFilter, // Dataman Filter
-		"__change_filter_full": UDN_DataFilterFull, // Updated version of DatamanFilter that takes in JSON and allows multi-constraints
-		//"__change_delete":    UDN_DataDelete,    // Dataman Delete
-		//"__change_delete_filter":    UDN_DataDeleteFilter,    // Dataman Delete Filter
-		//"__change_ensure_exists":    UDN_ChangeEnsureExists,    // Ensure that the specified data exists in the database.  Does not have Dataman equivalent functions, wrapper.
-		//"__change_ensure_not_exists":    UDN_ChangeEnsureNotExists,    // Ensure that the specified data DOES NOT exist in the database.  Does not have Dataman equivalent functions, wrapper.
-
-		"__safe_data_get":    UDN_SafeDataGet,    // Safe Dataman Get - Always connects to the correct database, and checks to ensure that
-		"__safe_data_filter": UDN_SafeDataFilter, // Safe Dataman Filter - Correct DB and added filter args guarantee restricted access
-		"__safe_data_filter_full": UDN_SafeDataFilterFull, // Safe Updated version of DatamanFilter that takes in JSON and allows multi-constraints
-		//"__safe_data_set":    UDN_SafeDataSet,    // Safe Dataman Set - Ensures this is the correct business before allowing the set
-		//"__safe_data_delete":    UDN_SafeDataDelete,    // Safe Dataman Delete
-		//"__safe_data_delete_filter":    UDN_SafeDataDeleteFilter,    // Safe Dataman Delete Filter
-
-
-		"__data_get":    UDN_DataGet,    // Dataman Get
-		"__data_set":    UDN_DataSet,    // Dataman Set
-		"__data_filter": UDN_DataFilter, // Dataman Filter
-		"__data_filter_full": UDN_DataFilterFull, // Updated version of DatamanFilter that takes in JSON and allows multi-constraints
-		"__data_delete":    UDN_DataDelete,    // Dataman Get
-		"__data_delete_filter":    UDN_DataDeleteFilter,    // Dataman Set
-		"__data_tombstone": UDN_DataTombstone, // Dataman "Delete" with a Tombstone marker: _is_deleted=true
-		"__data_field_map_delete": UDN_DataFieldMapDelete, // Data field map delete - Go into JSON data and delete things
-
-
-		"__time":          UDN_Time, // Return current time.Time object, and run AddDate if any args are passed in
-		"__time_string":          UDN_TimeString, // Return string of the time
-		"__time_string_date":          UDN_TimeStringDate, // Return string of the date
-
-		"__time_series_get":    UDN_TimeSeriesGet,    // Time Series: Get
-		"__time_series_filter":    UDN_TimeSeriesFilter,    // Time Series: Filter
-
-		"__compare_equal":     UDN_CompareEqual,    // Compare equality, takes 2 args and compares them.  Returns 1 if true, 0 if false.  For now, avoiding boolean types...
-		"__compare_not_equal": UDN_CompareNotEqual, // Compare equality, takes 2 args and compares them.  Returns 1 if true, 0 if false.  For now, avoiding boolean types...
-
-		"__ddd_render": UDN_DddRender, // DDD Render.current: the JSON Dialog Form data for this DDD position.  Uses __ddd_get to get the data, and ___ddd_move to change position.
-
-		"__uuid": UDN_Uuid, // Returns a UUID string
-
-		"__login": UDN_Login, // Login through LDAP
-
-		"__split":     UDN_StringSplit, // Split a string
-		"__lower":     UDN_StringLower, // Lower case a string
-		"__upper":     UDN_StringUpper, // Upper case a string
-		"__join" :     UDN_StringJoin,  // Join an array into a string on a separator string
-
-		"__debug_get_all_data": UDN_DebugGetAllUdnData, // Templates the string passed in as arg_0
-
-		"__string_to_time": UDN_StringToTime, // Converts a string to Time.time object if possible (format is "2006-01-02T15:04:05")
-		"__time_to_epoch": UDN_TimeToEpoch, // Converts a Time.time object to unix time in seconds
-		"__time_to_epoch_ms": UDN_TimeToEpochMs, // Converts a Time.time object to unix time in milliseconds
-		"__group_by": UDN_GroupBy, // Given a list of maps, group by some value based on that grouping
-		"__math": UDN_Math,
-
-		"__set_http_response": UDN_SetHttpResponseCode,
-		"__num_to_string": UDN_NumberToString, // Given input number (int/int64/float64) and optional precision (int), outputs string (with specified precision/ original number)
-		"__get_current_time": UDN_GetCurrentTime, // Given arg[0] string in the format 'YYYY-DD-MM hh:mm:ss'. If specific number given for YYYY, DD, MM, hh, mm, ss, use that number instead. Outputs go time.Time object of current time (UTC)
-		"__get_local_time": UDN_GetLocalTime, // Given arg[0] string a valid time zone. Outputs go time.Time object of specified time zone, otherwise time.Time object of local time
-
-		"__exec_command": UDN_ExecCommand,//UDN_ExecCommand, // Execute command line command. arg0 appname, arg1-n space delimited are args. 
-		"__http_request": UDN_HttpRequest, //Sends http requests to the given url endpoint, returns the decoded response if have any.
-
-		//TODO(g): I think I dont need this, as I can pass it to __ddd_render directly
-		//"__ddd_move": UDN_DddMove,				// DDD Move position.current.x.y:  Takes X/Y args, attempted to move:  0.1.1 ^ 0.1.0 < 0.1 > 0.1.0 V 0.1.1
-		//"__ddd_get": UDN_DddGet,					// DDD Get.current.{}
-		//"__ddd_set": UDN_DddSet,					// DDD Set.current.{}
-		//"__ddd_delete": UDN_DddDelete,			// DDD Delete.current: Delete the current item (and all it's sub-items).  Append will be used with __ddd_set/move
-
-		"__increment": UDN_Increment,				// Given arg[0] int, int32, int64, float32, float64. Increment value by 1
-		"__decrement": UDN_Decrement,   			// Given arg[0] int, int32, int64, float32, float64. Decrement value by 1
-		//"__render_page": UDN_RenderPage,			// Render a page, and return it's widgets so they can be dynamically updated
-
-		// New
-
-		//"__map_update_prefix": UDN_MapUpdatePrefix,			//TODO(g): Merge a the specified map into the input map, with a prefix, so we can do things like push the schema into the row map, giving us access to the field names and such
-		//"__map_clear": UDN_MapClear,			//TODO(g): Clears everything in a map "bucket", like: __map_clear.'temp'
-
-		//"__function_domain": UDN_StoredFunctionDomain,			//TODO(g): Just like function, but allows specifying the udn_stored_function_domain.id as well, so we can use different namespaces.
-		//"__capitalize": UDN_StringCapitalize,			//TODO(g): This capitalizes words, title-style
-		//"__pluralize": UDN_StringPluralize,			//TODO(g): This pluralizes words, or tries to at least
-		//"__starts_with": UDN_StringStartsWith,			//TODO(g): Returns bool if a string starts with the specified arg[0] string
-		//"__ends_with": UDN_StringEndsWith,			//TODO(g): Returns bool if a string starts with the specified arg[0] string
-		//"__get_session_data": UDN_SessionDataGet,			//TODO(g): Get something from a safe space in session data (cannot conflict with internal data)
-		//"__set_session_data": UDN_SessionDataGet,			//TODO(g): Set something from a safe space in session data (cannot conflict with internal data)
-		//"__continue": UDN_IterateContinue,		// Skip to next iteration
-		// -- Dont think I need this -- //"__break": UDN_IterateBreak,				//TODO(g): Break this iteration, we are done.  Is this needed?  Im not sure its needed, and it might suck
-
-		"__log_level": UDN_SetLogLevel,   	// Set the log level
-
-		"__custom_populate_schedule_duty_responsibility": UDN_Custom_PopulateScheduleDutyResponsibility,   			// CUSTOM: Populate Schedule for Duty Responsibilities
-
-		"__code": UDN_Custom_Code,   			// Code Execution from data.  First argument is DB to use, second is code_id, third is input_data override. //NOTE(g): This requires database tables to exist in the DB that match the functions requirements, which most UDN functions dont require.  Putting it in Custom code for now, but it will eventually be integrated into normal UDN once I have the schema population routines implemented.
-
-		"__custom_health_check_promql": UDN_Custom_Health_Check_PromQL,	// CUSTOM: ...
-		//"__custom_metric_filter": UDN_Custom_Metric_Filter,   			// CUSTOM: Fetch Metrics by name/labelset
-		//"__custom_metric_get_values": UDN_Custom_Metric_Get_Values,   			// CUSTOM: Get TS values for list of metrics
-		//"__custom_metric_rule_match_percent": UDN_Custom_Metric_Rule_Match_Percent,   	// CUSTOM: Returns a scalar, % of matches in the rules
-		//"__custom_metric_handle_outage": UDN_Custom_Metric_Handle_Outage,   	// CUSTOM: Handles an any outages from health check failures on metrics
-		//"__custom_metric_process_open_outages": UDN_Custom_Metric_Process_Open_Outages,   	// CUSTOM: Handles an any outages from health check failures on metrics
-		"__custom_metric_process_alert_notifications": UDN_Custom_Metric_Process_Alert_Notifications,   	// CUSTOM: Processes any open Alert Notifications
-		"__custom_metric_escalation_policy_oncall": UDN_Custom_Metric_Escalation_Policy_Oncall,   	// CUSTOM: Get the team/oncall members of the Escalation Policy
-
-		"__custom_duty_shift_summary": UDN_Custom_Duty_Shift_Summary,	// CUSTOM: Get the Duty shift summary over a time range
-		"__current_duty_responsibility_current_user": UDN_Custom_Duty_Responsibility_Current_User, // CUSTOM: ...
-
-		"__customer_duty_roster_user_shift_info": UDN_Custom_Duty_Roster_User_Shift_Info,   	//CUSTOM: ....
-
-		"__custom_weekly_activity": UDN_Custom_Activity_Daily, //CUSTOM: Weekly activity on a database/table/field
-
-		"__custom_date_range_parse": UDN_Custom_Date_Range_Parse, //CUSTOM: Weekly activity on a database/table/field
-
-		//"__customer_duty_responsibility_user_shift_next": UDN_Custom_Duty_Responsibility_User_Shift_Next,   	//CUSTOM: ....
-		//"__customer_duty_responsibility_user_shift_previous": UDN_Custom_Duty_Responsibility_User_Shift_Previous,   	//CUSTOM: ....
-
-		"__custom_monitor_post_process_change": UDN_Custom_Monitor_Post_Process_Change,   	// CUSTOM: Post change submit, process the data
-		"__customer_monitor_post_process_change": UDN_Custom_Monitor_Post_Process_Change,   	//TODO(g):REMOVE: This one is a typo.  Switch to the above __custom_*// CUSTOM: Post change submit, process the data
-
-		"__custom_dashboard_item_edit": UDN_Custom_Dashboard_Item_Edit,   	// CUSTOM:...
-
-		"__custom_dataman_create_filter_html": UDN_Custom_Dataman_Create_Filter_Html,   	// CUSTOM:...
-
-		"__custom_dataman_add_rule": UDN_Custom_Dataman_Add_Rule, // Add a rule for Dataman filter
-
-		"__custom_login": UDN_Custom_Login,		// CUSTOM:...
-		"__custom_auth": UDN_Custom_Auth,		// CUSTOM:...
+	// The default engine's datasources are the yudiendata globals, and its parse cache is UdnParsedCache.  Calling InitUdn again keeps the default engine, so its Configure settings and function packs arent lost.
+	if DefaultEngine == nil {
+		DefaultEngine = NewEngine()
+		DefaultEngine.Datasources = DefaultDatasources
+		DefaultEngine.ParseCache = UdnParsedCache
+		DefaultEngine.LogLevel = Debug_Udn_Log_Level
+		DefaultEngine.Logger = UdnLogger
 	}
 
+	UdnFunctions = DefaultEngine.Functions
+	UdnFunctionSignatures = DefaultEngine.FunctionSignatures
+	UdnFunctionNames = DefaultEngine.FunctionNames
+
 	PartTypeName = map[int]string{
 		int(part_unknown):  "Unknown",
 		int(part_function): "Function",
@@ -396,8 +175,10 @@ func InitUdn() {
 }
 
 func init() {
-	fmt.Printf("Initializing Yudien\n")
 	InitUdn()
+
+	// Logged, not printed, so it isnt in the output of tools like udn-run
+	DefaultEngine._Log(log_info, "Initializing Yudien\n")
 }
 
 func Lock(lock string) {
@@ -414,12 +195,36 @@ func Unlock(lock string) {
 	// Release a lock.  Should we ensure we still had it?  Can do if we gave it our request UUID
 }
 
+// Engine.ProcessSchemaUDNSetContext, with the engine of udn_schema
+func ProcessSchemaUDNSetContext(ctx context.Context, db *sql.DB, udn_schema map[string]interface{}, udn_data_json string, udn_data map[string]interface{}) interface{} {
+	return GetUdnEngine(udn_schema).ProcessSchemaUDNSetContext(ctx, db, udn_schema, udn_data_json, udn_data)
+}
+
+// ProcessSchemaUDNSet, cancelled with ctx (ex: the client went away).  The execution has the engine's Limits.
+func (engine *Engine) ProcessSchemaUDNSetContext(ctx context.Context, db *sql.DB, udn_schema map[string]interface{}, udn_data_json string, udn_data map[string]interface{}) interface{} {
+	engine._Attach(udn_schema)
+	_StartUdnExecutionContext(ctx, udn_schema)
+	return engine.ProcessSchemaUDNSet(db, udn_schema, udn_data_json, udn_data)
+}
+
+// Engine.ProcessSchemaUDNSet, with the engine of udn_schema (DefaultEngine, if it wasnt made by an engine)
 func ProcessSchemaUDNSet(db *sql.DB, udn_schema map[string]interface{}, udn_data_json string, udn_data map[string]interface{}) interface{} {
+	return GetUdnEngine(udn_schema).ProcessSchemaUDNSet(db, udn_schema, udn_data_json, udn_data)
+}
+
+func (engine *Engine) ProcessSchemaUDNSet(db *sql.DB, udn_schema map[string]interface{}, udn_data_json string, udn_data map[string]interface{}) interface{} {
+	engine._Attach(udn_schema)
+
 	UdnLogLevel(udn_schema, log_debug,"ProcessSchemaUDNSet: JSON:\n%s\n\n", udn_data_json)
 
+	defer _EndUdnExecution(_BeginUdnExecution(udn_schema))
+
 	var result interface{}
 
 	if udn_data_json != "" {
+		// Only the top level execution starts with an error, left over from a previous uncaught error.  Nested executions never start while an error is pending.
+		ClearUdnError(udn_schema)
+
 		// Extract the JSON into a list of list of lists (2), which gives our execution blocks, and UDN pairs (Source/Target)
 		udn_execution_group := UdnExecutionGroup{}
 
@@ -429,33 +234,34 @@ func ProcessSchemaUDNSet(db *sql.DB, udn_schema map[string]interface{}, udn_data
 			log.Panic(err)
 		}
 
-		// Ensure there is a Function Stack
-		if udn_data["__function_stack"] == nil {
-			udn_data["__function_stack"] = make([]map[string]interface{}, 0)
-		}
+		// New stack frame, so __get_temp/__set_temp are local to this execution, and our caller's loops cant be __break'ed from here
+		PushUdnFunctionStack(udn_data)
+		caller_loop_depth := _SwapUdnLoopDepth(udn_schema, 0)
 
-		// Add the new stack to the stack
-		new_function_stack := make(map[string]interface{})
-		new_function_stack["uuid"] = ksuid.New().String()
-		udn_data["__function_stack"] = append(udn_data["__function_stack"].([]map[string]interface{}), new_function_stack)
+		// Log lines are tagged with the request they are for: the id of the outermost function stack frame, which everything a request executes is inside
+		caller_request_id := udn_schema["request_id"]
+		udn_schema["request_id"] = udn_data["__function_stack"].([]map[string]interface{})[0]["uuid"]
 
 		//fmt.Printf("UDN Execution Group: %v\n\n", udn_execution_group)
 
-		// Process all the UDN Execution blocks
-		//TODO(g): Add in concurrency, right now it does it all sequentially
+		// Process all the UDN Execution groups in order.  The blocks in each group are run concurrently, and finish before the next group starts.
 		for _, udn_group := range udn_execution_group.Blocks {
-			for _, udn_group_block := range udn_group {
-				result = ProcessUDN(db, udn_schema, udn_group_block, udn_data)
+			result = ProcessUdnConcurrentBlocks(db, udn_schema, udn_group, udn_data)
+
+			// An uncaught error stops the rest of the groups too, it will keep unwinding to our caller
+			if UdnErrorPending(udn_schema) {
+				break
 			}
 		}
 
-		// Remove the udn_data["__temp_UUID"] data, so it doesn't just pollute the udn_data space
-		if udn_data["__temp"] != nil {
-			delete(udn_data["__temp"].(map[string]interface{}), new_function_stack["uuid"].(string))
-		}
+		_SwapUdnLoopDepth(udn_schema, caller_loop_depth)
+		PopUdnFunctionStack(udn_data)
 
-		// Remove the latest function stack, that we just put on
-		udn_data["__function_stack"] = udn_data["__function_stack"].([]map[string]interface{})[0:len(udn_data["__function_stack"].([]map[string]interface{}))-1]
+		if caller_request_id == nil {
+			delete(udn_schema, "request_id")
+		} else {
+			udn_schema["request_id"] = caller_request_id
+		}
 
 	} else {
 		UdnLogLevel(udn_schema, log_info,"UDN Execution Group: None\n\n")
@@ -464,6 +270,34 @@ func ProcessSchemaUDNSet(db *sql.DB, udn_schema map[string]interface{}, udn_data
 	return result
 }
 
+// Add a new frame to udn_data["__function_stack"], which gives the executed UDN its own temp storage (__get_temp/__set_temp).  Returns the new frame.
+func PushUdnFunctionStack(udn_data map[string]interface{}) map[string]interface{} {
+	// Ensure there is a Function Stack
+	if udn_data["__function_stack"] == nil {
+		udn_data["__function_stack"] = make([]map[string]interface{}, 0)
+	}
+
+	// Add the new stack to the stack
+	new_function_stack := make(map[string]interface{})
+	new_function_stack["uuid"] = ksuid.New().String()
+	udn_data["__function_stack"] = append(udn_data["__function_stack"].([]map[string]interface{}), new_function_stack)
+
+	return new_function_stack
+}
+
+// Remove the latest frame from udn_data["__function_stack"], and its temp storage
+func PopUdnFunctionStack(udn_data map[string]interface{}) {
+	function_stack := udn_data["__function_stack"].([]map[string]interface{})
+
+	// Remove the udn_data["__temp_UUID"] data, so it doesn't just pollute the udn_data space
+	if udn_data["__temp"] != nil {
+		delete(udn_data["__temp"].(map[string]interface{}), function_stack[len(function_stack)-1]["uuid"].(string))
+	}
+
+	// Remove the latest function stack, that we just put on
+	udn_data["__function_stack"] = function_stack[0 : len(function_stack)-1]
+}
+
 // Prepare UDN processing from schema specification -- Returns all the data structures we need to parse UDN properly
 func PrepareSchemaUDN(db *sql.DB) map[string]interface{} {
 	// Config
@@ -540,7 +374,7 @@ func PrepareSchemaUDN(db *sql.DB) map[string]interface{} {
 	//fmt.Printf("udn_group_map: %v\n", udn_group_map)
 
 	// Pack a result map for return
-	result_map := make(map[string]interface{})
+	result_map := NewUdnSchema()
 
 	result_map["function_map"] = udn_function_map
 	result_map["function_id_alias_map"] = udn_function_id_alias_map
@@ -549,30 +383,64 @@ func PrepareSchemaUDN(db *sql.DB) map[string]interface{} {
 	result_map["config_map"] = udn_config_map
 	result_map["stored_function"] = udn_stored_function
 
-	// By default, do not debug this request
-	result_map["udn_debug"] = false
+	UdnLogLevel(nil, log_debug, "=-=-=-=-= UDN Schema Created =-=-=-=-=\n")
 
-	// By default, logging is turned on
-	result_map["allow_logging"] = true
+	return result_map
+}
 
-	// Debug information, for rendering the debug output
-	UdnDebugReset(result_map)
+// Returns a udn_schema with no database configuration (functions, groups, stored functions), for executing UDN without a database, like tools and tests do.  PrepareSchemaUDN adds the database configuration to this.
+func NewUdnSchema() map[string]interface{} {
+	return DefaultEngine.NewUdnSchema()
+}
 
-	UdnLogLevel(nil, log_debug, "=-=-=-=-= UDN Schema Created =-=-=-=-=\n")
+// Engine.ProcessUDNContext, with the engine of udn_schema
+func ProcessUDNContext(ctx context.Context, db *sql.DB, udn_schema map[string]interface{}, udn_value_list []string, udn_data map[string]interface{}) interface{} {
+	return GetUdnEngine(udn_schema).ProcessUDNContext(ctx, db, udn_schema, udn_value_list, udn_data)
+}
 
-	return result_map
+// ProcessUDN, cancelled with ctx.  The execution has the engine's Limits.
+func (engine *Engine) ProcessUDNContext(ctx context.Context, db *sql.DB, udn_schema map[string]interface{}, udn_value_list []string, udn_data map[string]interface{}) interface{} {
+	engine._Attach(udn_schema)
+	_StartUdnExecutionContext(ctx, udn_schema)
+	return engine.ProcessUDN(db, udn_schema, udn_value_list, udn_data)
 }
 
-// Pass in a UDN string to be processed - Takes function map, and UDN schema data and other things as input, as it works stand-alone from the application it supports
+// Engine.ProcessUDN, with the engine of udn_schema (DefaultEngine, if it wasnt made by an engine)
 func ProcessUDN(db *sql.DB, udn_schema map[string]interface{}, udn_value_list []string, udn_data map[string]interface{}) interface{} {
+	return GetUdnEngine(udn_schema).ProcessUDN(db, udn_schema, udn_value_list, udn_data)
+}
+
+// Pass in a UDN string to be processed - Takes function map, and UDN schema data and other things as input, as it works stand-alone from the application it supports
+func (engine *Engine) ProcessUDN(db *sql.DB, udn_schema map[string]interface{}, udn_value_list []string, udn_data map[string]interface{}) interface{} {
+	engine._Attach(udn_schema)
+
 	UdnLogLevel(udn_schema, log_debug, "\n\nProcess UDN: \n\n")
 
 	var udn_command_value interface{} // used to track the piped input/output of UDN commands
 
+	defer _EndUdnExecution(_BeginUdnExecution(udn_schema))
+
+	ClearUdnError(udn_schema)
+
+	// Errors from panics say which statement they were in.  We may be executed from inside another statement (ex: __execute), so put theirs back when we are done.
+	defer _SwapUdnSource(udn_schema, _SwapUdnSource(udn_schema, ""))
+
+	// Temp data and variables need a function stack frame, which only lasts for these statements if our caller didnt give us one
+	if _PushUdnFunctionStackIfNone(udn_data) {
+		defer _PopUdnFunctionStackAdded(udn_data)
+	}
+
 	// Walk through each UDN string in the list - the output of one UDN string is piped onto the input of the next
 	for i := 0; i < len(udn_value_list); i++ {
 		UdnLogLevel(udn_schema, log_trace, "\n\nProcess UDN statement:  %s   \n\n", udn_value_list[i])
-		udn_command := ParseUdnString(db, udn_schema, udn_value_list[i])
+		_SwapUdnSource(udn_schema, udn_value_list[i])
+
+		udn_command, err := ParseUdnStringCached(db, udn_schema, udn_value_list[i])
+		if err != nil {
+			// Broken UDN is rejected, we dont execute any of the statements after it either, as they depend on its output
+			UdnLogLevel(udn_schema, log_error, "Process UDN: %s\n", err)
+			return nil
+		}
 
 		 //UdnLogLevel(udn_schema, log_trace, "\n-------DESCRIPTION: -------\n\n%s", DescribeUdnPart(udn_command))
 
@@ -581,31 +449,74 @@ func ProcessUDN(db *sql.DB, udn_schema map[string]interface{}, udn_value_list []
 		UdnLogLevel(udn_schema, log_debug, "------- BEGIN EXECUTION: -------\n\n")
 
 		// Execute the UDN Command
-		udn_command_value = ExecuteUdn(db, udn_schema, udn_command, udn_command_value, udn_data)
+		udn_command_value = _ExecuteUdnStatement(db, udn_schema, udn_value_list[i], udn_command, udn_command_value, udn_data)
 
 		UdnLogLevel(udn_schema, log_debug, "\n------- END EXECUTION: -------\n\n")
 
 		UdnLogLevel(udn_schema, log_debug, "------- RESULT: %v\n\n", SnippetData(udn_command_value, 1600))
 		//fmt.Printf("------- RESULT: %v\n\n", JsonDump(udn_command_value))
+
+		// The next statement takes this ones output, which we dont have, so we stop here and leave the error pending for our caller
+		if UdnErrorPending(udn_schema) {
+			return nil
+		}
 	}
 
 	return udn_command_value
 }
 
+// Engine.ProcessSingleUDNTarget, with the engine of udn_schema (DefaultEngine, if it wasnt made by an engine)
 func ProcessSingleUDNTarget(db *sql.DB, udn_schema map[string]interface{}, udn_value_target string, input interface{}, udn_data map[string]interface{}) interface{} {
+	return GetUdnEngine(udn_schema).ProcessSingleUDNTarget(db, udn_schema, udn_value_target, input, udn_data)
+}
+
+func (engine *Engine) ProcessSingleUDNTarget(db *sql.DB, udn_schema map[string]interface{}, udn_value_target string, input interface{}, udn_data map[string]interface{}) interface{} {
+	engine._Attach(udn_schema)
+
 	UdnLogLevel(udn_schema, log_debug, "\n\nProcess Single UDN: Target:  %s  Input: %s\n\n", udn_value_target, SnippetData(input, 80))
 
-	udn_target := ParseUdnString(db, udn_schema, udn_value_target)
+	udn_target, err := ParseUdnStringCached(db, udn_schema, udn_value_target)
+	if err != nil {
+		UdnLogLevel(udn_schema, log_error, "Process Single UDN: Target: %s\n", err)
+		return nil
+	}
+
+	defer _EndUdnExecution(_BeginUdnExecution(udn_schema))
 
-	target_result := ExecuteUdn(db, udn_schema, udn_target, input, udn_data)
+	ClearUdnError(udn_schema)
+
+	defer _SwapUdnSource(udn_schema, _SwapUdnSource(udn_schema, udn_value_target))
+
+	if _PushUdnFunctionStackIfNone(udn_data) {
+		defer _PopUdnFunctionStackAdded(udn_data)
+	}
+
+	target_result := _ExecuteUdnStatement(db, udn_schema, udn_value_target, udn_target, input, udn_data)
+
+	// Partial results arent returned, the caller can get the error with GetUdnError
+	if UdnErrorPending(udn_schema) {
+		UdnLogLevel(udn_schema, log_debug, "-------RETURNING: TARGET: Error: %v\n\n", GetUdnError(udn_schema)["message"])
+		return nil
+	}
 
 	UdnLogLevel(udn_schema, log_debug, "-------RETURNING: TARGET: %v\n\n", SnippetData(target_result, 300))
 	return target_result
 }
 
+// The value of an item or string argument of a function.  Literals are typed, except in the args of functions that take keys (UdnFunctionSignature.KeyArgs), where they are the key with their text.
+func _UdnArgumentValue(udn_schema map[string]interface{}, udn_start *UdnPart, arg_udn_start *UdnPart) interface{} {
+	if arg_udn_start.ValueFinalType != literal_none {
+		if signature := GetUdnEngine(udn_schema).FunctionSignatures[udn_start.Value]; signature != nil && signature.KeyArgs {
+			return arg_udn_start.Value
+		}
+	}
+
+	return UdnPartValue(arg_udn_start)
+}
+
 func ProcessUdnArguments(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart, input interface{}, udn_data map[string]interface{}) []interface{} {
-	if udn_start.Children.Len() > 0 {
-		UdnLogLevel(udn_schema, log_trace, "Processing UDN Arguments: %s [%s]  Starting: Arg Count: %d \n", udn_start.Value, udn_start.Id, udn_start.Children.Len())
+	if len(udn_start.Children) > 0 {
+		UdnLogLevel(udn_schema, log_trace, "Processing UDN Arguments: %s [%s]  Starting: Arg Count: %d \n", udn_start.Value, udn_start.Id, len(udn_start.Children))
 	}
 
 	// Argument list
@@ -613,8 +524,7 @@ func ProcessUdnArguments(db *sql.DB, udn_schema map[string]interface{}, udn_star
 
 	if udn_start.PartType == part_function || udn_start.PartType == part_compound {
 		// Look through the children, adding them to the args, as they are processed.
-		for child := udn_start.Children.Front(); child != nil; child = child.Next() {
-			arg_udn_start := child.Value.(*UdnPart)
+		for _, arg_udn_start := range udn_start.Children {
 
 			if arg_udn_start.PartType == part_compound {
 				// In a Compound part, the NextUdnPart is the function (currently)
@@ -628,7 +538,7 @@ func ProcessUdnArguments(db *sql.DB, udn_schema map[string]interface{}, udn_star
 
 					args = AppendArray(args, arg_result)
 				} else {
-					//UdnLogLevel(udn_schema, log_trace, "  UDN Args: Skipping: No NextUdnPart: Children: %d\n\n", arg_udn_start.Children.Len())
+					//UdnLogLevel(udn_schema, log_trace, "  UDN Args: Skipping: No NextUdnPart: Children: %d\n\n", arg_len(udn_start.Children))
 					//UdnLogLevel(udn_schema, log_trace, "  UDN Args: Skipping: No NextUdnPart: Value: %v\n\n", arg_udn_start.Value)
 				}
 			} else if arg_udn_start.PartType == part_function {
@@ -644,17 +554,21 @@ func ProcessUdnArguments(db *sql.DB, udn_schema map[string]interface{}, udn_star
 				//UdnLogLevel(udn_schema, log_trace, "--Starting Map Arg--\n\n")
 				// Then we populate it with data, by processing each of the keys and values
 				//TODO(g): Will first assume all keys are strings.  We may want to allow these to be dynamic as well, letting them be set by UDN, but forcing to a string afterwards...
-				for child := arg_udn_start.Children.Front(); child != nil; child = child.Next() {
-					key := child.Value.(*UdnPart).Value
+				for _, child := range arg_udn_start.Children {
+					key := child.Value
 
 					//ORIGINAL:
 					//TODO(z): Is ExecuteUdnCompound necessary for part_map instead of ExecuteUdnPart? Change if not
-					udn_part_value := child.Value.(*UdnPart).Children.Front().Value.(*UdnPart)
+					udn_part_value := child.Children[0]
 					//udn_part_result := ExecuteUdnPart(db, udn_schema, udn_part_value, input, udn_data)
 					udn_part_result := ExecuteUdnCompound(db, udn_schema, udn_part_value, input, udn_data)
 					arg_result_result[key] = udn_part_result.Result
 
 					UdnLogLevel(udn_schema, log_trace, "--  Map:  Key: %s  Value: %v (%T)--\n\n", key, udn_part_result.Result, udn_part_result.Result)
+
+					if UdnErrorPending(udn_schema) {
+						break
+					}
 				}
 				//UdnLogLevel(udn_schema, log_trace, "--Ending Map Arg--\n\n")
 
@@ -669,14 +583,17 @@ func ProcessUdnArguments(db *sql.DB, udn_schema map[string]interface{}, udn_star
 				//list_values := make([]interface{}, 0)
 
 				// Then we populate it with data, by processing each of the keys and values
-				for child := arg_udn_start.Children.Front(); child != nil; child = child.Next() {
-					udn_part_value := child.Value.(*UdnPart)
+				for _, udn_part_value := range arg_udn_start.Children {
 
 					UdnLogLevel(udn_schema, log_trace, "List Arg Eval: %v\n", udn_part_value)
 
 					udn_part_result := ExecuteUdnPart(db, udn_schema, udn_part_value, input, udn_data)
 					//list_values.PushBack(udn_part_result.Result)
 					array_values = AppendArray(array_values, udn_part_result.Result)
+
+					if UdnErrorPending(udn_schema) {
+						break
+					}
 				}
 
 				//UdnLogLevel(udn_schema, log_trace, "  UDN Argument: List: %v\n", SprintList(*list_values))
@@ -684,17 +601,25 @@ func ProcessUdnArguments(db *sql.DB, udn_schema map[string]interface{}, udn_star
 				//args = AppendArray(args, list_values)
 				args = AppendArray(args, array_values)
 			} else {
-				args = AppendArray(args, arg_udn_start.Value)
+				args = AppendArray(args, _UdnArgumentValue(udn_schema, udn_start, arg_udn_start))
+			}
+
+			// If an argument failed, we wont be executing this function, so dont process the rest of the arguments
+			if UdnErrorPending(udn_schema) {
+				break
 			}
 		}
 	} else if udn_start.PartType == part_list {
 		// Look through the children of the list and add to args
-		for child := udn_start.Children.Front(); child != nil; child = child.Next() {
-			udn_part_value := child.Value.(*UdnPart)
+		for _, udn_part_value := range udn_start.Children {
 
 			udn_part_result := ExecuteUdnPart(db, udn_schema, udn_part_value, input, udn_data)
 
 			args = AppendArray(args, udn_part_result.Result)
+
+			if UdnErrorPending(udn_schema) {
+				break
+			}
 		}
 	} else if udn_start.PartType == part_map {
 		// Look through the children of the map and add to args
@@ -702,22 +627,26 @@ func ProcessUdnArguments(db *sql.DB, udn_schema map[string]interface{}, udn_star
 
 		//UdnLogLevel(udn_schema, log_trace, "--Starting Map Arg--\n\n")
 
-		for child := udn_start.Children.Front(); child != nil; child = child.Next() {
-			key := child.Value.(*UdnPart).Value
+		for _, child := range udn_start.Children {
+			key := child.Value
 
-			udn_part_value := child.Value.(*UdnPart).Children.Front().Value.(*UdnPart)
+			udn_part_value := child.Children[0]
 			//udn_part_result := ExecuteUdnPart(db, udn_schema, udn_part_value, input, udn_data)
 			udn_part_result := ExecuteUdnCompound(db, udn_schema, udn_part_value, input, udn_data)
 			arg_result_result[key] = udn_part_result.Result
 
 			UdnLogLevel(udn_schema, log_trace, "--  Map:  Key: %s  Value: %v (%T)--\n\n", key, udn_part_result.Result, udn_part_result.Result)
+
+			if UdnErrorPending(udn_schema) {
+				break
+			}
 		}
 		//UdnLogLevel(udn_schema, log_trace, "--Ending Map Arg--\n\n")
 
 		args = AppendArray(args, arg_result_result)
 	} else {
 		// Default would be to just add to args (ex: string/item)
-		args = AppendArray(args, udn_start.Value)
+		args = AppendArray(args, UdnPartValue(udn_start))
 	}
 
 	// Only log if we have something to say, otherwise its just noise
@@ -733,18 +662,20 @@ func ProcessUdnArguments(db *sql.DB, udn_schema map[string]interface{}, udn_star
 func ExecuteUdn(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart, input interface{}, udn_data map[string]interface{}) interface{} {
 	// Process all our arguments, Executing any functions, at all depths.  Furthest depth first, to meet dependencies
 
-	UdnLogLevel(udn_schema, log_trace, "\nExecuteUDN: %s [%s]  Args: %d  Input: %s\n", udn_start.Value, udn_start.Id, udn_start.Children.Len(), SnippetData(input, 40))
+	UdnLogLevel(udn_schema, log_trace, "\nExecuteUDN: %s [%s]  Args: %d  Input: %s\n", udn_start.Value, udn_start.Id, len(udn_start.Children), SnippetData(input, 40))
 
 	// In case the function is nil, just pass through the input as the result.  Setting it here because we need this declared in function-scope
 	var result interface{}
 
 	// If this is a real function (not an end-block nil function)
-	if UdnFunctions[udn_start.Value] != nil {
+	if GetUdnEngine(udn_schema).Functions[udn_start.Value] != nil {
 		udn_result := ExecuteUdnPart(db, udn_schema, udn_start, input, udn_data)
 		result = udn_result.Result
 
-		// If we have more to process, do it
-		if udn_result.NextUdnPart != nil {
+		// If we have more to process, do it.  Unless we have an error, then we stop and let it unwind to a __try or our caller.
+		if UdnErrorPending(udn_schema) {
+			UdnLogLevel(udn_schema, log_trace, "ExecuteUdn: Error: Stopping at: %s [%s]\n", udn_start.Value, udn_start.Id)
+		} else if udn_result.NextUdnPart != nil {
 			UdnLogLevel(udn_schema, log_trace, "ExecuteUdn: Flow Control: JUMPING to NextUdnPart: %s [%s]\n", udn_result.NextUdnPart.Value, udn_result.NextUdnPart.Id)
 			// Our result gave us a NextUdnPart, so we can assume they performed some execution flow control themeselves, we will continue where they told us to
 			result = ExecuteUdn(db, udn_schema, udn_result.NextUdnPart, result, udn_data)
@@ -756,13 +687,11 @@ func ExecuteUdn(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPar
 	} else {
 		// Set the result to our input, because we got a nil-function, which doesnt change the result
 		result = input
-	}
 
-	// If the UDN Result is a list, convert it to an array, as it's easier to read the output
-	//TODO(g): Remove all the list.List stuff, so everything is an array.  Better.
-	result_type_str := fmt.Sprintf("%T", result)
-	if result_type_str == "*list.List" {
-		result = GetResult(result, type_array)
+		// Nil-functions are block ends (__end_if, __end_try), which flow control jumps to, so continue with whatever comes after the block
+		if udn_start.NextUdnPart != nil {
+			result = ExecuteUdn(db, udn_schema, udn_start.NextUdnPart, result, udn_data)
+		}
 	}
 
 	UdnLogLevel(udn_schema, log_trace, "ExecuteUDN: End Function: %s [%s]: Result: %s\n\n", udn_start.Value, udn_start.Id, SnippetData(result, 40))
@@ -774,11 +703,87 @@ func ExecuteUdn(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPar
 // Execute a single UdnPart.  This is necessary, because it may not be a function, it might be a Compound, which has a function inside it.
 //		At the top level, this is not necessary, but for flow control, we need to wrap this so that each Block Executor doesnt need to duplicate logic.
 //NOTE(g): This function must return a UdnPart, because it is necessary for Flow Control (__iterate, etc)
-func ExecuteUdnPart(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart, input interface{}, udn_data map[string]interface{}) UdnResult {
+func ExecuteUdnPart(db *sql.DB, udn_schema map[string]interface{}, udn_start *UdnPart, input interface{}, udn_data map[string]interface{}) (udn_result UdnResult) {
 	//UdnLogLevel(udn_schema, log_trace, "Executing UDN Part: %s [%s]\n", udn_start.Value, udn_start.Id)
 
+	// A panic (ex: GetResult on a bad record) becomes an error that unwinds like any other, instead of crashing the request.  Functions we execute recover their own panics, so this is only for our arguments and our function.
+	loop_depth := _GetUdnLoopDepth(udn_schema)
+	call_depth, _ := udn_data["__call_depth"].(int)
+	function_stack_depth := _GetUdnFunctionStackDepth(udn_data)
+	scope_depth := _GetUdnScopeDepth(udn_data)
+
+	var args []interface{}
+
+	udn_function := GetUdnEngine(udn_schema).Functions[udn_start.Value]
+
+	// Functions are spans of the execution trace, with the functions in their arguments nested in them.  This is deferred first, so it ends the span after a panic is recovered.
+	if udn_start.PartType == part_function && udn_function != nil {
+		if trace := GetUdnTrace(udn_schema); trace != nil {
+			span := trace.BeginSpan("function", udn_start.Value, udn_start.Id, input)
+
+			defer func() {
+				span.SetArgs(args)
+				trace.EndSpan(span, udn_result.Result, _UdnTraceError(udn_schema, udn_result.Error))
+			}()
+		}
+	}
+
+	// Functions are profiled from before their arguments are processed, until after a panic is recovered
+	var profile_frame *_UdnProfileFrame
+	if udn_start.PartType == part_function && udn_function != nil {
+		if profiler := GetUdnProfiler(udn_schema); profiler != nil {
+			profile_frame = profiler._BeginFunction(udn_schema, udn_start)
+			defer profiler._EndFunction(udn_schema, profile_frame)
+		}
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			// Our function may have panicked inside a loop or call it started, put back where we were
+			_SwapUdnLoopDepth(udn_schema, loop_depth)
+			ClearUdnLoopControl(udn_schema)
+			for _GetUdnFunctionStackDepth(udn_data) > function_stack_depth {
+				PopUdnFunctionStack(udn_data)
+			}
+			_RestoreUdnScopeDepth(udn_data, scope_depth)
+			for current_call_depth, _ := udn_data["__call_depth"].(int); current_call_depth > call_depth; current_call_depth-- {
+				PopUdnCallDepth(udn_data)
+			}
+
+			RecoverUdnPanic(udn_schema, udn_start, udn_data, recovered)
+
+			udn_result = UdnResult{}
+		}
+	}()
+
+	// Every function is a step of the execution budget.  If we are over a limit (steps, deadline, cancelled), this fails and unwinds like any other error.
+	if udn_start.PartType == part_function && udn_function != nil {
+		if message := CheckUdnExecutionBudget(udn_schema); message != "" {
+			SetUdnError(udn_schema, udn_start, nil, message)
+			return UdnResult{}
+		}
+
+		// Functions the execution's policy doesnt allow fail before their arguments are processed
+		if message := CheckUdnPolicyFunction(udn_schema, udn_start.Value); message != "" {
+			SetUdnError(udn_schema, udn_start, nil, message)
+			return UdnResult{}
+		}
+	}
+
 	// Process the arguments
-	args := ProcessUdnArguments(db, udn_schema, udn_start, input, udn_data)
+	args = ProcessUdnArguments(db, udn_schema, udn_start, input, udn_data)
+
+	if profile_frame != nil {
+		profile_frame._ArgsDone(args)
+	}
+
+	// If an argument failed, we dont execute with partial arguments, the error just unwinds through us
+	if UdnErrorPending(udn_schema) {
+		if udn_start.PartType == part_function {
+			UnwindUdnError(udn_schema, udn_start, args)
+		}
+		return UdnResult{}
+	}
 
 	UdnDebug(udn_schema, input, "View Input", fmt.Sprintf("Execute UDN Part: %s: %v", udn_start.Value, SnippetData(args, 300)))
 
@@ -787,14 +792,26 @@ func ExecuteUdnPart(db *sql.DB, udn_schema map[string]interface{}, udn_start *Ud
 	udn_data["arg"] = args
 
 	// What we return, unified return type in UDN
-	udn_result := UdnResult{}
+	udn_result = UdnResult{}
 
 	if udn_start.PartType == part_function {
-		if UdnFunctions[udn_start.Value] != nil {
+		if udn_function != nil {
 			// Execute a function
 			UdnLogLevel(udn_schema, log_trace, "Executing: %s [%s]   Args: %v\n", udn_start.Value, udn_start.Id, SnippetData(args, 80))
 
-			udn_result = UdnFunctions[udn_start.Value](db, udn_schema, udn_start, args, input, udn_data)
+			if debugger := GetUdnDebugger(udn_schema); debugger != nil {
+				udn_result = _ExecuteUdnFunctionDebug(debugger, db, udn_schema, udn_start, args, input, udn_data)
+			} else {
+				udn_result = udn_function(db, udn_schema, udn_start, args, input, udn_data)
+			}
+
+			if UdnErrorPending(udn_schema) {
+				// Something this function executed failed (flow control blocks, __function, __execute), so we are part of the stack
+				UnwindUdnError(udn_schema, udn_start, args)
+			} else if udn_result.Error != "" {
+				// The function failed, start unwinding
+				SetUdnError(udn_schema, udn_start, args, udn_result.Error)
+			}
 		} else {
 			//UdnLogLevel(udn_schema, log_trace, "Skipping Execution, nil function, result = input: %s\n", udn_start.Value)
 			udn_result.Result = input
@@ -812,7 +829,7 @@ func ExecuteUdnPart(db *sql.DB, udn_schema map[string]interface{}, udn_start *Ud
 		udn_result.Result = args
 	} else {
 		// We just store the value, if it is not handled as a special case above
-		udn_result.Result = udn_start.Value
+		udn_result.Result = UdnPartValue(udn_start)
 	}
 
 	//UdnLogLevel(udn_schema, log_trace, "=-=-=-=-= Executing UDN Part: End: %s [%s] Full Result: %v\n\n", udn_start.Value, udn_start.Id, udn_result.Result)	// DEBUG
@@ -839,7 +856,7 @@ func ExecuteUdnCompound(db *sql.DB, udn_schema map[string]interface{}, udn_start
 			udn_result = ExecuteUdnPart(db, udn_schema, udn_current, input, udn_data)
 			input = udn_result.Result
 
-			if udn_current.NextUdnPart == nil {
+			if udn_current.NextUdnPart == nil || UdnErrorPending(udn_schema) || UdnLoopControlPending(udn_schema) {
 				done = true
 				//fmt.Print("  UDN Compound: Finished\n")
 			} else {
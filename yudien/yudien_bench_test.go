@@ -63,3 +63,32 @@ func BenchmarkUDN(b *testing.B) {
 		b.Errorf("Error walking: %v", err)
 	}
 }
+
+// Statements that dont need a database, so the parser and executor can be measured anywhere
+var benchUdnStatements = map[string]string{
+	"pipe":    "__input.abc.__set.temp.x.__get.temp.x.__set.temp.y.__get.temp.y",
+	"iterate": "__input.[1,2,3,4,5,6,7,8,9,10].__iterate.__input.{a=1,b=[1,2,3],c=(__get.temp.x)}.__end_iterate",
+	"nested":  "__input.{a=[1,2,{b=[3,4]}],c={d={e=(__input.x)}}}.__set.temp.m.__get.temp.m.c.d.e",
+}
+
+func BenchmarkUdnParse(b *testing.B) {
+	for name, statement := range benchUdnStatements {
+		b.Run(name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				benchResult, _ = ParseUdnString(nil, nil, statement)
+			}
+		})
+	}
+}
+
+func BenchmarkUdnExecute(b *testing.B) {
+	for name, statement := range benchUdnStatements {
+		b.Run(name, func(b *testing.B) {
+			udn_schema := testUdnSchema()
+
+			for n := 0; n < b.N; n++ {
+				benchResult = ProcessSingleUDNTarget(nil, udn_schema, statement, nil, map[string]interface{}{})
+			}
+		})
+	}
+}
@@ -1,11 +1,11 @@
 package yudiencore
 
 import (
-	"container/list"
 	"fmt"
 	"strings"
 	"io/ioutil"
 	"encoding/json"
+	"path/filepath"
 )
 
 const (
@@ -59,13 +59,12 @@ type UdnPart struct {
 
 	Value string
 
-	// List of UdnPart structs, list is easier to use dynamically
-	//TODO(g): Switch this to an array.  Lists suck...
-	Children *list.List
+	// Arguments of a function, items of a list, keys of a map, and values of a map key
+	Children []*UdnPart
 
 	Id string
 
-	// Puts the data here after it's been evaluated
+	// Literal value, set while parsing.  Parsed parts are shared through the parse cache, so this is never written during execution, evaluated results only live in UdnResult
 	ValueFinal     interface{}
 	ValueFinalType int
 
@@ -82,7 +81,7 @@ type UdnPart struct {
 
 func NewUdnPart() UdnPart {
 	return UdnPart{
-		Children: list.New(),
+		Children: make([]*UdnPart, 0),
 	}
 }
 
@@ -193,31 +192,38 @@ func (udn_parent *UdnPart) AddChild(part_type int, value string) *UdnPart {
 	new_part.Value = value
 
 	// Add to current chilidren
-	udn_parent.Children.PushBack(&new_part)
+	udn_parent.Children = append(udn_parent.Children, &new_part)
 
 	return &new_part
 }
 
 
 func UdnError(udn_schema map[string]interface{}, format string, args ...interface{}) {
-	// Format the incoming Printf args, and print them
-	output := fmt.Sprintf("ERROR: " + format, args...)
+	// Format the incoming Printf args, and log them
+	message := fmt.Sprintf(format, args...)
+	output := "ERROR: " + message
 
-	fmt.Print(output)
+	GetUdnLogger(udn_schema).Log(log_error, message, _UdnLogFields(udn_schema))
 
 	// Append the output into our udn_schema["debug_log"], where we keep raw logs, before wrapping them up for debugging visibility purposes
 	if udn_schema != nil {
-		udn_schema["error_log"] = udn_schema["error_log"].(string) + output
+		error_log, _ := udn_schema["error_log"].(string)
+		udn_schema["error_log"] = error_log + output
 	}
 }
 
+// Return an error as a UdnResult.  UDN functions return this instead of panicking, so the error stops the pipe and can be handled with __try/__catch
+func UdnResultError(format string, args ...interface{}) UdnResult {
+	return UdnResult{Error: fmt.Sprintf(format, args...)}
+}
+
 func UdnLogHtml(udn_schema map[string]interface{}, log_level int, format string, args ...interface{}) {
 	UdnLogLevel(udn_schema, log_level, format, args)
 
 	if (Debug_Udn || udn_schema["udn_debug"].(bool)) && udn_schema["allow_logging"].(bool) {
-		// Format the incoming Printf args, and print them
+		// Format the incoming Printf args, and log them
 		output := fmt.Sprintf(format, args...)
-		fmt.Print(output)
+		GetUdnLogger(udn_schema).Log(log_debug, output, _UdnLogFields(udn_schema))
 
 		// Append the output into our udn_schema["debug_log"], where we keep raw logs, before wrapping them up for debugging visibility purposes
 		udn_schema["debug_log"] = udn_schema["debug_log"].(string) + output
@@ -228,9 +234,10 @@ func UdnLogHtml(udn_schema map[string]interface{}, log_level int, format string,
 
 
 func HtmlClean(html string) string {
+	// "&" first, or it would escape the entities we replace "<" and ">" with
+	html = strings.Replace(html, "&", "&amp;", -1)
 	html = strings.Replace(html, "<", "&lt;", -1)
 	html = strings.Replace(html, ">", "&gt;", -1)
-	html = strings.Replace(html, "&", "&amp;", -1)
 	html = strings.Replace(html, " ", "&nbsp;", -1)
 
 	return html
@@ -240,22 +247,45 @@ func HtmlClean(html string) string {
 
 
 
+// Where UdnDebugWriteHtml writes an execution's HTML debug logs (the temp directory if empty), and what removes old ones from there after it writes.  A yudien Engine is put in udn_schema["engine"], and implements this: they go in its LoggingConfig.TracePath, where they are removed like traces.
+type UdnDebugHtmlSettings interface {
+	GetDebugHtmlPath() string
+	PruneDebugHtml(directory string)
+}
+
+// Write the HTML debug log to a new file in the directory of its UdnDebugHtmlSettings, and return its path.  Each call gets its own file, so concurrent requests dont overwrite each other.
+//NOTE(g): The execution trace (yudien.StartUdnTrace) replaces this: it has per-function spans, JSON lines and a retention policy
 func UdnDebugWriteHtml(udn_schema map[string]interface{}) string {
 	if Debug_Udn || udn_schema["udn_debug"] == true {
 		fmt.Printf("\n\n\n\n-=-=-=-=-=- UDN Debug Write HTML -=-=-=-=-=-\n\n\n\n")
 	}
 
-	//TODO(g): Make this unique, time in milliseconds should be OK (and sequential), so we can have more than one.  Then deal with cleanup.  And make a sub directory...
-	output_path := "/tmp/udn_debug_log.html"
-
 	// Process any remaining HTML chunk as well
 	UdnDebugIncrementChunk(udn_schema)
 
-	err := ioutil.WriteFile(output_path, []byte(udn_schema["debug_output_html"].(string)), 0644)
+	output_path := ""
+
+	settings, _ := udn_schema["engine"].(UdnDebugHtmlSettings)
+
+	directory := ""
+	if settings != nil {
+		directory = settings.GetDebugHtmlPath()
+	}
+
+	output_file, err := ioutil.TempFile(directory, "udn_debug_log_*.html")
+	if err == nil {
+		output_path = output_file.Name()
+		_, err = output_file.WriteString(udn_schema["debug_output_html"].(string))
+		output_file.Close()
+	}
 	if err != nil {
 		UdnError(nil, err.Error())
 	}
 
+	if output_path != "" && settings != nil {
+		settings.PruneDebugHtml(filepath.Dir(output_path))
+	}
+
 	// Clear the schema info
 	//TODO(g): This only works for concurrency at the moment because I get the udn_schema every request, which is wasteful.  So work that out...
 	UdnDebugReset(udn_schema)
@@ -278,18 +308,14 @@ func UdnDebugReset(udn_schema map[string]interface{}) {
 	udn_schema["debug_output"] = ""
 	udn_schema["debug_output_html"] = `
 		<head>
-			<script src="https://ajax.googleapis.com/ajax/libs/jquery/3.2.1/jquery.min.js">
-			</script>
 			<script>
 			function ToggleDisplay(element_id) {
-				var current_display = $('#'+element_id).css('display');
-				if (current_display == 'none') {
-					$('#'+element_id).css('display', 'block');
-					//alert('Setting ' + element_id + ' to BLOCK == Current: ' + current_display)
+				var element = document.getElementById(element_id);
+				if (element.style.display == 'none') {
+					element.style.display = 'block';
 				}
 				else {
-					$('#'+element_id).css('display', 'none');
-					//alert('Setting ' + element_id + ' to NONE == Current: ' + current_display)
+					element.style.display = 'none';
 				}
 			}
 			</script>
@@ -366,10 +392,10 @@ func UdnDebugUpdate(udn_schema map[string]interface{}) {
 
 func UdnLog(udn_schema map[string]interface{}, format string, args ...interface{}) {
 	if (Debug_Udn || udn_schema["udn_debug"].(bool)) && udn_schema["allow_logging"].(bool) {
-		// Format the incoming Printf args, and print them
+		// Format the incoming Printf args, and log them
 		output := fmt.Sprintf(format, args...)
 
-		fmt.Print(output)
+		GetUdnLogger(udn_schema).Log(log_debug, output, _UdnLogFields(udn_schema))
 
 		// Append the output into our udn_schema["debug_log"], where we keep raw logs, before wrapping them up for debugging visibility purposes
 		udn_schema["debug_log"] = udn_schema["debug_log"].(string) + output
@@ -380,19 +406,18 @@ func UdnLogLevel(udn_schema map[string]interface{}, log_level int, format string
 	// Function works the same as UdnLog/UdnError but allows level logging
 	//TODO(z): Migrate UdnDebug functionality here later
 	//TODO(z): Combine all log functions to put under UdnLogLevel
-	if log_level <= Debug_Udn_Log_Level {
-		output := fmt.Sprintf(format, args...)
+	if log_level <= GetUdnLogLevel(udn_schema) {
+		message := fmt.Sprintf(format, args...)
 
 		if log_level == log_error && udn_schema != nil{
-			output = fmt.Sprintf("ERROR: " + format, args...)
-			udn_schema["error_log"] = udn_schema["error_log"].(string) + output
+			udn_schema["error_log"] = udn_schema["error_log"].(string) + "ERROR: " + message
 		}
 		if log_level >= log_debug && udn_schema != nil{
 			// Append the output into our udn_schema["debug_log"], where we keep raw logs, before wrapping them up for debugging visibility purposes
-			udn_schema["debug_log"] = udn_schema["debug_log"].(string) + output
+			udn_schema["debug_log"] = udn_schema["debug_log"].(string) + message
 		}
 
-		fmt.Print(output)
+		GetUdnLogger(udn_schema).Log(log_level, message, _UdnLogFields(udn_schema))
 	}
 }
 
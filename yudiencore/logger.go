@@ -0,0 +1,225 @@
+package yudiencore
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// UdnLogLevel, UdnLog and UdnError write to a Logger, instead of printing.  The message is formatted, without the "ERROR: " prefix (the level says it is an error).  Fields are key/values about where it was logged (ex: "request_id", "source"), and may be nil.
+type Logger interface {
+	Log(level int, message string, fields map[string]interface{})
+}
+
+// Where all the UDN logs go.  Configure sets this to a JsonLinesLogger when LoggingConfig.OutputPath is set.
+var UdnLogger Logger = &TextLogger{Output: os.Stdout}
+
+// An execution's own log level and Logger, instead of Debug_Udn_Log_Level and UdnLogger.  A yudien Engine is put in udn_schema["engine"], and implements this.
+type UdnLogSettings interface {
+	GetLogLevel() int
+	GetLogger() Logger
+}
+
+// Returns the log level of the execution that udn_schema is for
+func GetUdnLogLevel(udn_schema map[string]interface{}) int {
+	if settings, ok := udn_schema["engine"].(UdnLogSettings); ok {
+		return settings.GetLogLevel()
+	}
+
+	return Debug_Udn_Log_Level
+}
+
+// Returns the Logger of the execution that udn_schema is for
+func GetUdnLogger(udn_schema map[string]interface{}) Logger {
+	if settings, ok := udn_schema["engine"].(UdnLogSettings); ok {
+		return settings.GetLogger()
+	}
+
+	return UdnLogger
+}
+
+// Prints the messages as they are, like UDN always has.  Fields are not printed.
+type TextLogger struct {
+	Output io.Writer
+
+	lock sync.Mutex
+}
+
+func (logger *TextLogger) Log(level int, message string, fields map[string]interface{}) {
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	if level == log_error {
+		message = "ERROR: " + message
+	}
+
+	fmt.Fprint(logger.Output, message)
+}
+
+// Writes every message as a JSON line: {"time": ..., "level": "error", "message": ..., "request_id": ..., ...}, with the fields at the top level.  When the file is over MaxSize bytes it is rotated: path.1 is the newest old file, up to path.<MaxBackups>.
+type JsonLinesLogger struct {
+	Path       string
+	MaxSize    int64 // 0 never rotates
+	MaxBackups int   // Old files kept when rotating.  0 keeps none.
+
+	lock sync.Mutex
+	file *os.File
+	size int64
+
+	// Path was moved by a rotation, but the new file didnt open.  We keep writing to the old file until it does.
+	reopen bool
+}
+
+// Opens (appending to) path
+func NewJsonLinesLogger(path string, max_size int64, max_backups int) (*JsonLinesLogger, error) {
+	logger := &JsonLinesLogger{Path: path, MaxSize: max_size, MaxBackups: max_backups}
+
+	if err := logger._Open(); err != nil {
+		return nil, err
+	}
+
+	return logger, nil
+}
+
+func (logger *JsonLinesLogger) Log(level int, message string, fields map[string]interface{}) {
+	line := make(map[string]interface{}, len(fields)+3)
+	for key, value := range fields {
+		line[key] = value
+	}
+	line["time"] = time.Now().UTC().Format(time.RFC3339Nano)
+	line["level"] = LogLevelName(level)
+	line["message"] = strings.TrimSpace(message)
+
+	line_json, err := json.Marshal(line)
+	if err != nil {
+		// A field that cant be encoded, the message is still worth having
+		line_json, _ = json.Marshal(map[string]interface{}{"time": line["time"], "level": line["level"], "message": line["message"], "log_error": err.Error()})
+	}
+	line_json = append(line_json, '\n')
+
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	if logger.file == nil {
+		return
+	}
+
+	if logger.reopen || (logger.MaxSize > 0 && logger.size > 0 && logger.size+int64(len(line_json)) > logger.MaxSize) {
+		if err := logger._Rotate(); err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: Rotate log: %s: %s\n", logger.Path, err)
+		}
+	}
+
+	written, err := logger.file.Write(line_json)
+	logger.size += int64(written)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: Write log: %s: %s\n", logger.Path, err)
+	}
+}
+
+func (logger *JsonLinesLogger) Close() error {
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	if logger.file == nil {
+		return nil
+	}
+
+	err := logger.file.Close()
+	logger.file = nil
+	return err
+}
+
+func (logger *JsonLinesLogger) _Open() error {
+	file, err := os.OpenFile(logger.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	logger.file = file
+	logger.size = info.Size()
+
+	return nil
+}
+
+// Move path to path.1 (and path.1 to path.2, ...), and start a new file.  If this fails, the current file is kept, so no lines are lost, and the next line tries again.
+func (logger *JsonLinesLogger) _Rotate() error {
+	if !logger.reopen {
+		if logger.MaxBackups > 0 {
+			// Only shift the backups up to the first free one, so retrying a failed rotation doesnt push out more of them
+			free_backup := 1
+			for free_backup < logger.MaxBackups {
+				if _, err := os.Stat(fmt.Sprintf("%s.%d", logger.Path, free_backup)); err != nil {
+					break
+				}
+				free_backup++
+			}
+			for backup := free_backup - 1; backup >= 1; backup-- {
+				os.Rename(fmt.Sprintf("%s.%d", logger.Path, backup), fmt.Sprintf("%s.%d", logger.Path, backup+1))
+			}
+			if err := os.Rename(logger.Path, logger.Path+".1"); err != nil {
+				return err
+			}
+		} else if err := os.Remove(logger.Path); err != nil {
+			return err
+		}
+
+		logger.reopen = true
+	}
+
+	old_file := logger.file
+
+	if err := logger._Open(); err != nil {
+		return err
+	}
+
+	old_file.Close()
+	logger.reopen = false
+
+	return nil
+}
+
+func LogLevelName(level int) string {
+	switch level {
+	case log_error:
+		return "error"
+	case log_warn:
+		return "warn"
+	case log_info:
+		return "info"
+	case log_debug:
+		return "debug"
+	case log_trace:
+		return "trace"
+	default:
+		return "off"
+	}
+}
+
+// Where a log line came from: the request (the outermost function stack frame's id), and the UDN statement being executed
+func _UdnLogFields(udn_schema map[string]interface{}) map[string]interface{} {
+	if udn_schema == nil {
+		return nil
+	}
+
+	fields := make(map[string]interface{})
+
+	if request_id, ok := udn_schema["request_id"].(string); ok && request_id != "" {
+		fields["request_id"] = request_id
+	}
+	if source, ok := udn_schema["udn_source"].(string); ok && source != "" {
+		fields["source"] = source
+	}
+
+	return fields
+}
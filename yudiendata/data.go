@@ -65,13 +65,38 @@ type DatabaseConfig struct {
 	ConnectOptions string `json:"connect_opts"`
 }
 
+// The databases of a Yudien engine, by name.  The default database is also "_default".
+type Datasources struct {
+	Instance       map[string]*storagenode.DatasourceInstance
+	Config         map[string]*storagenode.DatasourceInstanceConfig
+	Database       map[string]string
+	DatabaseConfig map[string]DatabaseConfig
+
+	DefaultTarget string
+
+	// When it is set, the Dataman functions use it instead of the databases (see DatamanStore)
+	StandIn DatamanStore
+}
+
+func NewDatasources() *Datasources {
+	return &Datasources{
+		Instance:       map[string]*storagenode.DatasourceInstance{},
+		Config:         map[string]*storagenode.DatasourceInstanceConfig{},
+		Database:       map[string]string{},
+		DatabaseConfig: map[string]DatabaseConfig{},
+	}
+}
+
+// The default engine's databases.  Dataman functions use them, unless their options have other "datasources".
+var DefaultDatasources = NewDatasources()
+
 var DefaultDatabase *DatabaseConfig
-var AllDatabaseConfig = map[string]DatabaseConfig{}
+var AllDatabaseConfig = DefaultDatasources.DatabaseConfig
 
 
-var DatasourceInstance = map[string]*storagenode.DatasourceInstance{}
-var DatasourceConfig = map[string]*storagenode.DatasourceInstanceConfig{}
-var DatasourceDatabase = map[string]string{}
+var DatasourceInstance = DefaultDatasources.Instance
+var DatasourceConfig = DefaultDatasources.Config
+var DatasourceDatabase = DefaultDatasources.Database
 
 var DefaultDatabaseTarget string
 
@@ -80,10 +105,15 @@ var DatabaseToDatasourceInstance = map[string]*storagenode.DatasourceInstance{}
 
 
 func Query(db *sql.DB, sql string) []map[string]interface{} {
+	return QueryContext(context.Background(), db, sql)
+}
+
+// Query, which is cancelled with the context (ex: the UDN execution's deadline)
+func QueryContext(ctx context.Context, db *sql.DB, sql string) []map[string]interface{} {
 	UdnLogLevel(nil, log_debug,"Query: %s\n", sql)
 
 	// Query
-	rs, err := db.Query(sql)
+	rs, err := db.QueryContext(ctx, sql)
 	if err != nil {
 		log.Panic(fmt.Sprintf("SQL: %s\nError: %s\n", sql, err))
 	}
@@ -130,18 +160,29 @@ func Query(db *sql.DB, sql string) []map[string]interface{} {
 	return outArr
 }
 
+// Returns the datasources of the Dataman options: an engine that isnt the default passes its own as "datasources", otherwise they are DefaultDatasources
+func GetDatasources(options map[string]interface{}) *Datasources {
+	if datasources, ok := options["datasources"].(*Datasources); ok {
+		return datasources
+	}
+
+	return DefaultDatasources
+}
+
 // Returns a DatasourceInstance.  If name is "" or not found, it starts with the lowest DB and finds the first collection/table that matches
 func GetDatasourceInstance(options map[string]interface{}) (*storagenode.DatasourceInstance, string, string) {
-	datasource_instance := DatasourceInstance["_default"]
-	datasource_database := DatasourceDatabase["_default"]
+	datasources := GetDatasources(options)
+
+	datasource_instance := datasources.Instance["_default"]
+	datasource_database := datasources.Database["_default"]
 
 	selected_db := "_default"
 
 	// If there is a specified option to select an explicit Database
 	if options["db"] != nil {
-		if DatasourceInstance[options["db"].(string)] != nil {
-			datasource_instance = DatasourceInstance[options["db"].(string)]
-			datasource_database = DatasourceDatabase[options["db"].(string)]
+		if datasources.Instance[options["db"].(string)] != nil {
+			datasource_instance = datasources.Instance[options["db"].(string)]
+			datasource_database = datasources.Database[options["db"].(string)]
 
 			selected_db = options["db"].(string)
 		}
@@ -158,6 +199,28 @@ func GetDatasourceInstance(options map[string]interface{}) (*storagenode.Datasou
 >>>>>>> 4c058a4... Clean up
 }
 
+// Options for the queries a Dataman function makes itself (ex: the schema lookups of DatamanDelete), on the same datasources and context as options
+func _DatamanBaseOptions(options map[string]interface{}) map[string]interface{} {
+	base_options := make(map[string]interface{})
+
+	for _, key := range []string{"datasources", "context"} {
+		if options[key] != nil {
+			base_options[key] = options[key]
+		}
+	}
+
+	return base_options
+}
+
+// The context of the "context" option, so the query is cancelled with the execution that made it
+func _DatamanContext(options map[string]interface{}) context.Context {
+	if ctx, ok := options["context"].(context.Context); ok {
+		return ctx
+	}
+
+	return context.Background()
+}
+
 func GetRecordLabel(datasource_database string, collection_name string, record_id int) string {
 	record_label := fmt.Sprintf("%s.%s.%d", datasource_database, collection_name, record_id)
 
@@ -187,7 +250,7 @@ func AddJoinAsFlatNamespace(record map[string]interface{}, join_array []interfac
 	}
 }
 
-func DatamanGetByLabel(record_label string) map[string]interface{} {
+func DatamanGetByLabel(record_label string, options map[string]interface{}) map[string]interface{} {
 	UdnLogLevel(nil, log_debug, "Dataman GET By Label: %s\n", record_label)
 
 	parts := strings.Split(record_label, ".")
@@ -196,7 +259,8 @@ func DatamanGetByLabel(record_label string) map[string]interface{} {
 	table := parts[1]
 	record_pkey := GetResult(parts[2], type_int).(int64)
 
-	options := make(map[string]interface{})
+	// The label's database, on the datasources of options
+	options = MapCopy(options)
 	options["db"] = database
 
 	record := DatamanGet(table, int(record_pkey), options)
@@ -204,7 +268,7 @@ func DatamanGetByLabel(record_label string) map[string]interface{} {
 	return record
 }
 
-func DatamanSetByLabel(record_label string, record map[string]interface{}) map[string]interface{} {
+func DatamanSetByLabel(record_label string, record map[string]interface{}, options map[string]interface{}) map[string]interface{} {
 	UdnLogLevel(nil, log_debug, "Dataman SET By Label: %s: %v\n", record_label, record)
 
 	parts := strings.Split(record_label, ".")
@@ -212,7 +276,7 @@ func DatamanSetByLabel(record_label string, record map[string]interface{}) map[s
 	database := parts[0]
 	table := parts[1]
 
-	options := make(map[string]interface{})
+	options = MapCopy(options)
 	options["db"] = database
 
 	record_result := DatamanSet(table, record, options)
@@ -221,6 +285,10 @@ func DatamanSetByLabel(record_label string, record map[string]interface{}) map[s
 }
 
 func DatamanGet(collection_name string, record_id int, options map[string]interface{}) map[string]interface{} {
+	if stand_in := GetDatasources(options).StandIn; stand_in != nil {
+		return stand_in.Get(collection_name, int64(record_id), options)
+	}
+
 	//fmt.Printf("DatamanGet: %s: %d\n", collection_name, record_id)
 
 	datasource_instance, datasource_database, selected_db := GetDatasourceInstance(options)
@@ -238,12 +306,17 @@ func DatamanGet(collection_name string, record_id int, options map[string]interf
 
 	dataman_query := &query.Query{query.Get, get_map}
 
-	result := datasource_instance.HandleQuery(context.Background(), dataman_query)
+	result := datasource_instance.HandleQuery(_DatamanContext(options), dataman_query)
 
 	if result.Error != "" {
 		UdnLogLevel(nil, log_error, "Dataman GET: %s: ERRORS: %v\n", datasource_database, result.Error)
 	}
 
+	// Ex: the query was cancelled
+	if len(result.Return) == 0 {
+		return nil
+	}
+
 	UdnLogLevel(nil, log_debug, "Dataman GET: %s: %v\n", datasource_database, result.Return[0])
 
 	record := result.Return[0]
@@ -276,6 +349,10 @@ func DatamanGet(collection_name string, record_id int, options map[string]interf
 func DatamanSet(collection_name string, record map[string]interface{}, options map[string]interface{}) map[string]interface{} {
 	UdnLogLevel(nil, log_trace, "Dataman SET: %s: %v\n", collection_name, record)
 
+	if stand_in := GetDatasources(options).StandIn; stand_in != nil {
+		return stand_in.Set(collection_name, record, options)
+	}
+
 	// Duplicate this map, because we are messing with a live map, that we dont expect to change in this function...
 	//TODO(g):REMOVE: Once I dont need to manipulate the map in this function anymore...
 	record = MapCopy(record)
@@ -484,7 +561,7 @@ func DatamanSet(collection_name string, record map[string]interface{}, options m
 	//UdnLogLevel(nil, log_trace, "Dataman SET: Query: ABORT ABORT ABORT\n")
 	//return record		//DEBUG- ABORT ABORT ABORT <<----==-------
 
-	result := datasource_instance.HandleQuery(context.Background(), dataman_query)
+	result := datasource_instance.HandleQuery(_DatamanContext(options), dataman_query)
 
 
 	if result.ValidationError != nil {
@@ -518,6 +595,10 @@ func DatamanSet(collection_name string, record map[string]interface{}, options m
 func DatamanInsert(collection_name string, record map[string]interface{}, options map[string]interface{}) map[string]interface{} {
 	UdnLogLevel(nil, log_trace, "Dataman INSERT: %s: %v\n", collection_name, record)
 
+	if stand_in := GetDatasources(options).StandIn; stand_in != nil {
+		return stand_in.Set(collection_name, record, options)
+	}
+
 	// Duplicate this map, because we are messing with a live map, that we dont expect to change in this function...
 	//TODO(g):REMOVE: Once I dont need to manipulate the map in this function anymore...
 	record = MapCopy(record)
@@ -543,7 +624,7 @@ func DatamanInsert(collection_name string, record map[string]interface{}, option
 	//UdnLogLevel(nil, log_debug,"Dataman SET: Record: %v\n", record)
 	UdnLogLevel(nil, log_trace, "Dataman INSERT: Query: JSON: %v\n", JsonDump(dataman_query))
 
-	result := datasource_instance.HandleQuery(context.Background(), dataman_query)
+	result := datasource_instance.HandleQuery(_DatamanContext(options), dataman_query)
 
 
 	if result.ValidationError != nil {
@@ -574,6 +655,9 @@ func DatamanInsert(collection_name string, record map[string]interface{}, option
 }
 
 func DatamanFilter(collection_name string, filter_input_map map[string]interface{}, options map[string]interface{}) []map[string]interface{} {
+	if stand_in := GetDatasources(options).StandIn; stand_in != nil {
+		return stand_in.Filter(collection_name, filter_input_map, options)
+	}
 
 	//fmt.Printf("DatamanFilter: %s:  Filter: %v  Join: %v\n\n", collection_name, filter, options["join"])
 	//fmt.Printf("Sort: %v\n", options["sort"])		//TODO(g): Sorting
@@ -649,7 +733,7 @@ func DatamanFilter(collection_name string, filter_input_map map[string]interface
 	dataman_query := &query.Query{query.Filter, filter_map}
 
 
-	result := datasource_instance.HandleQuery(context.Background(), dataman_query)
+	result := datasource_instance.HandleQuery(_DatamanContext(options), dataman_query)
 
 	if result.Error != "" {
 		UdnLogLevel(nil, log_error, "Dataman ERROR: %v\n", result.Error)
@@ -692,6 +776,10 @@ func DatamanFilter(collection_name string, filter_input_map map[string]interface
 func DatamanFilterFull(collection_name string, filter interface{}, options map[string]interface{}) []map[string]interface{} {
 	// Contains updated functionality of DatamanFilter where multiple constraints can be used as per dataman specs
 
+	if stand_in := GetDatasources(options).StandIn; stand_in != nil {
+		return stand_in.Filter(collection_name, filter, options)
+	}
+
 	datasource_instance, datasource_database, selected_db := GetDatasourceInstance(options)
 
 	// filter should be a map[string]interface{} for single filters and []interface{} for multi-filters
@@ -720,7 +808,7 @@ func DatamanFilterFull(collection_name string, filter interface{}, options map[s
 
 	dataman_query := &query.Query{query.Filter, filter_map}
 
-	result := datasource_instance.HandleQuery(context.Background(), dataman_query)
+	result := datasource_instance.HandleQuery(_DatamanContext(options), dataman_query)
 
 	if result.Error != "" {
 		UdnLogLevel(nil, log_error, "Dataman ERROR: %v\n", result.Error)
@@ -777,13 +865,21 @@ func DatamanDelete(collection_name string, record_id int64, options map[string]i
 	// Whether to delete or NULL FK dependencies on the deleted entry (recursively)
 	UdnLogLevel(nil, log_trace, "Delete entry: collection name: %v, record_id: %v, options: %v\n", collection_name, record_id, options)
 
+	// The stand-in has no schema tables to find dependencies in, it only deletes the record
+	if stand_in := GetDatasources(options).StandIn; stand_in != nil {
+		return stand_in.Delete(collection_name, record_id, options)
+	}
+
 	_, datasource_database, _ := GetDatasourceInstance(options)
 
+	// The schema tables and dependencies are in the same datasources
+	base_options := _DatamanBaseOptions(options)
+
 	var record map[string]interface{}
 
 	// Find the schema_id
 	schema_filter := DatamanFormat("{'name':['=', '%s']}", datasource_database)
-	schema_result := DatamanFilterFull("schema", schema_filter, make(map[string]interface{}))
+	schema_result := DatamanFilterFull("schema", schema_filter, base_options)
 	var schema_id int64
 
 	if len(schema_result) > 0 {
@@ -795,7 +891,7 @@ func DatamanDelete(collection_name string, record_id int64, options map[string]i
 
 	// Find the table_id
 	table_filter := DatamanFormat("[{'name':['=', '%s']}, 'AND', {'schema_id':['=', '%d']}]", collection_name, schema_id)
-	table_result := DatamanFilterFull("schema_table", table_filter, make(map[string]interface{}))
+	table_result := DatamanFilterFull("schema_table", table_filter, base_options)
 	var table_id int64
 
 	if len(table_result) > 0 {
@@ -807,7 +903,7 @@ func DatamanDelete(collection_name string, record_id int64, options map[string]i
 
 	// For the given entry, check if there are any dependencies
 	dependency_list := make([]map[string]interface{}, 0, 10)
-	FindDeleteDependency(schema_id, table_id, record_id, &dependency_list)
+	FindDeleteDependency(schema_id, table_id, record_id, &dependency_list, base_options)
 
 	UdnLogLevel(nil, log_debug, "\nDependency List: %v\n\n", dependency_list)
 
@@ -818,13 +914,13 @@ func DatamanDelete(collection_name string, record_id int64, options map[string]i
 
 			if (dependency_list[i]["delete"].(bool)) {
 				// Delete the dependent entry
-				DatamanDeleteRaw(dependent_table_name, dependency_list[i]["record_id"].(int64), make(map[string]interface{}))
+				DatamanDeleteRaw(dependent_table_name, dependency_list[i]["record_id"].(int64), base_options)
 			} else {
 				// NULL the entry's FK reference
 				dependent_table_field_name := dependency_list[i]["table_field_name"].(string)
 				dependent_record_id := dependency_list[i]["record_id"].(int64)
 
-				DatamanNullRaw(dependent_table_name, dependent_table_field_name, dependent_record_id, make(map[string]interface{}))
+				DatamanNullRaw(dependent_table_name, dependent_table_field_name, dependent_record_id, base_options)
 			}
 		}
 	}
@@ -839,7 +935,7 @@ func DatamanDelete(collection_name string, record_id int64, options map[string]i
 // Add function when necessary - currently UDN_DataDeleteFilter runs DatamanDelete on each entry in the filtered list
 //}
 
-func FindDeleteDependency(schema_id int64, schema_table_id int64, record_id int64, dependency_list *[]map[string]interface{}) {
+func FindDeleteDependency(schema_id int64, schema_table_id int64, record_id int64, dependency_list *[]map[string]interface{}, options map[string]interface{}) {
 	// Dependencies are formatted as a array of map[string]interface{}
 	// Fields in the map
 	// "schema_name" - string
@@ -852,7 +948,7 @@ func FindDeleteDependency(schema_id int64, schema_table_id int64, record_id int6
 
 	// Find the _id for schema_table_field PK
 	table_field_filter := DatamanFormat("[{'schema_table_id':['=', '%d']}, 'AND', {'name': ['=', '_id']}]", schema_table_id)
-	table_field_result := DatamanFilterFull("schema_table_field", table_field_filter, make(map[string]interface{}))
+	table_field_result := DatamanFilterFull("schema_table_field", table_field_filter, options)
 	var table_field_id int64
 
 	// If the field exists, look for dependent fields
@@ -861,7 +957,7 @@ func FindDeleteDependency(schema_id int64, schema_table_id int64, record_id int6
 
 		// Find all dependent fields from foreign_key_schema_table_field_id
 		dependent_table_field_filter := DatamanFormat("{'foreign_key_schema_table_field_id':['=', '%d']}", table_field_id)
-		dependent_table_field_result := DatamanFilterFull("schema_table_field", dependent_table_field_filter, make(map[string]interface{}))
+		dependent_table_field_result := DatamanFilterFull("schema_table_field", dependent_table_field_filter, options)
 
 		// For each dependent field - find the dependent table, look for dependent entries, and add them to the dependency list
 		for _, dependent_table_field := range dependent_table_field_result {
@@ -870,7 +966,7 @@ func FindDeleteDependency(schema_id int64, schema_table_id int64, record_id int6
 			dependent_entry_name := dependent_table_field["name"]
 
 			dependent_table_filter := DatamanFormat("{'_id':['=', '%d']}", dependent_table_id)
-			dependent_table_result := DatamanFilterFull("schema_table", dependent_table_filter, make(map[string]interface{}))
+			dependent_table_result := DatamanFilterFull("schema_table", dependent_table_filter, options)
 			dependent_table_name := ""
 
 			if len(dependent_table_result) > 0 {
@@ -879,18 +975,18 @@ func FindDeleteDependency(schema_id int64, schema_table_id int64, record_id int6
 
 			// Look in the dependent table and look for dependent entries that need to be NULL/deleted
 			dependent_entry_filter := DatamanFormat("{'%s':['=', '%d']}", dependent_entry_name, record_id)
-			dependent_entry_result := DatamanFilterFull(dependent_table_name, dependent_entry_filter, make(map[string]interface{}))
+			dependent_entry_result := DatamanFilterFull(dependent_table_name, dependent_entry_filter, options)
 
 			// Check the delete_dependency table for the action to be performed on the dependent entries (NULL/deleted)
 			delete_dependency_filter := DatamanFormat("[{'delete_schema_table_field_id':['=', '%d']}, 'AND', {'schema_table_id':['=','%d']}]", table_field_id, dependent_table_id)
-			delete_dependency_result := DatamanFilterFull("schema_table_delete_dependency", delete_dependency_filter, make(map[string]interface{}))
+			delete_dependency_result := DatamanFilterFull("schema_table_delete_dependency", delete_dependency_filter, options)
 
 			// Set the flag for either delete or NULL dependent entries
 			delete := len(delete_dependency_result) > 0
 
 			// Find the schema name
 			schema_filter := DatamanFormat("{'_id':['=', '%d']}", schema_id)
-			schema_result := DatamanFilterFull("schema", schema_filter, make(map[string]interface{}))
+			schema_result := DatamanFilterFull("schema", schema_filter, options)
 			schema_name := ""
 
 			if len(schema_result) > 0 {
@@ -926,7 +1022,7 @@ func FindDeleteDependency(schema_id int64, schema_table_id int64, record_id int6
 					dependent_record_id := dependent_entry["_id"].(int64)
 
 					if !seen_record_id[dependent_record_id] {
-						FindDeleteDependency(schema_id, dependent_table_id, dependent_record_id, dependency_list)
+						FindDeleteDependency(schema_id, dependent_table_id, dependent_record_id, dependency_list, options)
 					}
 				}
 			}
@@ -937,6 +1033,10 @@ func FindDeleteDependency(schema_id int64, schema_table_id int64, record_id int6
 func DatamanDeleteRaw(collection_name string, record_id int64, options map[string]interface{}) map[string]interface{}{
 	// Used for deleting the a single entry with no other FK dependent on the deleted entry
 	// If there are other FK(s) dependent on the deleted entry, please use DatamanDelete
+	if stand_in := GetDatasources(options).StandIn; stand_in != nil {
+		return stand_in.Delete(collection_name, record_id, options)
+	}
+
 	datasource_instance, datasource_database, selected_db := GetDatasourceInstance(options)
 
 	delete_map := map[string]interface{} {
@@ -950,7 +1050,7 @@ func DatamanDeleteRaw(collection_name string, record_id int64, options map[strin
 
 	dataman_query := &query.Query{query.Delete, delete_map}
 
-	result := datasource_instance.HandleQuery(context.Background(), dataman_query)
+	result := datasource_instance.HandleQuery(_DatamanContext(options), dataman_query)
 
 	record := make(map[string]interface{})
 
@@ -1407,6 +1507,13 @@ func SanitizeSQL(text string) string {
 }
 
 func InitDataman(database_config DatabaseConfig, databases map[string]DatabaseConfig) {
+	DefaultDatasources.Init(database_config, databases)
+
+	DefaultDatabaseTarget = DefaultDatasources.DefaultTarget
+}
+
+// Connect to the default database, and the other databases
+func (datasources *Datasources) Init(database_config DatabaseConfig, databases map[string]DatabaseConfig) {
 	configfile := database_config.Schema
 
 	datasource, config, err := InitDatamanDatabase(database_config)
@@ -1415,19 +1522,19 @@ func InitDataman(database_config DatabaseConfig, databases map[string]DatabaseCo
 	}
 
 	//TODO(g): Fix this, as this hardcodes everything to one.  Simple in the beginning, but maybe not useful now.  Maybe just the default?
-	DefaultDatabaseTarget = database_config.Database
+	datasources.DefaultTarget = database_config.Database
 
 	// Add this DB as the _default, because it is our default
-	DatasourceInstance["_default"] = datasource
-	DatasourceConfig["_default"] = config
-	DatasourceDatabase["_default"] = database_config.Database
-	AllDatabaseConfig["_default"] = database_config
+	datasources.Instance["_default"] = datasource
+	datasources.Config["_default"] = config
+	datasources.Database["_default"] = database_config.Database
+	datasources.DatabaseConfig["_default"] = database_config
 
 	// Also add this DB under it's own name, so that we can access it both ways
-	DatasourceInstance[database_config.Name] = datasource
-	DatasourceConfig[database_config.Name] = config
-	DatasourceDatabase[database_config.Name] = database_config.Database
-	AllDatabaseConfig[database_config.Name] = database_config
+	datasources.Instance[database_config.Name] = datasource
+	datasources.Config[database_config.Name] = config
+	datasources.Database[database_config.Name] = database_config.Database
+	datasources.DatabaseConfig[database_config.Name] = database_config
 
 
 	// Initialize all our secondary databases
@@ -1438,10 +1545,10 @@ func InitDataman(database_config DatabaseConfig, databases map[string]DatabaseCo
 			panic(fmt.Sprintf("Load schema configuration data: %s: %s", database_data.Schema, err.Error()))
 		}
 
-		DatasourceInstance[database_data.Name] = datasource
-		DatasourceConfig[database_data.Name] = config
-		DatasourceDatabase[database_data.Name] = database_data.Database
-		AllDatabaseConfig[database_data.Name] = database_data
+		datasources.Instance[database_data.Name] = datasource
+		datasources.Config[database_data.Name] = config
+		datasources.Database[database_data.Name] = database_data.Database
+		datasources.DatabaseConfig[database_data.Name] = database_data
 	}
 
 }
@@ -1497,27 +1604,21 @@ func ValidateField(database string, table string, record_pkey string, field_name
 
 
 
-func GetDatasource(record_id int64) map[string]interface{} {
-	options := make(map[string]interface{})
-
+func GetDatasource(record_id int64, options map[string]interface{}) map[string]interface{} {
 	//TODO(g): Cache these
 	result_map := DatamanGet("datasource", int(record_id), options)
 
 	return result_map
 }
 
-func GetSchema(record_id int64) map[string]interface{} {
-	options := make(map[string]interface{})
-
+func GetSchema(record_id int64, options map[string]interface{}) map[string]interface{} {
 	//TODO(g): Cache these
 	result_map := DatamanGet("schema", int(record_id), options)
 
 	return result_map
 }
 
-func GetSchemaTable(record_id int64) map[string]interface{} {
-	options := make(map[string]interface{})
-
+func GetSchemaTable(record_id int64, options map[string]interface{}) map[string]interface{} {
 	//TODO(g): Cache these
 	result_map := DatamanGet("schema_table", int(record_id), options)
 
@@ -1525,9 +1626,7 @@ func GetSchemaTable(record_id int64) map[string]interface{} {
 }
 
 
-func GetSchemaTableField(record_id int64) map[string]interface{} {
-	options := make(map[string]interface{})
-
+func GetSchemaTableField(record_id int64, options map[string]interface{}) map[string]interface{} {
 	//TODO(g): Cache these
 	result_map := DatamanGet("schema_table_field", int(record_id), options)
 
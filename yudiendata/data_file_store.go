@@ -0,0 +1,364 @@
+package yudiendata
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/ghowland/yudien/yudiencore"
+	. "github.com/ghowland/yudien/yudienutil"
+)
+
+// Stands in for the Dataman datasources, so UDN can be executed without Postgres (ex: the udn-run CLI, and tests).  When the StandIn of the datasources is set, DatamanGet, DatamanSet, DatamanInsert, DatamanFilter, DatamanFilterFull and DatamanDelete use it instead of the configured datasources.
+//NOTE(g): Raw SQL (Query, __query) still needs a database
+type DatamanStore interface {
+	Get(collection_name string, record_id int64, options map[string]interface{}) map[string]interface{}
+	Set(collection_name string, record map[string]interface{}, options map[string]interface{}) map[string]interface{}
+	Filter(collection_name string, filter interface{}, options map[string]interface{}) []map[string]interface{}
+	Delete(collection_name string, record_id int64, options map[string]interface{}) map[string]interface{}
+}
+
+// A DatamanStore that keeps its records in memory, loaded from a JSON fixture file: {"collection_name": [{"_id": 1, ...}, ...], ...}.  Changes are only written back to the file with Save.
+//NOTE(g): All databases (options["db"]) share the same collections.  Joins and time_range filter options are not supported.
+type DatamanFileStore struct {
+	Path        string
+	Collections map[string][]map[string]interface{}
+
+	lock sync.Mutex
+}
+
+// Load a fixture file.  An empty path starts with no records.
+func NewDatamanFileStore(path string) (*DatamanFileStore, error) {
+	store := &DatamanFileStore{Path: path, Collections: make(map[string][]map[string]interface{})}
+
+	if path == "" {
+		return store, nil
+	}
+
+	fixture_json, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fixture := make(map[string][]map[string]interface{})
+	err = json.Unmarshal(fixture_json, &fixture)
+	if err != nil {
+		return nil, fmt.Errorf("Dataman File Store: %s: %s", path, err)
+	}
+
+	for collection_name, records := range fixture {
+		for _, record := range records {
+			JsonIntegers(record)
+		}
+
+		store.Collections[collection_name] = records
+	}
+
+	return store, nil
+}
+
+// Write the records back to the fixture file
+func (store *DatamanFileStore) Save() error {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	fixture_json, err := json.MarshalIndent(store.Collections, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(store.Path, fixture_json, 0644)
+}
+
+func (store *DatamanFileStore) Get(collection_name string, record_id int64, options map[string]interface{}) map[string]interface{} {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	UdnLogLevel(nil, log_debug, "Dataman File Store: Get: %s: %d\n", collection_name, record_id)
+
+	index := store._FindRecord(collection_name, record_id)
+	if index == -1 {
+		return nil
+	}
+
+	record := DeepCopy(store.Collections[collection_name][index]).(map[string]interface{})
+	record["_record_label"] = _DatamanFileStoreLabel(options, collection_name, record_id)
+
+	if record["_is_deleted"] == true && options["ignore_tombstones"] != nil && options["ignore_tombstones"] != true {
+		record["_error"] = "This record has been deleted with a Tombstone: _is_deleted = true"
+	} else if record["_is_secret"] == true && options["expose_secrets"] != true {
+		record["_error"] = "This record is secret"
+	}
+
+	return record
+}
+
+// Updates the fields of an existing record, or inserts a new record if it has no _id (or a negative _id, like DatamanSet), or there is no record with its _id
+func (store *DatamanFileStore) Set(collection_name string, record map[string]interface{}, options map[string]interface{}) map[string]interface{} {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	UdnLogLevel(nil, log_debug, "Dataman File Store: Set: %s: %v\n", collection_name, record)
+
+	record = DeepCopy(record).(map[string]interface{})
+	delete(record, "_record_label")
+	delete(record, "_defaults")
+
+	record_id := int64(-1)
+	if record["_id"] != nil && record["_id"] != "" && record["_id"] != "<nil>" {
+		record_id = GetResult(record["_id"], type_int).(int64)
+	}
+
+	index := -1
+	if record_id >= 0 {
+		index = store._FindRecord(collection_name, record_id)
+	}
+
+	if index == -1 {
+		// New records get the next _id, unless they have one that isnt used yet
+		if record_id < 0 {
+			record_id = 1
+			for _, existing_record := range store.Collections[collection_name] {
+				if existing_id := GetResult(existing_record["_id"], type_int).(int64); existing_id >= record_id {
+					record_id = existing_id + 1
+				}
+			}
+		}
+
+		record["_id"] = record_id
+		store.Collections[collection_name] = append(store.Collections[collection_name], record)
+	} else {
+		record["_id"] = record_id
+
+		existing_record := store.Collections[collection_name][index]
+		for key, value := range record {
+			existing_record[key] = value
+		}
+		record = existing_record
+	}
+
+	result := DeepCopy(record).(map[string]interface{})
+	result["_record_label"] = _DatamanFileStoreLabel(options, collection_name, record_id)
+
+	return result
+}
+
+// Returns the records matching a Dataman filter: {field: value}, {field: [operator, value]}, or a list of filters joined with "AND" or "OR", ex: [{field1: value1}, "AND", {field2: ["<", value2]}].  options "sort" (field names) and "limit" are supported.
+func (store *DatamanFileStore) Filter(collection_name string, filter interface{}, options map[string]interface{}) []map[string]interface{} {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	UdnLogLevel(nil, log_debug, "Dataman File Store: Filter: %s: %v\n", collection_name, filter)
+
+	result := make([]map[string]interface{}, 0)
+
+	for _, record := range store.Collections[collection_name] {
+		if record["_is_deleted"] == true && options["ignore_tombstones"] != true {
+			continue
+		}
+		if record["_is_secret"] == true && options["expose_secrets"] != true {
+			continue
+		}
+		if !_DatamanFileStoreMatch(record, filter) {
+			continue
+		}
+
+		result_record := DeepCopy(record).(map[string]interface{})
+		result_record["_record_label"] = _DatamanFileStoreLabel(options, collection_name, GetResult(record["_id"], type_int).(int64))
+
+		result = append(result, result_record)
+	}
+
+	if options["sort"] != nil {
+		sort_fields := GetResult(options["sort"], type_array).([]interface{})
+
+		sort.SliceStable(result, func(i, j int) bool {
+			for _, sort_field := range sort_fields {
+				field := fmt.Sprintf("%v", sort_field)
+
+				if compare := _DatamanFileStoreCompare(result[i][field], result[j][field]); compare != 0 {
+					return compare < 0
+				}
+			}
+			return false
+		})
+	}
+
+	if options["limit"] != nil {
+		if limit := int(GetResult(options["limit"], type_int).(int64)); limit > 0 && limit < len(result) {
+			result = result[:limit]
+		}
+	}
+
+	return result
+}
+
+func (store *DatamanFileStore) Delete(collection_name string, record_id int64, options map[string]interface{}) map[string]interface{} {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	UdnLogLevel(nil, log_debug, "Dataman File Store: Delete: %s: %d\n", collection_name, record_id)
+
+	index := store._FindRecord(collection_name, record_id)
+	if index == -1 {
+		return map[string]interface{}{"error": "Dataman Delete: Could not delete entry", "error_message": fmt.Sprintf("No %s record: %d", collection_name, record_id)}
+	}
+
+	records := store.Collections[collection_name]
+	record := records[index]
+
+	store.Collections[collection_name] = append(records[:index:index], records[index+1:]...)
+
+	record["_record_label"] = _DatamanFileStoreLabel(options, collection_name, record_id)
+
+	return record
+}
+
+// Returns the index of the record with record_id, or -1
+func (store *DatamanFileStore) _FindRecord(collection_name string, record_id int64) int {
+	for index, record := range store.Collections[collection_name] {
+		if record["_id"] != nil && GetResult(record["_id"], type_int).(int64) == record_id {
+			return index
+		}
+	}
+
+	return -1
+}
+
+func _DatamanFileStoreLabel(options map[string]interface{}, collection_name string, record_id int64) string {
+	database, ok := options["db"].(string)
+	if !ok {
+		database = "_default"
+	}
+
+	return GetRecordLabel(database, collection_name, int(record_id))
+}
+
+// Returns true if the record matches the Dataman filter
+func _DatamanFileStoreMatch(record map[string]interface{}, filter interface{}) bool {
+	switch filter := filter.(type) {
+	case nil:
+		return true
+
+	case map[string]interface{}:
+		for field, constraint := range filter {
+			if !_DatamanFileStoreMatchField(record[field], constraint) {
+				return false
+			}
+		}
+		return true
+
+	case []interface{}:
+		// Filters joined by "AND" or "OR", evaluated left to right
+		if len(filter) == 0 {
+			return true
+		}
+
+		matched := _DatamanFileStoreMatch(record, filter[0])
+
+		for index := 1; index+1 < len(filter); index += 2 {
+			if strings.ToUpper(fmt.Sprintf("%v", filter[index])) == "OR" {
+				matched = matched || _DatamanFileStoreMatch(record, filter[index+1])
+			} else {
+				matched = matched && _DatamanFileStoreMatch(record, filter[index+1])
+			}
+		}
+
+		return matched
+	}
+
+	UdnLogLevel(nil, log_error, "Dataman File Store: Unknown filter: %v\n", filter)
+	return false
+}
+
+// Constraints are a value (equal to it), or [operator, value]: =, !=, <, <=, >, >=, in, not in
+func _DatamanFileStoreMatchField(value interface{}, constraint interface{}) bool {
+	operator, operand := "=", constraint
+
+	switch constraint := constraint.(type) {
+	case []interface{}:
+		if len(constraint) == 2 {
+			operator, operand = fmt.Sprintf("%v", constraint[0]), constraint[1]
+		}
+	case []string:
+		if len(constraint) == 2 {
+			operator, operand = constraint[0], constraint[1]
+		}
+	}
+
+	switch strings.ToLower(operator) {
+	case "=":
+		return _DatamanFileStoreCompare(value, operand) == 0
+	case "!=":
+		return _DatamanFileStoreCompare(value, operand) != 0
+	case "<":
+		return _DatamanFileStoreCompare(value, operand) < 0
+	case "<=":
+		return _DatamanFileStoreCompare(value, operand) <= 0
+	case ">":
+		return _DatamanFileStoreCompare(value, operand) > 0
+	case ">=":
+		return _DatamanFileStoreCompare(value, operand) >= 0
+	case "in", "not in":
+		found := false
+		for _, item := range GetResult(operand, type_array).([]interface{}) {
+			if _DatamanFileStoreCompare(value, item) == 0 {
+				found = true
+				break
+			}
+		}
+		return found == (strings.ToLower(operator) == "in")
+	}
+
+	UdnLogLevel(nil, log_error, "Dataman File Store: Unknown filter operator: %s\n", operator)
+	return false
+}
+
+// Compare two field values: -1, 0 or 1.  Numbers are compared as numbers, even if one is a string (filters from UDN are often strings), everything else as strings.
+func _DatamanFileStoreCompare(a interface{}, b interface{}) int {
+	a_number, a_is_number := _DatamanFileStoreNumber(a)
+	b_number, b_is_number := _DatamanFileStoreNumber(b)
+
+	if a_is_number && b_is_number {
+		if a_number < b_number {
+			return -1
+		} else if a_number > b_number {
+			return 1
+		}
+		return 0
+	}
+
+	return strings.Compare(_DatamanFileStoreString(a), _DatamanFileStoreString(b))
+}
+
+func _DatamanFileStoreNumber(value interface{}) (float64, bool) {
+	switch value := value.(type) {
+	case int:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	case float64:
+		return value, true
+	case string:
+		number, err := strconv.ParseFloat(value, 64)
+		return number, err == nil
+	}
+
+	return 0, false
+}
+
+func _DatamanFileStoreString(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case time.Time:
+		return value.Format(time_format_db)
+	}
+
+	return fmt.Sprintf("%v", value)
+}
@@ -50,13 +50,12 @@ const ( // order matters for log levels
 func GetResult(input interface{}, type_value int) interface{} {
 	//fmt.Printf("GetResult: %d: %s\n", type_value, SnippetData(input, 60))
 
-	type_str := fmt.Sprintf("%T", input)
-
 	// Unwrap UdnResult, if it is wrapped
-	if type_str == "main.UdnResult" {
-		input = input.(UdnResult).Result
-	} else if type_str == "*main.UdnResult" {
-		input = input.(*UdnResult).Result
+	switch udn_result := input.(type) {
+	case UdnResult:
+		input = udn_result.Result
+	case *UdnResult:
+		input = udn_result.Result
 	}
 
 	switch type_value {
@@ -154,17 +153,18 @@ func GetResult(input interface{}, type_value int) interface{} {
 			}
 		}
 	case type_map:
-		//fmt.Printf("GetResult: Map: %s\n", type_str)
+		//fmt.Printf("GetResult: Map: %T\n", input)
 
-		// If this is already a map, return it
-		if type_str == "map[string]interface {}" {
+		switch input_value := input.(type) {
+		case map[string]interface{}:
+			// If this is already a map, return it
 			return input
-		} else if type_str == "*list.List" {
+		case *list.List:
 			// Else, if this is a list, convert the elements into a map, with keys as string indexes values ("0", "1")
 			result := make(map[string]interface{})
 
 			count := 0
-			for child := input.(*list.List).Front(); child != nil; child = child.Next() {
+			for child := input_value.Front(); child != nil; child = child.Next() {
 				count_str := strconv.Itoa(count)
 
 				// Add the index as a string, and the value to the map
@@ -173,12 +173,11 @@ func GetResult(input interface{}, type_value int) interface{} {
 			}
 
 			return result
-
-		} else if strings.HasPrefix(type_str, "[]") {
+		case []interface{}:
 			// Else, if this is an array, convert the elements into a map, with keys as string indexes values ("0", "1")
 			result := make(map[string]interface{})
 
-			for count, value := range input.([]interface{}) {
+			for count, value := range input_value {
 				count_str := strconv.Itoa(count)
 
 				// Add the index as a string, and the value to the map
@@ -186,8 +185,7 @@ func GetResult(input interface{}, type_value int) interface{} {
 			}
 
 			return result
-
-		} else {
+		default:
 			// Else, this is not a map yet, so turn it into one, of the key "value"
 			result := make(map[string]interface{})
 
@@ -198,39 +196,39 @@ func GetResult(input interface{}, type_value int) interface{} {
 			return result
 		}
 	case type_array:
-		// If this is already an array, return it as-is
-		if type_str == "[]map[string]interface {}" {
+		switch input_value := input.(type) {
+		case []map[string]interface{}:
 			new_array := make([]interface{}, 0)
-			for _, item := range input.([]map[string]interface{}) {
+			for _, item := range input_value {
 				new_array = AppendArray(new_array, item)
 			}
 			return new_array
-		} else if strings.HasPrefix(type_str, "[]string") {
+		case []string:
 			new_array := make([]interface{}, 0)
-			for _, item := range input.([]string) {
+			for _, item := range input_value {
 				new_array = AppendArray(new_array, item)
 			}
 			return new_array
-		} else if strings.HasPrefix(type_str, "[]") {
+		case []interface{}:
+			// If this is already an array, return it as-is
 			return input
-		} else if type_str == "*list.List" {
+		case *list.List:
 			// Else, if this is a List, then create an array and store all the list elements into the array
-			result := make([]interface{}, input.(*list.List).Len())
+			result := make([]interface{}, input_value.Len())
 
 			count := 0
-			for child := input.(*list.List).Front(); child != nil; child = child.Next() {
+			for child := input_value.Front(); child != nil; child = child.Next() {
 				// Add the index as a string, and the value to the map
 				result[count] = child.Value
 				count++
 			}
 			return result
-
-		} else if type_str == "map[string]interface {}" {
+		case map[string]interface{}:
 			// Else, if this is a Map, then create an array and all the key/values as a single item map, with keys: "key", "value"
-			result := make([]interface{}, len(input.(map[string]interface{})))
+			result := make([]interface{}, len(input_value))
 
 			count := 0
-			for key, value := range input.(map[string]interface{}) {
+			for key, value := range input_value {
 				// Make a tuple array
 				item := make(map[string]interface{})
 				item["key"] = key
@@ -243,9 +241,11 @@ func GetResult(input interface{}, type_value int) interface{} {
 			}
 
 			return result
-
-		} else {
-			if input != nil {
+		default:
+			if input != nil && reflect.TypeOf(input).Kind() == reflect.Slice {
+				// Any other kind of array is returned as-is
+				return input
+			} else if input != nil {
 				// Just make a single item array and stick it in
 				result := make([]interface{}, 1)
 				result[0] = input
@@ -556,6 +556,26 @@ func JsonLoadArray(text string) ([]interface{}, error) {
 	return new_array, err
 }
 
+// Convert whole numbers, which JSON decodes as float64, into int64, which is what UDN and database records use for integers.  Maps and arrays are converted in place.
+func JsonIntegers(value interface{}) interface{} {
+	switch value := value.(type) {
+	case float64:
+		if value == float64(int64(value)) {
+			return int64(value)
+		}
+	case map[string]interface{}:
+		for key, item := range value {
+			value[key] = JsonIntegers(item)
+		}
+	case []interface{}:
+		for index, item := range value {
+			value[index] = JsonIntegers(item)
+		}
+	}
+
+	return value
+}
+
 func MapListToDict(map_array []map[string]interface{}, key string) map[string]interface{} {
 	// Build a map of all our web site page widgets, so we can
 	output_map := make(map[string]interface{})
@@ -717,13 +737,7 @@ func InArray(val interface{}, array interface{}) (exists bool, index int) {
 }
 
 func DeepCopy(v interface{}) interface{} {
-    // copystructure won't take a nil, so early return
-    if v == nil {
-        return nil
-    }
-    // might save a few cycles if we test for unmutable types
-    // and return early?
-    v_copy, err := copystructure.Copy(v)
+    v_copy, err := DeepCopyChecked(v)
     if err != nil {
         fmt.Print(err)
         return v
@@ -731,6 +745,17 @@ func DeepCopy(v interface{}) interface{} {
     return v_copy
 }
 
+// Same as DeepCopy, but returns the error instead of the original value, for callers that must not share the original
+func DeepCopyChecked(v interface{}) (interface{}, error) {
+    // copystructure won't take a nil, so early return
+    if v == nil {
+        return nil, nil
+    }
+    // might save a few cycles if we test for unmutable types
+    // and return early?
+    return copystructure.Copy(v)
+}
+
 func TemplateMap(template_map map[string]interface{}, text string) string {
 	new_template := NewTextTemplateMap()
 	new_template.Map = template_map
@@ -782,99 +807,91 @@ func UseArgArrayOrFirstArgString(args []interface{}) []interface{} {
 
 
 func GetChildResult(parent interface{}, child interface{}) DynamicResult {
-	type_str := fmt.Sprintf("%T", parent)
-	//fmt.Printf("\n\nGetChildResult: %s: %s: %v\n\n", type_str, child, SnippetData(parent, 300))
+	//fmt.Printf("\n\nGetChildResult: %T: %s: %v\n\n", parent, child, SnippetData(parent, 300))
 
 	result := DynamicResult{}
 
 	// Check if the parent is an array or a map
-	if strings.HasPrefix(type_str, "[]") {
-		// Array access - check what type of array the parent is
-		switch parent.(type) {
-		case []string:
-			parent_array := parent.([]string)
-			index := GetResult(child, type_int).(int64)
+	switch parent_value := parent.(type) {
+	case map[string]interface{}:
+		child_str := GetResult(child, type_string).(string)
 
-			if index >= 0 && index < int64(len(parent_array)) {
-				result.Result = parent_array[index]
-			}
-		case []interface{}:
-			parent_array := parent.([]interface{})
-			index := GetResult(child, type_int).(int64)
+		// Map access
+		result.Result = parent_value[child_str]
+		result.Type = type_map
 
-			if index >= 0 && index < int64(len(parent_array)) {
-				result.Result = parent_array[index]
-			}
-		case []map[string]interface{}:
-			parent_array := parent.([]map[string]interface{})
-			index := GetResult(child, type_int).(int64)
+		return result
+	case []string:
+		index := GetResult(child, type_int).(int64)
 
-			if index >= 0 && index < int64(len(parent_array)) {
-				result.Result = parent_array[index]
-			}
-		default:
-			// Array type not recognized - return parent for now
-			result.Result = parent
+		if index >= 0 && index < int64(len(parent_value)) {
+			result.Result = parent_value[index]
 		}
+	case []interface{}:
+		index := GetResult(child, type_int).(int64)
 
-		result.Type = type_array
-
-		return result
+		if index >= 0 && index < int64(len(parent_value)) {
+			result.Result = parent_value[index]
+		}
+	case []map[string]interface{}:
+		index := GetResult(child, type_int).(int64)
 
-	} else {
-		child_str := GetResult(child, type_string).(string)
+		if index >= 0 && index < int64(len(parent_value)) {
+			result.Result = parent_value[index]
+		}
+	default:
+		if parent == nil || reflect.TypeOf(parent).Kind() != reflect.Slice {
+			// Map access, on something that isnt a map
+			parent_map := parent.(map[string]interface{})
+			result.Result = parent_map[GetResult(child, type_string).(string)]
+			result.Type = type_map
+			return result
+		}
 
-		// Map access
-		parent_map := parent.(map[string]interface{})
+		// Array type not recognized - return parent for now
+		result.Result = parent
+	}
 
-		result.Result = parent_map[child_str]
-		result.Type = type_map
+	result.Type = type_array
 
-		return result
-	}
+	return result
 }
 
 func SetChildResult(parent interface{}, child interface{}, value interface{}) {
-	type_str := fmt.Sprintf("%T", parent)
-	UdnLogLevel(nil, log_trace, "SetChildResult: %s: %v: %v\n\n", type_str, child, SnippetData(parent, 300))
+	UdnLogLevel(nil, log_trace, "SetChildResult: %T: %v: %v\n\n", parent, child, SnippetData(parent, 300))
 
 	// Check if the parent is an array or a map
-	if strings.HasPrefix(type_str, "[]") {
-		// Array access - check what type of array the parent is
-		switch parent.(type) {
-		case []string:
-			parent_array := parent.([]string)
-			index := GetResult(child, type_int).(int64)
-
-			if index >= 0 && index < int64(len(parent_array)) {
-				parent_array[index] = value.(string)
-			}
-		case []interface{}:
-			parent_array := parent.([]interface{})
-			index := GetResult(child, type_int).(int64)
+	switch parent_value := parent.(type) {
+	case map[string]interface{}:
+		child_str := GetResult(child, type_string).(string)
 
-			if index >= 0 && index < int64(len(parent_array)) {
-				parent_array[index] = value
-			}
-		case []map[string]interface{}:
-			parent_array := parent.([]map[string]interface{})
-			index := GetResult(child, type_int).(int64)
+		// Set the value
+		parent_value[child_str] = DeepCopy(value)
+	case []string:
+		index := GetResult(child, type_int).(int64)
 
-			if index >= 0 && index < int64(len(parent_array)) {
-				parent_array[index] = value.(map[string]interface{})
-			}
-		default:
-			// type is not recognized - do nothing for now
+		if index >= 0 && index < int64(len(parent_value)) {
+			parent_value[index] = value.(string)
 		}
+	case []interface{}:
+		index := GetResult(child, type_int).(int64)
 
-	} else {
-		child_str := GetResult(child, type_string).(string)
-
-		// Map access
-		parent_map := parent.(map[string]interface{})
+		if index >= 0 && index < int64(len(parent_value)) {
+			parent_value[index] = value
+		}
+	case []map[string]interface{}:
+		index := GetResult(child, type_int).(int64)
 
-		// Set the value
-		parent_map[child_str] = DeepCopy(value)
+		if index >= 0 && index < int64(len(parent_value)) {
+			parent_value[index] = value.(map[string]interface{})
+		}
+	default:
+		if parent == nil || reflect.TypeOf(parent).Kind() != reflect.Slice {
+			// Map access, on something that isnt a map
+			child_str := GetResult(child, type_string).(string)
+			parent.(map[string]interface{})[child_str] = DeepCopy(value)
+		}
+		// Else, array type is not recognized - do nothing for now
 	}
 }
 
@@ -1050,9 +1067,6 @@ func TemplateShortFromMap(template_string string, template_map map[string]interf
 
 
 func TemplateFromMap(template_string string, template_map map[string]interface{}) string {
-
-	template_string = strings.Replace(template_string, "\\", "", -1)
-
 	UdnLogLevel(nil, log_trace, "String Template From Value: Template Input: Post Conversion Input: %v\n\n", SnippetData(template_map, 600))
 
 